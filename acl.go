@@ -0,0 +1,228 @@
+package rdf2go
+
+import (
+	"sort"
+	"strings"
+)
+
+// AccessMode is an access mode of Web Access Control, such as ACLRead
+type AccessMode string
+
+// Access modes of Web Access Control, also used by ACP policies
+const (
+	ACLRead    AccessMode = nsACL + "Read"
+	ACLWrite   AccessMode = nsACL + "Write"
+	ACLAppend  AccessMode = nsACL + "Append"
+	ACLControl AccessMode = nsACL + "Control"
+)
+
+// Authorization describes an acl:Authorization of a Web Access Control
+// document. All lists are sorted.
+type Authorization struct {
+	URI          string
+	Agents       []string
+	AgentClasses []string
+	AgentGroups  []string
+	// AccessTo holds the resources the authorization applies to
+	AccessTo []string
+	// Default holds the containers whose members the authorization applies to
+	Default []string
+	Modes   []AccessMode
+}
+
+// Authorizations reads the acl:Authorization nodes of the graph, sorted by
+// URI
+func (g *Graph) Authorizations() []*Authorization {
+	var auths []*Authorization
+	for _, t := range g.All(nil, NewResource(nsRDF+"type"), NewResource(nsACL+"Authorization")) {
+		s := t.Subject
+		a := &Authorization{
+			URI:          s.RawValue(),
+			Agents:       g.resourceValues(s, nsACL+"agent"),
+			AgentClasses: g.resourceValues(s, nsACL+"agentClass"),
+			AgentGroups:  g.resourceValues(s, nsACL+"agentGroup"),
+			AccessTo:     g.resourceValues(s, nsACL+"accessTo"),
+			Default:      g.resourceValues(s, nsACL+"default"),
+		}
+		for _, mode := range g.resourceValues(s, nsACL+"mode") {
+			a.Modes = append(a.Modes, AccessMode(mode))
+		}
+		auths = append(auths, a)
+	}
+	sort.Slice(auths, func(i, j int) bool { return auths[i].URI < auths[j].URI })
+	return auths
+}
+
+// IsAllowed returns whether the graph, holding Web Access Control
+// authorizations or ACP access control resources, grants an agent the given
+// access mode to a resource. The agent is a WebID, or is empty for an
+// unauthenticated agent. Write access implies Append access. Authorizations
+// with acl:default, and ACP policies applied with acp:memberAccessControl,
+// apply to the resources inside the container. Groups are only resolved
+// from the vcard:hasMember statements of the graph itself.
+func (g *Graph) IsAllowed(agent string, mode AccessMode, resource string) bool {
+	for _, a := range g.Authorizations() {
+		if a.appliesTo(resource) && a.grants(mode) && g.matchesAgent(a, agent) {
+			return true
+		}
+	}
+	return g.acpAllows(agent, mode, resource)
+}
+
+// appliesTo returns whether an authorization applies to a resource
+func (a *Authorization) appliesTo(resource string) bool {
+	for _, r := range a.AccessTo {
+		if r == resource {
+			return true
+		}
+	}
+	for _, c := range a.Default {
+		if inContainer(resource, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// grants returns whether an authorization grants an access mode
+func (a *Authorization) grants(mode AccessMode) bool {
+	return grantsMode(a.Modes, mode)
+}
+
+// matchesAgent returns whether an authorization is given to an agent
+func (g *Graph) matchesAgent(a *Authorization, agent string) bool {
+	for _, class := range a.AgentClasses {
+		switch {
+		case class == nsFOAF+"Agent":
+			return true
+		case class == nsACL+"AuthenticatedAgent" && len(agent) > 0:
+			return true
+		}
+	}
+	if len(agent) == 0 {
+		return false
+	}
+	for _, a := range a.Agents {
+		if a == agent {
+			return true
+		}
+	}
+	for _, group := range a.AgentGroups {
+		if g.One(NewResource(group), NewResource(nsVCard+"hasMember"), NewResource(agent)) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// acpAllows returns whether the ACP policies applying to a resource allow an
+// access mode to an agent, and none of them denies it
+func (g *Graph) acpAllows(agent string, mode AccessMode, resource string) bool {
+	allowed := false
+	for _, acr := range g.All(nil, NewResource(nsACP+"resource"), nil) {
+		target := acr.Object.RawValue()
+		predicates := []string{}
+		if target == resource {
+			predicates = append(predicates, nsACP+"accessControl")
+		}
+		if inContainer(resource, target) {
+			predicates = append(predicates, nsACP+"memberAccessControl")
+		}
+		for _, p := range predicates {
+			for _, ac := range g.All(acr.Subject, NewResource(p), nil) {
+				for _, policy := range g.All(ac.Object, NewResource(nsACP+"apply"), nil) {
+					if !g.acpPolicyMatches(policy.Object, agent) {
+						continue
+					}
+					if grantsMode(g.accessModes(policy.Object, nsACP+"deny"), mode) {
+						return false
+					}
+					if grantsMode(g.accessModes(policy.Object, nsACP+"allow"), mode) {
+						allowed = true
+					}
+				}
+			}
+		}
+	}
+	return allowed
+}
+
+// acpPolicyMatches returns whether all the matchers of acp:allOf, at least
+// one of acp:anyOf and none of acp:noneOf match an agent. A policy without
+// acp:allOf nor acp:anyOf never matches.
+func (g *Graph) acpPolicyMatches(policy Term, agent string) bool {
+	allOf := g.All(policy, NewResource(nsACP+"allOf"), nil)
+	anyOf := g.All(policy, NewResource(nsACP+"anyOf"), nil)
+	if len(allOf) == 0 && len(anyOf) == 0 {
+		return false
+	}
+	for _, m := range allOf {
+		if !g.acpMatcherMatches(m.Object, agent) {
+			return false
+		}
+	}
+	if len(anyOf) > 0 {
+		found := false
+		for _, m := range anyOf {
+			if g.acpMatcherMatches(m.Object, agent) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, m := range g.All(policy, NewResource(nsACP+"noneOf"), nil) {
+		if g.acpMatcherMatches(m.Object, agent) {
+			return false
+		}
+	}
+	return true
+}
+
+// acpMatcherMatches returns whether one of the acp:agent values of a matcher
+// matches an agent
+func (g *Graph) acpMatcherMatches(matcher Term, agent string) bool {
+	for _, t := range g.All(matcher, NewResource(nsACP+"agent"), nil) {
+		switch t.Object.RawValue() {
+		case nsACP + "PublicAgent":
+			return true
+		case nsACP + "AuthenticatedAgent":
+			if len(agent) > 0 {
+				return true
+			}
+		case agent:
+			if len(agent) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// accessModes returns the access modes that are values of p for s
+func (g *Graph) accessModes(s Term, p string) []AccessMode {
+	var modes []AccessMode
+	for _, t := range g.All(s, NewResource(p), nil) {
+		modes = append(modes, AccessMode(t.Object.RawValue()))
+	}
+	return modes
+}
+
+// grantsMode returns whether the modes include mode, Write including Append
+func grantsMode(modes []AccessMode, mode AccessMode) bool {
+	for _, m := range modes {
+		if m == mode || m == ACLWrite && mode == ACLAppend {
+			return true
+		}
+	}
+	return false
+}
+
+// inContainer returns whether a resource is inside a container, directly or
+// not
+func inContainer(resource string, container string) bool {
+	return strings.HasSuffix(container, "/") && len(resource) > len(container) &&
+		strings.HasPrefix(resource, container)
+}
@@ -0,0 +1,71 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAllowedWAC(t *testing.T) {
+	g := NewGraph("https://pod.example/docs/.acl")
+	require.NoError(t, g.Parse(strings.NewReader(`@prefix acl: <http://www.w3.org/ns/auth/acl#> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix vcard: <http://www.w3.org/2006/vcard/ns#> .
+<#owner> a acl:Authorization ;
+  acl:agent <https://alice.example/card#me> ;
+  acl:accessTo <https://pod.example/docs/> ;
+  acl:default <https://pod.example/docs/> ;
+  acl:mode acl:Read, acl:Write, acl:Control .
+<#public> a acl:Authorization ;
+  acl:agentClass foaf:Agent ;
+  acl:accessTo <https://pod.example/docs/public.ttl> ;
+  acl:mode acl:Read .
+<#team> a acl:Authorization ;
+  acl:agentGroup <#editors> ;
+  acl:default <https://pod.example/docs/> ;
+  acl:mode acl:Append .
+<#editors> vcard:hasMember <https://bob.example/card#me> .`), "text/turtle"))
+
+	auths := g.Authorizations()
+	require.Len(t, auths, 3)
+	assert.Equal(t, "https://pod.example/docs/.acl#owner", auths[0].URI)
+	assert.Equal(t, []AccessMode{ACLControl, ACLRead, ACLWrite}, auths[0].Modes)
+
+	alice := "https://alice.example/card#me"
+	bob := "https://bob.example/card#me"
+	assert.True(t, g.IsAllowed(alice, ACLWrite, "https://pod.example/docs/"))
+	assert.True(t, g.IsAllowed(alice, ACLAppend, "https://pod.example/docs/a/b.ttl"))
+	assert.False(t, g.IsAllowed(alice, ACLRead, "https://pod.example/other/"))
+	assert.True(t, g.IsAllowed("", ACLRead, "https://pod.example/docs/public.ttl"))
+	assert.False(t, g.IsAllowed("", ACLWrite, "https://pod.example/docs/public.ttl"))
+	assert.False(t, g.IsAllowed("", ACLRead, "https://pod.example/docs/private.ttl"))
+	assert.True(t, g.IsAllowed(bob, ACLAppend, "https://pod.example/docs/log.ttl"))
+	assert.False(t, g.IsAllowed(bob, ACLWrite, "https://pod.example/docs/log.ttl"))
+	assert.False(t, g.IsAllowed(bob, ACLAppend, "https://pod.example/docs/"))
+}
+
+func TestIsAllowedACP(t *testing.T) {
+	g := NewGraph("https://pod.example/docs/.acr")
+	require.NoError(t, g.Parse(strings.NewReader(`@prefix acl: <http://www.w3.org/ns/auth/acl#> .
+@prefix acp: <http://www.w3.org/ns/solid/acp#> .
+<> a acp:AccessControlResource ;
+  acp:resource <https://pod.example/docs/> ;
+  acp:accessControl [ acp:apply <#ownerPolicy> ] ;
+  acp:memberAccessControl [ acp:apply <#ownerPolicy>, <#readPolicy>, <#denyPolicy> ] .
+<#ownerPolicy> acp:allow acl:Read, acl:Write ;
+  acp:allOf [ acp:agent <https://alice.example/card#me> ] .
+<#readPolicy> acp:allow acl:Read ;
+  acp:anyOf [ acp:agent acp:AuthenticatedAgent ] .
+<#denyPolicy> acp:deny acl:Read ;
+  acp:allOf [ acp:agent <https://eve.example/card#me> ] .`), "text/turtle"))
+
+	alice := "https://alice.example/card#me"
+	assert.True(t, g.IsAllowed(alice, ACLWrite, "https://pod.example/docs/"))
+	assert.True(t, g.IsAllowed(alice, ACLAppend, "https://pod.example/docs/a.ttl"))
+	assert.True(t, g.IsAllowed("https://bob.example/card#me", ACLRead, "https://pod.example/docs/a.ttl"))
+	assert.False(t, g.IsAllowed("https://bob.example/card#me", ACLRead, "https://pod.example/docs/"))
+	assert.False(t, g.IsAllowed("", ACLRead, "https://pod.example/docs/a.ttl"))
+	assert.False(t, g.IsAllowed("https://eve.example/card#me", ACLRead, "https://pod.example/docs/a.ttl"))
+}
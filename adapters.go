@@ -0,0 +1,54 @@
+package rdf2go
+
+import (
+	rdf "github.com/deiu/gon3"
+	jsonld "github.com/linkeddata/gojsonld"
+)
+
+// AddFromGon3 adds the triples parsed by a gon3 parser to the graph, for
+// callers driving the parser themselves, e.g. with a base IRI other than the
+// URI of the graph:
+//
+//	p := rdf.NewParser(base)
+//	if _, err := p.Parse(r); err != nil { ... }
+//	g.AddFromGon3(p)
+//
+// As with Parse, blank nodes are kept apart from those of other documents,
+// and the limits and warning handler of the graph apply.
+func (g *Graph) AddFromGon3(parser *rdf.Parser) error {
+	b := newTripleBuilder()
+	if parser.Graph != nil {
+		for t := range parser.Graph.IterTriples() {
+			b.add(rdf2term(t.Subject), rdf2term(t.Predicate), rdf2term(t.Object))
+		}
+	}
+	return g.addBuilt(b)
+}
+
+// AddFromDataset adds the triples of all the graphs of a dataset produced by
+// gojsonld, e.g. with jsonld.ToRDF and custom options, to the graph. As
+// with Parse, blank nodes are kept apart from those of other documents, and
+// the limits and warning handler of the graph apply.
+func (g *Graph) AddFromDataset(ds *jsonld.Dataset) error {
+	b := newTripleBuilder()
+	for t := range ds.IterTriples() {
+		b.add(jterm2term(t.Subject), jterm2term(t.Predicate), jterm2term(t.Object))
+	}
+	return g.addBuilt(b)
+}
+
+// addBuilt checks the triples collected by a builder against the limits of
+// the graph and adds them
+func (g *Graph) addBuilt(b *tripleBuilder) error {
+	if g.warn != nil {
+		warnTriples(b.triples, g.warn)
+	}
+	if err := g.limits.checkTerms(b.triples); err != nil {
+		return err
+	}
+	if err := g.limits.checkDepth(b.triples); err != nil {
+		return err
+	}
+	g.BulkAdd(b.triples)
+	return nil
+}
@@ -0,0 +1,42 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	rdf "github.com/deiu/gon3"
+	jsonld "github.com/linkeddata/gojsonld"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFromGon3(t *testing.T) {
+	p := rdf.NewParser("http://example.org/base/")
+	_, err := p.Parse(strings.NewReader(`<#me> <http://xmlns.com/foaf/0.1/name> "Me"@en ; <http://xmlns.com/foaf/0.1/knows> [ <http://xmlns.com/foaf/0.1/name> "You" ] .`))
+	require.NoError(t, err)
+
+	g := NewGraph(testUri)
+	require.NoError(t, g.AddFromGon3(p))
+	assert.Equal(t, 3, g.Len())
+	assert.True(t, g.Contains(NewResource("http://example.org/base/#me"), NewResource(nsFOAF+"name"), NewLiteralWithLanguage("Me", "en")))
+
+	g = NewGraph(testUri)
+	g.SetLimits(Limits{MaxLiteralLength: 2})
+	assert.Error(t, g.AddFromGon3(p))
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestAddFromDataset(t *testing.T) {
+	doc, err := jsonld.ReadJSON([]byte(`{"@id": "http://example.org/#me", "http://xmlns.com/foaf/0.1/name": "Me", "http://xmlns.com/foaf/0.1/knows": {"http://xmlns.com/foaf/0.1/name": "You"}}`))
+	require.NoError(t, err)
+	ds, err := jsonld.ToRDF(doc, &jsonld.Options{})
+	require.NoError(t, err)
+
+	g := NewGraph(testUri)
+	require.NoError(t, g.AddFromDataset(ds))
+	assert.Equal(t, 3, g.Len())
+	assert.True(t, g.Contains(NewResource("http://example.org/#me"), NewResource(nsFOAF+"name"), NewLiteral("Me")))
+	knows := g.One(NewResource("http://example.org/#me"), NewResource(nsFOAF+"knows"), nil)
+	require.NotNil(t, knows)
+	assert.IsType(t, &BlankNode{}, knows.Object)
+}
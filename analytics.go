@@ -0,0 +1,121 @@
+package rdf2go
+
+import (
+	"math"
+	"sort"
+)
+
+// ScoredTerm is a term with a score, as returned by the graph analytics
+type ScoredTerm struct {
+	Term  Term
+	Score float64
+}
+
+// InDegree returns the nodes of the graph (resources and blank nodes) with
+// the number of triples having them as object, highest first
+func (g *Graph) InDegree() []ScoredTerm {
+	return g.degree(func(t *Triple) Term { return t.Object })
+}
+
+// OutDegree returns the nodes of the graph (resources and blank nodes) with
+// the number of triples having them as subject, highest first
+func (g *Graph) OutDegree() []ScoredTerm {
+	return g.degree(func(t *Triple) Term { return t.Subject })
+}
+
+func (g *Graph) degree(end func(*Triple) Term) []ScoredTerm {
+	nodes := g.nodes()
+	for triple := range g.IterTriples() {
+		if n, ok := nodes[encodeTerm(end(triple))]; ok {
+			n.Score++
+		}
+	}
+	return sortScores(nodes)
+}
+
+// nodes returns a score for each resource and blank node of the graph
+func (g *Graph) nodes() map[string]*ScoredTerm {
+	nodes := make(map[string]*ScoredTerm)
+	for triple := range g.IterTriples() {
+		for _, t := range []Term{triple.Subject, triple.Object} {
+			if _, ok := t.(*Literal); ok {
+				continue
+			}
+			if key := encodeTerm(t); nodes[key] == nil {
+				nodes[key] = &ScoredTerm{Term: t}
+			}
+		}
+	}
+	return nodes
+}
+
+// PageRank scores the nodes of the graph (resources and blank nodes) with
+// the PageRank algorithm, following the triples from subject to object, and
+// returns them highest first. The scores add up to 1. damping is usually
+// 0.85; the computation stops after the given number of iterations, or once
+// the scores have converged.
+func (g *Graph) PageRank(damping float64, iterations int) []ScoredTerm {
+	nodes := g.nodes()
+	n := float64(len(nodes))
+	if n == 0 {
+		return nil
+	}
+	links := make(map[string][]string)
+	for triple := range g.IterTriples() {
+		if _, ok := triple.Object.(*Literal); ok {
+			continue
+		}
+		s := encodeTerm(triple.Subject)
+		links[s] = append(links[s], encodeTerm(triple.Object))
+	}
+	rank := make(map[string]float64, len(nodes))
+	for key := range nodes {
+		rank[key] = 1 / n
+	}
+	for i := 0; i < iterations; i++ {
+		next := make(map[string]float64, len(nodes))
+		// the rank of nodes without links is spread over all the nodes
+		dangling := 0.0
+		for key, r := range rank {
+			if len(links[key]) == 0 {
+				dangling += r
+			}
+		}
+		for key := range nodes {
+			next[key] = (1-damping)/n + damping*dangling/n
+		}
+		for key, targets := range links {
+			share := damping * rank[key] / float64(len(targets))
+			for _, target := range targets {
+				next[target] += share
+			}
+		}
+		delta := 0.0
+		for key := range nodes {
+			delta += math.Abs(next[key] - rank[key])
+		}
+		rank = next
+		if delta < 1e-9 {
+			break
+		}
+	}
+	for key, node := range nodes {
+		node.Score = rank[key]
+	}
+	return sortScores(nodes)
+}
+
+// sortScores returns the scored terms, highest score first
+func sortScores(nodes map[string]*ScoredTerm) []ScoredTerm {
+	scores := make([]ScoredTerm, 0, len(nodes))
+	for _, node := range nodes {
+		scores = append(scores, *node)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return encodeTerm(scores[i].Term) < encodeTerm(scores[j].Term)
+	})
+	return scores
+}
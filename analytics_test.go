@@ -0,0 +1,38 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphAnalytics(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`@prefix ex: <http://example.org/> .
+ex:a ex:links ex:hub .
+ex:b ex:links ex:hub .
+ex:c ex:links ex:hub, ex:b .
+ex:hub ex:links ex:a ; ex:name "Hub" .`, "text/turtle"))
+	ex := func(name string) Term { return NewResource("http://example.org/" + name) }
+
+	in := g.InDegree()
+	assert.Len(t, in, 4)
+	assert.Equal(t, ScoredTerm{ex("hub"), 3}, in[0])
+	assert.Equal(t, ScoredTerm{ex("c"), 0}, in[3])
+	out := g.OutDegree()
+	assert.Equal(t, ScoredTerm{ex("c"), 2}, out[0])
+	assert.Equal(t, ScoredTerm{ex("hub"), 2}, out[1])
+
+	ranks := g.PageRank(0.85, 100)
+	assert.Len(t, ranks, 4)
+	assert.Equal(t, ex("hub"), ranks[0].Term)
+	assert.Equal(t, ex("a"), ranks[1].Term)
+	assert.Equal(t, ex("c"), ranks[3].Term)
+	total := 0.0
+	for _, r := range ranks {
+		total += r.Score
+	}
+	assert.InDelta(t, 1, total, 1e-9)
+	assert.Nil(t, NewGraph("").PageRank(0.85, 10))
+}
@@ -0,0 +1,100 @@
+package rdf2go
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NewBinaryLiteral returns an xsd:base64Binary or xsd:hexBinary literal
+// holding the data read from r, encoding it as it is read
+func NewBinaryLiteral(r io.Reader, datatype string) (Term, error) {
+	var b strings.Builder
+	var w io.WriteCloser
+	switch datatype {
+	case nsXSD + "base64Binary":
+		w = base64.NewEncoder(base64.StdEncoding, &b)
+	case nsXSD + "hexBinary":
+		w = nopWriteCloser{hex.NewEncoder(&b)}
+	default:
+		return nil, fmt.Errorf("%s is not a binary datatype", datatype)
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	value := b.String()
+	if datatype == nsXSD+"hexBinary" {
+		// the canonical form of xsd:hexBinary uses upper case digits
+		value = strings.ToUpper(value)
+	}
+	return NewLiteralWithDatatype(value, NewResource(datatype)), nil
+}
+
+// BinaryLiteralReader returns a reader decoding the data of an
+// xsd:base64Binary or xsd:hexBinary literal as it is read. Decoding errors
+// are returned by the reader.
+func BinaryLiteralReader(t Term) (io.Reader, error) {
+	l, ok := t.(*Literal)
+	if !ok || l.Datatype == nil {
+		return nil, fmt.Errorf("%s is not a binary literal", t)
+	}
+	switch l.Datatype.RawValue() {
+	case nsXSD + "base64Binary":
+		return base64.NewDecoder(base64.StdEncoding, strings.NewReader(stripSpaces(l.Value))), nil
+	case nsXSD + "hexBinary":
+		return hex.NewDecoder(strings.NewReader(l.Value)), nil
+	}
+	return nil, fmt.Errorf("%s is not a binary literal", t)
+}
+
+// stripSpaces removes the whitespace allowed in xsd:base64Binary values
+func stripSpaces(s string) string {
+	if !strings.ContainsAny(s, " \t\r\n") {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func init() {
+	RegisterDatatype(nsXSD+"base64Binary", &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			return base64.StdEncoding.DecodeString(stripSpaces(value))
+		},
+		Format: func(v interface{}) (string, error) {
+			b, ok := v.([]byte)
+			if !ok {
+				return "", fmt.Errorf("cannot format %T as a base64Binary", v)
+			}
+			return base64.StdEncoding.EncodeToString(b), nil
+		},
+	})
+	RegisterDatatype(nsXSD+"hexBinary", &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			return hex.DecodeString(value)
+		},
+		Format: func(v interface{}) (string, error) {
+			b, ok := v.([]byte)
+			if !ok {
+				return "", fmt.Errorf("cannot format %T as a hexBinary", v)
+			}
+			return strings.ToUpper(hex.EncodeToString(b)), nil
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package rdf2go
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryLiterals(t *testing.T) {
+	data := []byte{0, 1, 0xfe, 0xff, 'r', 'd', 'f'}
+
+	l, err := NewTypedLiteral(data, nsXSD+"base64Binary")
+	require.NoError(t, err)
+	assert.Equal(t, "AAH+/3JkZg==", l.RawValue())
+	v, err := LiteralValue(NewLiteralWithDatatype("AAH+\n/3Jk Zg==", NewResource(nsXSD+"base64Binary")))
+	require.NoError(t, err)
+	assert.Equal(t, data, v)
+
+	l, err = NewTypedLiteral(data, nsXSD+"hexBinary")
+	require.NoError(t, err)
+	assert.Equal(t, "0001FEFF726466", l.RawValue())
+	v, err = LiteralValue(NewLiteralWithDatatype("0001feff726466", NewResource(nsXSD+"hexBinary")))
+	require.NoError(t, err)
+	assert.Equal(t, data, v)
+
+	_, err = LiteralValue(NewLiteralWithDatatype("0G", NewResource(nsXSD+"hexBinary")))
+	assert.Error(t, err)
+}
+
+func TestBinaryLiteralStreaming(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10000)
+	for _, datatype := range []string{nsXSD + "base64Binary", nsXSD + "hexBinary"} {
+		l, err := NewBinaryLiteral(bytes.NewReader(data), datatype)
+		require.NoError(t, err)
+		r, err := BinaryLiteralReader(l)
+		require.NoError(t, err)
+		back, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, data, back, datatype)
+	}
+
+	_, err := NewBinaryLiteral(strings.NewReader("x"), nsXSD+"string")
+	assert.Error(t, err)
+	_, err = BinaryLiteralReader(NewLiteral("AA=="))
+	assert.Error(t, err)
+	r, err := BinaryLiteralReader(NewLiteralWithDatatype("A!==", NewResource(nsXSD+"base64Binary")))
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package rdf2go
+
+import "sort"
+
+// BlankNodes returns the blank nodes used as subject or object in the graph,
+// sorted by ID
+func (g *Graph) BlankNodes() []Term {
+	seen := make(map[string]bool)
+	var nodes []Term
+	for triple := range g.IterTriples() {
+		for _, t := range []Term{triple.Subject, triple.Object} {
+			if b, ok := t.(*BlankNode); ok && !seen[b.ID] {
+				seen[b.ID] = true
+				nodes = append(nodes, b)
+			}
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].(*BlankNode).ID < nodes[j].(*BlankNode).ID
+	})
+	return nodes
+}
+
+// BlankNodeTriples returns the triples in which the blank node is the
+// subject or the object
+func (g *Graph) BlankNodeTriples(b Term) []*Triple {
+	triples := g.All(b, nil, nil)
+	for _, triple := range g.All(nil, nil, b) {
+		if !triple.Subject.Equal(b) {
+			triples = append(triples, triple)
+		}
+	}
+	return triples
+}
+
+// IsAnonymousSafe reports whether the blank node can be written without a
+// label, nested in the description of the only triple using it as object
+// (or at the top level if none does). Blank nodes that are the object of
+// several triples, or that would end up nested in themselves through a
+// cycle, need a label.
+func (g *Graph) IsAnonymousSafe(b Term) bool {
+	if _, ok := b.(*BlankNode); !ok {
+		return false
+	}
+	refs := g.All(nil, nil, b)
+	if len(refs) > 1 {
+		return false
+	}
+	// follow the chain of blank nodes b is nested in, which must not loop
+	for seen := 0; len(refs) == 1; seen++ {
+		parent, ok := refs[0].Subject.(*BlankNode)
+		if !ok {
+			return true
+		}
+		if parent.Equal(b) || seen > g.Len() {
+			return false
+		}
+		refs = g.All(nil, nil, parent)
+	}
+	return true
+}
+
+// HasBNodeCycles reports whether blank nodes of the graph refer to each
+// other in a cycle, e.g. _:a <p> _:b . _:b <q> _:a . Serializers nesting
+// blank nodes in the description of the node referring to them, like the
+// [ ... ] syntax of Turtle or embedded node objects in JSON-LD, must write
+// the blank nodes of a cycle with a label instead.
+func (g *Graph) HasBNodeCycles() bool {
+	return len(g.CyclicBlankNodes()) > 0
+}
+
+// CyclicBlankNodes returns the blank nodes of the graph that are part of a
+// cycle of blank nodes, see HasBNodeCycles, sorted by ID
+func (g *Graph) CyclicBlankNodes() []Term {
+	edges := make(map[string][]string)
+	nodes := make(map[string]Term)
+	for triple := range g.IterTriples() {
+		s, ok := triple.Subject.(*BlankNode)
+		if !ok {
+			continue
+		}
+		o, ok := triple.Object.(*BlankNode)
+		if !ok {
+			continue
+		}
+		edges[s.ID] = append(edges[s.ID], o.ID)
+		nodes[s.ID], nodes[o.ID] = s, o
+	}
+
+	// Tarjan's strongly connected components, with an explicit stack since
+	// chains can be as long as the graph allows: the nodes of a component
+	// with more than one node, or with a node referring to itself, are on a
+	// cycle
+	type frame struct {
+		id   string
+		next int
+	}
+	index, low := make(map[string]int), make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cyclic []Term
+	visit := func(id string) {
+		index[id], low[id] = len(index), len(index)
+		stack = append(stack, id)
+		onStack[id] = true
+	}
+	for root := range edges {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		visit(root)
+		frames := []frame{{id: root}}
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			if f.next < len(edges[f.id]) {
+				child := edges[f.id][f.next]
+				f.next++
+				if _, ok := index[child]; !ok {
+					visit(child)
+					frames = append(frames, frame{id: child})
+				} else if onStack[child] && index[child] < low[f.id] {
+					low[f.id] = index[child]
+				}
+				continue
+			}
+			id := f.id
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				if parent := frames[len(frames)-1].id; low[id] < low[parent] {
+					low[parent] = low[id]
+				}
+			}
+			if low[id] != index[id] {
+				continue
+			}
+			i := len(stack) - 1
+			for stack[i] != id {
+				i--
+			}
+			component := stack[i:]
+			stack = stack[:i]
+			for _, n := range component {
+				onStack[n] = false
+			}
+			if len(component) > 1 || selfLoop(edges[id], id) {
+				for _, n := range component {
+					cyclic = append(cyclic, nodes[n])
+				}
+			}
+		}
+	}
+	sort.Slice(cyclic, func(i, j int) bool {
+		return cyclic[i].(*BlankNode).ID < cyclic[j].(*BlankNode).ID
+	})
+	return cyclic
+}
+
+// selfLoop returns whether id is among the targets of its edges
+func selfLoop(targets []string, id string) bool {
+	for _, t := range targets {
+		if t == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlankNodes(t *testing.T) {
+	g := NewGraph(testUri)
+	a, b, c, d, e := NewBlankNode("a"), NewBlankNode("b"), NewBlankNode("c"), NewBlankNode("d"), NewBlankNode("e")
+	me, p := NewResource("#me"), NewResource("#p")
+	g.AddTriple(me, p, a)
+	g.AddTriple(a, p, b)
+	g.AddTriple(me, p, c)
+	g.AddTriple(a, p, c)
+	g.AddTriple(d, p, e)
+	g.AddTriple(e, p, d)
+	g.AddTriple(b, p, NewLiteral("x"))
+
+	assert.Equal(t, []Term{a, b, c, d, e}, g.BlankNodes())
+	assert.Len(t, g.BlankNodeTriples(a), 3)
+	assert.Len(t, g.BlankNodeTriples(b), 2)
+	assert.Len(t, g.BlankNodeTriples(NewBlankNode("missing")), 0)
+
+	assert.True(t, g.IsAnonymousSafe(a))
+	assert.True(t, g.IsAnonymousSafe(b))
+	// referenced twice
+	assert.False(t, g.IsAnonymousSafe(c))
+	// cycle
+	assert.False(t, g.IsAnonymousSafe(d))
+	assert.False(t, g.IsAnonymousSafe(me))
+}
+
+func TestIsAnonymousSafeParsed(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString("<#me> <#knows> [ <#knows> [ <#name> \"x\" ] ] .", "text/turtle"))
+	for _, b := range g.BlankNodes() {
+		assert.True(t, g.IsAnonymousSafe(b))
+	}
+	g.AddTriple(g.BlankNodes()[0], NewResource("#self"), g.BlankNodes()[0])
+	assert.False(t, g.IsAnonymousSafe(g.BlankNodes()[0]))
+}
+
+func TestBNodeCycles(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString(`@prefix geo: <http://www.w3.org/2003/01/geo/wgs84_pos#> .
+<http://example.org/place> geo:location _:loc .
+_:loc geo:point _:point ; geo:lat "48.8" .
+_:point geo:within _:loc .
+_:self geo:near _:self .
+_:tree geo:part [ geo:part [ geo:lat "1" ] ] .`, "text/turtle"))
+	assert.True(t, g.HasBNodeCycles())
+	cyclic := g.CyclicBlankNodes()
+	require.Len(t, cyclic, 3)
+	within := g.One(nil, NewResource("http://www.w3.org/2003/01/geo/wgs84_pos#within"), nil)
+	near := g.One(nil, NewResource("http://www.w3.org/2003/01/geo/wgs84_pos#near"), nil)
+	assert.ElementsMatch(t, []Term{within.Subject, within.Object, near.Subject}, cyclic)
+	assert.False(t, g.IsAnonymousSafe(near.Subject))
+
+	tree := NewGraph(testUri)
+	require.NoError(t, tree.ParseString(`<http://example.org/a> <http://example.org/p> [ <http://example.org/p> [ <http://example.org/q> _:x ] ] .
+_:x <http://example.org/q> "leaf" .`, "text/turtle"))
+	assert.False(t, tree.HasBNodeCycles())
+	assert.Empty(t, tree.CyclicBlankNodes())
+}
@@ -0,0 +1,176 @@
+package rdf2go
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// GraphCache is an LRU cache of graphs keyed by document URI. Entries expire
+// after a TTL, and the least recently used ones are evicted once the cache
+// holds more than MaxEntries graphs or more than MaxBytes of estimated memory.
+// It is safe for concurrent use, and the zero value is an empty cache with no
+// limits. Graphs are stored and returned as snapshots, so changes made by
+// callers never affect the cached graphs.
+type GraphCache struct {
+	// TTL is the time after which an entry expires. Zero means never.
+	TTL time.Duration
+	// MaxEntries is the maximum number of graphs in the cache. Zero means no limit.
+	MaxEntries int
+	// MaxBytes is the maximum estimated memory of all cached graphs. Zero means no limit.
+	MaxBytes int64
+	// SkipVerify is passed to NewGraph when graphs are loaded by the cache
+	SkipVerify bool
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	size    int64
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	uri     string
+	graph   *Graph
+	size    int64
+	expires time.Time
+}
+
+// NewGraphCache creates a GraphCache with the given TTL and maximum number of entries
+func NewGraphCache(ttl time.Duration, maxEntries int) *GraphCache {
+	return &GraphCache{
+		TTL:        ttl,
+		MaxEntries: maxEntries,
+	}
+}
+
+// init prepares a zero value cache for use, with c.mu held
+func (c *GraphCache) init() {
+	if c.lru == nil {
+		c.lru = list.New()
+		c.entries = make(map[string]*list.Element)
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+}
+
+// Get returns the cached graph for the given URI, if it exists and has not expired
+func (c *GraphCache) Get(uri string) (*Graph, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	elt, ok := c.entries[defrag(uri)]
+	if !ok {
+		return nil, false
+	}
+	entry := elt.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && c.now().After(entry.expires) {
+		c.removeElement(elt)
+		return nil, false
+	}
+	c.lru.MoveToFront(elt)
+	return entry.graph.Snapshot(), true
+}
+
+// Put stores a graph in the cache under the given URI
+func (c *GraphCache) Put(uri string, g *Graph) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	uri = defrag(uri)
+	if elt, ok := c.entries[uri]; ok {
+		c.removeElement(elt)
+	}
+	entry := &cacheEntry{uri: uri, graph: g.Snapshot()}
+	if c.MaxBytes > 0 {
+		entry.size = g.EstimateMemory()
+	}
+	if c.TTL > 0 {
+		entry.expires = c.now().Add(c.TTL)
+	}
+	c.entries[uri] = c.lru.PushFront(entry)
+	c.size += entry.size
+	c.evict()
+}
+
+// Remove drops the graph cached under the given URI
+func (c *GraphCache) Remove(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	if elt, ok := c.entries[defrag(uri)]; ok {
+		c.removeElement(elt)
+	}
+}
+
+// Len returns the number of graphs in the cache
+func (c *GraphCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	return c.lru.Len()
+}
+
+// Load returns the cached graph for the given URI, fetching it with LoadURI
+// and caching it on a miss
+func (c *GraphCache) Load(uri string) (*Graph, error) {
+	return c.load(context.Background(), uri, NewGraph(defrag(uri), c.SkipVerify))
+}
+
+// load returns the cached graph for the given URI, fetching it into doc on a miss
+func (c *GraphCache) load(ctx context.Context, uri string, doc *Graph) (*Graph, error) {
+	if g, ok := c.Get(uri); ok {
+		return g, nil
+	}
+	_, err := doc.load(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	c.Put(uri, doc)
+	return doc, nil
+}
+
+// SetCache makes LoadURI (and the loaders built on it, such as LoadURIs and
+// Harvest) fetch documents through the given cache. A nil cache disables
+// caching.
+func (g *Graph) SetCache(c *GraphCache) {
+	g.cache = c
+}
+
+// loadCached loads a document through the cache of the graph, and merges it
+// into the graph
+func (g *Graph) loadCached(ctx context.Context, uri string) error {
+	if len(g.uri) == 0 {
+		g.uri = defrag(uri)
+	}
+	doc := NewGraph(defrag(uri))
+	doc.httpClient = g.httpClient
+	doc.limits = g.limits
+	doc.warn = g.warn
+	doc.parseMode = g.parseMode
+	cached, err := g.cache.load(ctx, uri, doc)
+	if err != nil {
+		return err
+	}
+	g.merge(cached)
+	g.meta = cached.meta.copy()
+	return nil
+}
+
+func (c *GraphCache) evict() {
+	for c.lru.Len() > 0 {
+		if (c.MaxEntries <= 0 || c.lru.Len() <= c.MaxEntries) &&
+			(c.MaxBytes <= 0 || c.size <= c.MaxBytes) {
+			return
+		}
+		c.removeElement(c.lru.Back())
+	}
+}
+
+func (c *GraphCache) removeElement(elt *list.Element) {
+	entry := c.lru.Remove(elt).(*cacheEntry)
+	delete(c.entries, entry.uri)
+	c.size -= entry.size
+}
@@ -0,0 +1,111 @@
+package rdf2go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphCacheLRU(t *testing.T) {
+	c := NewGraphCache(0, 2)
+	c.Put("a", NewGraph("a"))
+	c.Put("b", NewGraph("b"))
+	_, ok := c.Get("a#me")
+	assert.True(t, ok)
+
+	// b is the least recently used entry
+	c.Put("c", NewGraph("c"))
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+
+	c.Remove("a")
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestGraphCacheTTL(t *testing.T) {
+	now := time.Now()
+	c := NewGraphCache(time.Minute, 0)
+	c.now = func() time.Time { return now }
+	c.Put("a", NewGraph("a"))
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestGraphCacheMaxBytes(t *testing.T) {
+	g := NewGraph("a")
+	g.AddTriple(NewResource("a"), NewResource("b"), NewLiteral("c"))
+	c := NewGraphCache(0, 0)
+	c.MaxBytes = g.EstimateMemory()
+	c.Put("a", g)
+	assert.Equal(t, 1, c.Len())
+	c.Put("b", g)
+	assert.Equal(t, 1, c.Len())
+	_, ok := c.Get("b")
+	assert.True(t, ok)
+}
+
+func TestGraphCacheLoad(t *testing.T) {
+	uri := testServer.URL + "/foo#me"
+	c := NewGraphCache(time.Minute, 10)
+	g, err := c.Load(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.Len())
+
+	// callers get their own copy of the cached graph
+	g.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	g2, err := c.Load(testServer.URL + "/foo")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g2.Len())
+	assert.Equal(t, 1, c.Len())
+
+	_, err = c.Load(testServer.URL + "/fail")
+	assert.Error(t, err)
+}
+
+func TestGraphCacheZeroValue(t *testing.T) {
+	var c GraphCache
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	c.Put("a", NewGraph("a"))
+	assert.Equal(t, 1, c.Len())
+	c.Remove("a")
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestGraphSetCache(t *testing.T) {
+	var fetches atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fetches.Add(1)
+		w.Header().Set("Content-Type", "text/turtle")
+		w.Write([]byte(simpleTurtle))
+	}))
+	defer ts.Close()
+
+	c := NewGraphCache(time.Minute, 10)
+	for i := 0; i < 3; i++ {
+		g := NewGraph("")
+		g.SetCache(c)
+		assert.NoError(t, g.LoadURI(ts.URL+"/doc#me"))
+		assert.Equal(t, 2, g.Len())
+		assert.Equal(t, ts.URL+"/doc", g.URI())
+	}
+	assert.Equal(t, int32(1), fetches.Load())
+
+	g := NewGraph(testUri)
+	g.SetCache(c)
+	assert.NoError(t, g.LoadURIs([]string{ts.URL + "/doc", ts.URL + "/other"}, 2))
+	assert.Equal(t, int32(2), fetches.Load())
+	assert.Error(t, g.LoadURI(testServer.URL+"/fail"))
+}
@@ -0,0 +1,55 @@
+package rdf2go
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// readDeadliner is implemented by readers such as net.Conn, whose blocked
+// reads can be interrupted by moving their deadline
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// contextReader fails reading once its context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := cr.r.Read(p)
+	if err != nil && cr.ctx.Err() != nil {
+		// report the cancellation rather than the error of an interrupted
+		// read, e.g. os.ErrDeadlineExceeded
+		err = cr.ctx.Err()
+	}
+	return n, err
+}
+
+// withContext wraps r so that reading fails with the error of ctx once it
+// is done, which stops slow inputs between reads. Readers with a read
+// deadline also have a blocked read interrupted when ctx is done; the
+// returned function clears their deadline once parsing is over.
+func withContext(ctx context.Context, r io.Reader) (io.Reader, func()) {
+	if ctx.Done() == nil {
+		return r, func() {}
+	}
+	d, ok := r.(readDeadliner)
+	if !ok {
+		return &contextReader{ctx: ctx, r: r}, func() {}
+	}
+	// moving the deadline once ctx is done, rather than to the deadline of
+	// ctx, ensures the error of ctx is set when the read fails
+	stop := context.AfterFunc(ctx, func() {
+		d.SetReadDeadline(time.Now())
+	})
+	return &contextReader{ctx: ctx, r: r}, func() {
+		stop()
+		d.SetReadDeadline(time.Time{})
+	}
+}
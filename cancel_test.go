@@ -0,0 +1,72 @@
+package rdf2go
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowReader sends a triple at each read, after a delay, and never ends
+type slowReader struct {
+	delay time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	return copy(p, "<a> <b> <c> .\n"), nil
+}
+
+func TestParseContextCancelled(t *testing.T) {
+	g := NewGraph(testUri)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, mime := range []string{"text/turtle", "application/ld+json"} {
+		err := g.ParseContext(ctx, strings.NewReader(`{"@id": "http://a", "http://b": "c"}`), mime)
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Equal(t, 0, g.Len())
+
+	err := parseTurtle("<a> <b> <c> .", "", func(s Term, p Term, o Term) {}, turtleOptions{ctx: ctx})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseContextSlowReader(t *testing.T) {
+	g := NewGraph(testUri)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err := g.ParseContext(ctx, &slowReader{delay: 5 * time.Millisecond}, "text/turtle")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestParseContextConnDeadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	go server.Write([]byte("<a> <b> "))
+
+	g := NewGraph(testUri)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := g.ParseContext(ctx, client, "text/turtle")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the deadline is cleared once parsing is over
+	go server.Write([]byte("<a> <b> <c> .\n"))
+	buf := make([]byte, 32)
+	n, err := client.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "<a> <b> <c> .\n", string(buf[:n]))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	err = g.ParseContext(ctx, client, "text/turtle")
+	assert.ErrorIs(t, err, context.Canceled)
+}
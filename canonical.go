@@ -0,0 +1,107 @@
+package rdf2go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CanonicalNTriples serializes the graph to N-Triples in a canonical form:
+// blank nodes are relabeled based on the triples they appear in, and lines
+// are sorted, so that two graphs differing only by blank node labels or
+// triple order produce the same output.
+//
+// Blank nodes are labeled using a hash of their surroundings, iterated a few
+// times to take neighbouring blank nodes into account. Graphs with blank
+// nodes that cannot be told apart this way (e.g. symmetric structures) are
+// still serialized deterministically, but isomorphic graphs are not
+// guaranteed to produce identical output in that case.
+func (g *Graph) CanonicalNTriples() []byte {
+	labels := canonicalLabels(g)
+	lines := make([]string, 0, g.Len())
+	for triple := range g.IterTriples() {
+		lines = append(lines, canonicalTerm(triple.Subject, labels)+" "+
+			canonicalTerm(triple.Predicate, labels)+" "+
+			canonicalTerm(triple.Object, labels)+" .\n")
+	}
+	sort.Strings(lines)
+	// remove duplicates, which can appear when triples only differ by blank node labels
+	out := new(strings.Builder)
+	for i, line := range lines {
+		if i > 0 && line == lines[i-1] {
+			continue
+		}
+		out.WriteString(line)
+	}
+	return []byte(out.String())
+}
+
+func canonicalTerm(t Term, labels map[string]string) string {
+	if b, ok := t.(*BlankNode); ok {
+		return "_:" + labels[b.ID]
+	}
+	return encodeTerm(t)
+}
+
+// canonicalLabels computes canonical labels for all the blank nodes of the graph
+func canonicalLabels(g *Graph) map[string]string {
+	triples := make(map[string][]*Triple)
+	for triple := range g.IterTriples() {
+		if b, ok := triple.Subject.(*BlankNode); ok {
+			triples[b.ID] = append(triples[b.ID], triple)
+		}
+		if b, ok := triple.Object.(*BlankNode); ok {
+			if s, ok := triple.Subject.(*BlankNode); !ok || s.ID != b.ID {
+				triples[b.ID] = append(triples[b.ID], triple)
+			}
+		}
+	}
+
+	hashes := make(map[string]string, len(triples))
+	for id := range triples {
+		hashes[id] = ""
+	}
+	// refine the hashes with the hashes of neighbouring blank nodes
+	for round := 0; round < 4; round++ {
+		next := make(map[string]string, len(hashes))
+		for id, ts := range triples {
+			lines := make([]string, 0, len(ts))
+			for _, t := range ts {
+				lines = append(lines, hashTerm(t.Subject, id, hashes)+" "+
+					hashTerm(t.Predicate, id, hashes)+" "+hashTerm(t.Object, id, hashes))
+			}
+			sort.Strings(lines)
+			sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+			next[id] = hex.EncodeToString(sum[:])
+		}
+		hashes = next
+	}
+
+	ids := make([]string, 0, len(hashes))
+	for id := range hashes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if hashes[ids[i]] != hashes[ids[j]] {
+			return hashes[ids[i]] < hashes[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	labels := make(map[string]string, len(ids))
+	for i, id := range ids {
+		labels[id] = fmt.Sprintf("c14n%d", i)
+	}
+	return labels
+}
+
+func hashTerm(t Term, self string, hashes map[string]string) string {
+	if b, ok := t.(*BlankNode); ok {
+		if b.ID == self {
+			return "_:self"
+		}
+		return "_:" + hashes[b.ID]
+	}
+	return encodeTerm(t)
+}
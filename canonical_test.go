@@ -0,0 +1,29 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalNTriples(t *testing.T) {
+	g1 := NewGraph(testUri)
+	g1.AddTriple(NewResource("b"), NewResource("p"), NewBlankNode("x"))
+	g1.AddTriple(NewBlankNode("x"), NewResource("name"), NewLiteral("X"))
+	g1.AddTriple(NewBlankNode("y"), NewResource("name"), NewLiteral("Y"))
+	g1.AddTriple(NewResource("a"), NewResource("p"), NewResource("c"))
+
+	g2 := NewGraph(testUri)
+	g2.AddTriple(NewBlankNode("n2"), NewResource("name"), NewLiteral("Y"))
+	g2.AddTriple(NewResource("a"), NewResource("p"), NewResource("c"))
+	g2.AddTriple(NewBlankNode("n1"), NewResource("name"), NewLiteral("X"))
+	g2.AddTriple(NewResource("b"), NewResource("p"), NewBlankNode("n1"))
+
+	c1 := g1.CanonicalNTriples()
+	assert.Equal(t, string(c1), string(g2.CanonicalNTriples()))
+	assert.Contains(t, string(c1), "_:c14n")
+	assert.NotContains(t, string(c1), "_:x")
+
+	g2.AddTriple(NewBlankNode("n2"), NewResource("p"), NewBlankNode("n1"))
+	assert.NotEqual(t, string(c1), string(g2.CanonicalNTriples()))
+}
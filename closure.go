@@ -0,0 +1,36 @@
+package rdf2go
+
+// Closure returns the nodes reachable from start by following pred any
+// number of times, e.g. all the super classes of a class with
+// rdfs:subClassOf, or all the narrower concepts of a concept with
+// skos:broader and inverse set. With inverse set, pred is followed from
+// object to subject. The nodes are returned in breadth-first order, each
+// once, and start is only included if it is reachable from itself through a
+// cycle.
+func (g *Graph) Closure(start Term, pred Term, inverse bool) []Term {
+	var nodes []Term
+	seen := map[string]bool{}
+	queue := []Term{start}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		var triples []*Triple
+		if inverse {
+			triples = g.All(nil, pred, next)
+		} else {
+			triples = g.All(next, pred, nil)
+		}
+		for _, t := range triples {
+			node := t.Object
+			if inverse {
+				node = t.Subject
+			}
+			if key := encodeTerm(node); !seen[key] {
+				seen[key] = true
+				nodes = append(nodes, node)
+				queue = append(queue, node)
+			}
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,27 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClosure(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`@prefix skos: <http://www.w3.org/2004/02/skos/core#> .
+@prefix ex: <http://example.org/> .
+ex:cat skos:broader ex:feline .
+ex:feline skos:broader ex:mammal .
+ex:dog skos:broader ex:mammal .
+ex:mammal skos:broader ex:animal .
+ex:a skos:broader ex:b .
+ex:b skos:broader ex:a .`, "text/turtle"))
+	broader := NewResource("http://www.w3.org/2004/02/skos/core#broader")
+	ex := func(name string) Term { return NewResource("http://example.org/" + name) }
+
+	assert.Equal(t, []Term{ex("feline"), ex("mammal"), ex("animal")}, g.Closure(ex("cat"), broader, false))
+	assert.ElementsMatch(t, []Term{ex("mammal"), ex("feline"), ex("dog"), ex("cat")}, g.Closure(ex("animal"), broader, true))
+	assert.Equal(t, []Term{ex("b"), ex("a")}, g.Closure(ex("a"), broader, false))
+	assert.Empty(t, g.Closure(ex("animal"), broader, false))
+}
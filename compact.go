@@ -0,0 +1,71 @@
+package rdf2go
+
+// CompactReport tells what Compact changed in a graph
+type CompactReport struct {
+	// Duplicates is the number of triples removed because the graph held an
+	// equal triple, including those made equal by the other changes
+	Duplicates int
+	// StringDatatypes is the number of literals whose redundant xsd:string
+	// datatype was dropped
+	StringDatatypes int
+	// Literals is the number of literals rewritten to their canonical form,
+	// e.g. "01"^^xsd:integer to "1"^^xsd:integer, or "Hi"@EN to "Hi"@en
+	Literals int
+}
+
+// compactOptions are the normalizations applied to literals by Compact,
+// which give equivalent literals the same form
+var compactOptions = NormalizeOptions{CanonicalNumbers: true, CanonicalDates: true, LowercaseLanguage: true}
+
+// Compact cleans up a graph assembled from many sources: it drops the
+// xsd:string datatype of literals, which plain literals already have,
+// rewrites numbers, booleans, dates and language tags to their canonical
+// form so that equivalent literals become equal, and removes the triples
+// left duplicated, along with those the store already held twice. Unlike
+// NormalizeLiterals, it does not change the text of string literals.
+func (g *Graph) Compact() CompactReport {
+	var report CompactReport
+	seen := make(map[string]bool)
+	// affected holds the compacted triples replacing those removed, by key
+	affected := make(map[string]*Triple)
+	var removed []*Triple
+	for triple := range g.IterTriples() {
+		t := triple
+		if lit, ok := triple.Object.(*Literal); ok {
+			c := normalizeLiteral(lit, compactOptions)
+			if c.Datatype != nil && c.Datatype.RawValue() == nsXSD+"string" {
+				c.Datatype = nil
+				report.StringDatatypes++
+			}
+			if c.Value != lit.Value || c.Language != lit.Language {
+				report.Literals++
+			}
+			if c.Value != lit.Value || c.Language != lit.Language || c.Datatype != lit.Datatype {
+				t = NewTriple(triple.Subject, triple.Predicate, c)
+			}
+		}
+		key := tripleKey(t.Subject, t.Predicate, t.Object)
+		if seen[key] {
+			report.Duplicates++
+			if affected[key] == nil {
+				affected[key] = t
+			}
+			removed = append(removed, triple)
+			continue
+		}
+		seen[key] = true
+		if t != triple {
+			affected[key] = t
+			removed = append(removed, triple)
+		}
+	}
+	for _, t := range removed {
+		g.Remove(t)
+	}
+	for _, t := range affected {
+		if g.One(t.Subject, t.Predicate, t.Object) == nil {
+			g.Add(t)
+		}
+	}
+	return report
+}
@@ -0,0 +1,44 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	for _, g := range []*Graph{NewGraph(testUri), NewGraphWithStore(testUri, &sliceStore{})} {
+		s, p := NewResource("http://example.org/s"), NewResource("http://example.org/p")
+		integer := NewResource(nsXSD + "integer")
+		g.Add(NewTriple(s, p, NewLiteralWithDatatype("1", integer)))
+		g.Add(NewTriple(s, p, NewLiteralWithDatatype("01", integer)))
+		g.Add(NewTriple(s, p, NewLiteralWithDatatype("a", NewResource(nsXSD+"string"))))
+		g.Add(NewTriple(s, p, NewLiteralWithLanguage("Hi", "EN")))
+		g.Add(NewTriple(s, p, NewLiteralWithLanguage(" Hi", "en")))
+		g.Add(NewTriple(s, p, NewResource("http://example.org/o")))
+		g.Add(NewTriple(s, p, NewResource("http://example.org/o")))
+
+		// only the slice store holds the same triple twice
+		duplicates := 1
+		if _, ok := g.store.(*sliceStore); ok {
+			duplicates = 2
+		}
+		report := g.Compact()
+		assert.Equal(t, duplicates, report.Duplicates)
+		assert.Equal(t, 2, report.Literals)
+		assert.Equal(t, 1, report.StringDatatypes)
+		assert.Equal(t, 5, g.Len())
+		assert.Len(t, g.All(s, p, NewLiteralWithDatatype("1", integer)), 1)
+		assert.NotNil(t, g.One(s, p, NewLiteralWithLanguage("Hi", "en")))
+		assert.NotNil(t, g.One(s, p, NewLiteralWithLanguage(" Hi", "en")))
+		assert.Len(t, g.All(s, p, NewResource("http://example.org/o")), 1)
+		for triple := range g.IterTriples() {
+			if l, ok := triple.Object.(*Literal); ok && l.Value == "a" {
+				assert.Nil(t, l.Datatype)
+			}
+		}
+
+		assert.Equal(t, CompactReport{}, g.Compact())
+		assert.Equal(t, 5, g.Len())
+	}
+}
@@ -0,0 +1,193 @@
+package rdf2go
+
+import (
+	"sort"
+	"strings"
+)
+
+// ContactVocabulary selects the ontology used to write contact information
+type ContactVocabulary int
+
+const (
+	// ContactFOAF writes foaf:name, foaf:mbox, foaf:phone, etc. FOAF has no terms
+	// for postal addresses, so those are not written.
+	ContactFOAF ContactVocabulary = 1 << iota
+	// ContactVCard writes vcard:fn, vcard:hasEmail, vcard:hasTelephone,
+	// vcard:hasAddress, etc.
+	ContactVCard
+)
+
+// Contact holds the contact information of a person or organization.
+// Emails and phone numbers are stored without their mailto: and tel: schemes.
+type Contact struct {
+	Name       string
+	GivenName  string
+	FamilyName string
+	Emails     []string
+	Phones     []string
+	Addresses  []*ContactAddress
+}
+
+// ContactAddress is a postal address
+type ContactAddress struct {
+	Street     string
+	Locality   string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// Contact reads the contact information of s, looking for both FOAF and
+// vCard properties
+func (g *Graph) Contact(s Term) *Contact {
+	c := &Contact{
+		Name:       g.firstValue(s, nsVCard+"fn", nsFOAF+"name"),
+		GivenName:  g.firstValue(s, nsVCard+"given-name", nsFOAF+"givenName"),
+		FamilyName: g.firstValue(s, nsVCard+"family-name", nsFOAF+"familyName"),
+	}
+	c.Emails = g.contactValues(s, "mailto:", nsFOAF+"mbox", nsVCard+"hasEmail", nsVCard+"email")
+	c.Phones = g.contactValues(s, "tel:", nsFOAF+"phone", nsVCard+"hasTelephone", nsVCard+"tel")
+	for _, t := range g.All(s, NewResource(nsVCard+"hasAddress"), nil) {
+		c.Addresses = append(c.Addresses, &ContactAddress{
+			Street:     g.value(t.Object, nsVCard+"street-address"),
+			Locality:   g.value(t.Object, nsVCard+"locality"),
+			Region:     g.value(t.Object, nsVCard+"region"),
+			PostalCode: g.value(t.Object, nsVCard+"postal-code"),
+			Country:    g.value(t.Object, nsVCard+"country-name"),
+		})
+	}
+	return c
+}
+
+// firstValue returns the value of the first predicate for which s has one
+func (g *Graph) firstValue(s Term, predicates ...string) string {
+	for _, p := range predicates {
+		if v := g.value(s, p); len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// contactValues collects the values of the given predicates, which point
+// either directly to a mailto:/tel: IRI or literal, or to a node holding it
+// in vcard:value (as used by Solid profiles)
+func (g *Graph) contactValues(s Term, scheme string, predicates ...string) []string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, p := range predicates {
+		for _, t := range g.All(s, NewResource(p), nil) {
+			v := t.Object.RawValue()
+			if _, ok := t.Object.(*Literal); !ok && !strings.HasPrefix(v, scheme) {
+				v = g.value(t.Object, nsVCard+"value")
+			}
+			v = strings.TrimPrefix(v, scheme)
+			if len(v) > 0 && !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// AddContact writes the contact information of s using the given
+// vocabularies, e.g. ContactFOAF|ContactVCard to write both
+func (g *Graph) AddContact(s Term, c *Contact, vocab ContactVocabulary) {
+	if vocab&ContactFOAF != 0 {
+		g.addLiteral(s, nsFOAF+"name", c.Name)
+		g.addLiteral(s, nsFOAF+"givenName", c.GivenName)
+		g.addLiteral(s, nsFOAF+"familyName", c.FamilyName)
+		for _, email := range c.Emails {
+			g.addResource(s, nsFOAF+"mbox", "mailto:"+email)
+		}
+		for _, phone := range c.Phones {
+			g.addResource(s, nsFOAF+"phone", "tel:"+phone)
+		}
+	}
+	if vocab&ContactVCard != 0 {
+		g.addLiteral(s, nsVCard+"fn", c.Name)
+		g.addLiteral(s, nsVCard+"given-name", c.GivenName)
+		g.addLiteral(s, nsVCard+"family-name", c.FamilyName)
+		for _, email := range c.Emails {
+			node := NewAnonNode()
+			g.AddTriple(s, NewResource(nsVCard+"hasEmail"), node)
+			g.addResource(node, nsVCard+"value", "mailto:"+email)
+		}
+		for _, phone := range c.Phones {
+			node := NewAnonNode()
+			g.AddTriple(s, NewResource(nsVCard+"hasTelephone"), node)
+			g.addResource(node, nsVCard+"value", "tel:"+phone)
+		}
+		for _, a := range c.Addresses {
+			node := NewAnonNode()
+			g.AddTriple(s, NewResource(nsVCard+"hasAddress"), node)
+			g.addLiteral(node, nsVCard+"street-address", a.Street)
+			g.addLiteral(node, nsVCard+"locality", a.Locality)
+			g.addLiteral(node, nsVCard+"region", a.Region)
+			g.addLiteral(node, nsVCard+"postal-code", a.PostalCode)
+			g.addLiteral(node, nsVCard+"country-name", a.Country)
+		}
+	}
+}
+
+// MapContact copies the contact information of s, read from both FOAF and
+// vCard, to the given vocabulary, e.g. to add vCard terms to a FOAF profile.
+// Values already present in that vocabulary are not duplicated.
+func (g *Graph) MapContact(s Term, vocab ContactVocabulary) {
+	c := g.Contact(s)
+	if vocab&ContactFOAF != 0 {
+		present := &Contact{
+			Name:       g.value(s, nsFOAF+"name"),
+			GivenName:  g.value(s, nsFOAF+"givenName"),
+			FamilyName: g.value(s, nsFOAF+"familyName"),
+			Emails:     g.contactValues(s, "mailto:", nsFOAF+"mbox"),
+			Phones:     g.contactValues(s, "tel:", nsFOAF+"phone"),
+		}
+		g.AddContact(s, contactDiff(c, present), ContactFOAF)
+	}
+	if vocab&ContactVCard != 0 {
+		present := &Contact{
+			Name:       g.value(s, nsVCard+"fn"),
+			GivenName:  g.value(s, nsVCard+"given-name"),
+			FamilyName: g.value(s, nsVCard+"family-name"),
+			Emails:     g.contactValues(s, "mailto:", nsVCard+"hasEmail", nsVCard+"email"),
+			Phones:     g.contactValues(s, "tel:", nsVCard+"hasTelephone", nsVCard+"tel"),
+		}
+		g.AddContact(s, contactDiff(c, present), ContactVCard)
+	}
+}
+
+// contactDiff returns the names, emails and phones of c that are missing
+// from present. Addresses are only ever read from vCard, so they are left out.
+func contactDiff(c *Contact, present *Contact) *Contact {
+	diff := &Contact{}
+	if len(present.Name) == 0 {
+		diff.Name = c.Name
+	}
+	if len(present.GivenName) == 0 {
+		diff.GivenName = c.GivenName
+	}
+	if len(present.FamilyName) == 0 {
+		diff.FamilyName = c.FamilyName
+	}
+	diff.Emails = missing(c.Emails, present.Emails)
+	diff.Phones = missing(c.Phones, present.Phones)
+	return diff
+}
+
+// missing returns the values that are not found in present
+func missing(values []string, present []string) []string {
+	found := make(map[string]bool)
+	for _, p := range present {
+		found[p] = true
+	}
+	var m []string
+	for _, v := range values {
+		if !found[v] {
+			m = append(m, v)
+		}
+	}
+	return m
+}
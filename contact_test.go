@@ -0,0 +1,48 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactRead(t *testing.T) {
+	data := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix vcard: <http://www.w3.org/2006/vcard/ns#> .
+<#me> foaf:name "Alice" ;
+  foaf:mbox <mailto:alice@example.org> ;
+  vcard:hasEmail [ a vcard:Work ; vcard:value <mailto:alice@work.example> ] ;
+  vcard:hasTelephone [ vcard:value <tel:+33123> ] ;
+  vcard:hasAddress [ vcard:locality "Paris" ; vcard:country-name "France" ] .`
+	g := NewGraph(testUri)
+	assert.NoError(t, g.Parse(strings.NewReader(data), "text/turtle"))
+
+	c := g.Contact(NewResource(testUri + "#me"))
+	assert.Equal(t, "Alice", c.Name)
+	assert.Equal(t, []string{"alice@example.org", "alice@work.example"}, c.Emails)
+	assert.Equal(t, []string{"+33123"}, c.Phones)
+	assert.Equal(t, []*ContactAddress{{Locality: "Paris", Country: "France"}}, c.Addresses)
+}
+
+func TestContactWriteAndMap(t *testing.T) {
+	g := NewGraph(testUri)
+	me := NewResource(testUri + "#me")
+	g.AddContact(me, &Contact{Name: "Bob", Emails: []string{"bob@example.org"}, Phones: []string{"+1555"}}, ContactFOAF)
+	assert.NotNil(t, g.One(me, NewResource(nsFOAF+"mbox"), NewResource("mailto:bob@example.org")))
+	assert.Nil(t, g.One(me, NewResource(nsVCard+"fn"), nil))
+
+	g.MapContact(me, ContactVCard)
+	assert.NotNil(t, g.One(me, NewResource(nsVCard+"fn"), NewLiteral("Bob")))
+	email := g.One(me, NewResource(nsVCard+"hasEmail"), nil)
+	assert.NotNil(t, g.One(email.Object, NewResource(nsVCard+"value"), NewResource("mailto:bob@example.org")))
+
+	// mapping again does not duplicate values
+	n := g.Len()
+	g.MapContact(me, ContactFOAF|ContactVCard)
+	assert.Equal(t, n, g.Len())
+
+	c := g.Contact(me)
+	assert.Equal(t, []string{"bob@example.org"}, c.Emails)
+	assert.Equal(t, []string{"+1555"}, c.Phones)
+}
@@ -0,0 +1,113 @@
+package rdf2go
+
+import "sort"
+
+// Dataset is a collection of graphs, each identified by a name
+type Dataset struct {
+	graphs map[string]*Graph
+}
+
+// NewDataset creates an empty Dataset
+func NewDataset() *Dataset {
+	return &Dataset{graphs: make(map[string]*Graph)}
+}
+
+// Graph returns the graph with the given name, or nil if there is none
+func (d *Dataset) Graph(name string) *Graph {
+	return d.graphs[name]
+}
+
+// SetGraph adds a graph to the dataset, replacing the graph with the same
+// name if any
+func (d *Dataset) SetGraph(name string, g *Graph) {
+	d.graphs[name] = g
+}
+
+// Names returns the names of the graphs of the dataset, sorted
+func (d *Dataset) Names() []string {
+	names := make([]string, 0, len(d.graphs))
+	for name := range d.graphs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Len returns the number of triples in all the graphs of the dataset
+func (d *Dataset) Len() int {
+	n := 0
+	for _, g := range d.graphs {
+		n += g.Len()
+	}
+	return n
+}
+
+// Quad is a triple along with the name of the graph of a Dataset holding it
+type Quad struct {
+	Graph  string
+	Triple *Triple
+}
+
+// All returns the triples matching a pattern of S, P, O objects, where nil
+// matches anything, in each graph of the dataset, or only in the named
+// graphs if names are given. This is what GRAPH ?g { s p o } matches, e.g.
+// to find which source asserted a triple when graphs are named after it.
+// Quads are ordered by graph name, then by triple.
+func (d *Dataset) All(s Term, p Term, o Term, names ...string) []Quad {
+	if len(names) == 0 {
+		names = d.Names()
+	}
+	var quads []Quad
+	for _, name := range names {
+		g := d.graphs[name]
+		if g == nil {
+			continue
+		}
+		var triples []*Triple
+		if s == nil && p == nil && o == nil {
+			triples = g.Triples(true)
+		} else {
+			for _, t := range g.All(s, p, o) {
+				if matchTriple(t, s, p, o) {
+					triples = append(triples, t)
+				}
+			}
+			sort.Slice(triples, func(i, j int) bool { return lessTriple(triples[i], triples[j]) })
+		}
+		for _, t := range triples {
+			quads = append(quads, Quad{Graph: name, Triple: t})
+		}
+	}
+	return quads
+}
+
+// GraphsWith returns the names of the graphs of the dataset holding a
+// triple matching a pattern of S, P, O objects, where nil matches anything,
+// sorted
+func (d *Dataset) GraphsWith(s Term, p Term, o Term) []string {
+	var names []string
+	for _, name := range d.Names() {
+		if d.graphs[name].Contains(s, p, o) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// MergeWithSource merges the triples of other into the graph of the dataset
+// named after source, which is created if needed, so that each triple can
+// still be traced back to the sources asserting it with GraphsWith. The
+// name is the IRI of source, or the label of a blank node; a nil source
+// stands for other itself, named after its URI.
+func (d *Dataset) MergeWithSource(other *Graph, source Term) {
+	if source == nil {
+		source = other.Term()
+	}
+	name := source.RawValue()
+	g := d.graphs[name]
+	if g == nil {
+		g = NewGraph(name)
+		d.graphs[name] = g
+	}
+	g.Merge(other)
+}
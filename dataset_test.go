@@ -0,0 +1,55 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatasetAll(t *testing.T) {
+	d := NewDataset()
+	alice, bob := NewGraph("http://example.org/alice"), NewGraph("http://example.org/bob")
+	knows := NewResource(nsFOAF + "knows")
+	alice.AddTriple(NewResource("#a"), knows, NewResource("#b"))
+	alice.AddTriple(NewResource("#a"), knows, NewResource("#c"))
+	bob.AddTriple(NewResource("#b"), knows, NewResource("#c"))
+	bob.AddTriple(NewResource("#b"), NewResource(nsFOAF+"name"), NewLiteral("Bob"))
+	d.SetGraph("bob", bob)
+	d.SetGraph("alice", alice)
+
+	quads := d.All(nil, knows, NewResource("#c"))
+	assert.Len(t, quads, 2)
+	assert.Equal(t, "alice", quads[0].Graph)
+	assert.Equal(t, "bob", quads[1].Graph)
+	assert.True(t, quads[1].Triple.Subject.Equal(NewResource("#b")))
+
+	assert.Len(t, d.All(nil, nil, nil), 4)
+	assert.Len(t, d.All(nil, nil, nil, "bob", "carol"), 2)
+	assert.Len(t, d.All(NewResource("#a"), nil, NewResource("#c")), 1)
+
+	assert.Equal(t, []string{"alice", "bob"}, d.GraphsWith(nil, knows, NewResource("#c")))
+	assert.Equal(t, []string{"bob"}, d.GraphsWith(nil, NewResource(nsFOAF+"name"), nil))
+	assert.Empty(t, d.GraphsWith(NewResource("#z"), nil, nil))
+}
+
+func TestDatasetMergeWithSource(t *testing.T) {
+	d := NewDataset()
+	knows := NewResource(nsFOAF + "knows")
+	a, b, c := NewResource("#a"), NewResource("#b"), NewResource("#c")
+	first, second := NewGraph("http://example.org/first"), NewGraph("http://example.org/second")
+	first.AddTriple(a, knows, b)
+	second.AddTriple(a, knows, b)
+	second.AddTriple(b, knows, c)
+
+	d.MergeWithSource(first, NewResource("http://example.org/crawl"))
+	d.MergeWithSource(second, nil)
+	d.MergeWithSource(second, NewResource("http://example.org/crawl"))
+	assert.Equal(t, []string{"http://example.org/crawl", "http://example.org/second"}, d.Names())
+	assert.Equal(t, 2, d.Graph("http://example.org/crawl").Len())
+	assert.Equal(t, "<http://example.org/crawl>", d.Graph("http://example.org/crawl").Term().String())
+	assert.Equal(t, []string{"http://example.org/crawl", "http://example.org/second"}, d.GraphsWith(a, knows, b))
+	assert.Equal(t, []string{"http://example.org/crawl", "http://example.org/second"}, d.GraphsWith(b, knows, c))
+
+	d.MergeWithSource(first, NewBlankNode("upload"))
+	assert.Equal(t, []string{"http://example.org/crawl", "http://example.org/second", "upload"}, d.GraphsWith(a, knows, b))
+}
@@ -0,0 +1,306 @@
+package rdf2go
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DatatypeCodec converts the literals of a datatype to and from Go values
+type DatatypeCodec struct {
+	// Parse converts a lexical value to a Go value
+	Parse func(value string) (interface{}, error)
+	// Format converts a Go value to a lexical value
+	Format func(v interface{}) (string, error)
+	// Compare orders two values returned by Parse, returning -1, 0 or +1. It
+	// is nil when the values of the datatype are not ordered.
+	Compare func(a, b interface{}) int
+	// JSON converts a value returned by Parse to the value written as
+	// @value when serializing to JSON-LD. When it is nil, the lexical value
+	// is written as a string.
+	JSON func(v interface{}) interface{}
+}
+
+var (
+	datatypesMu sync.RWMutex
+	datatypes   = map[string]*DatatypeCodec{}
+)
+
+// RegisterDatatype sets the codec used for the literals of a datatype, e.g.
+// to support geo:wktLiteral, replacing the codec previously registered for
+// it, if any. A nil codec removes the registration.
+func RegisterDatatype(iri string, c *DatatypeCodec) {
+	datatypesMu.Lock()
+	defer datatypesMu.Unlock()
+	if c == nil {
+		delete(datatypes, iri)
+		return
+	}
+	datatypes[iri] = c
+}
+
+// LookupDatatype returns the codec registered for a datatype, or nil
+func LookupDatatype(iri string) *DatatypeCodec {
+	datatypesMu.RLock()
+	defer datatypesMu.RUnlock()
+	return datatypes[iri]
+}
+
+// literalDatatype returns the datatype IRI of a literal, which is
+// rdf:langString for literals with a language and xsd:string for plain ones
+func literalDatatype(l *Literal) string {
+	switch {
+	case len(l.Language) > 0:
+		return nsRDF + "langString"
+	case l.Datatype == nil:
+		return nsXSD + "string"
+	}
+	return l.Datatype.RawValue()
+}
+
+// LiteralValue converts a literal to a Go value with the codec of its
+// datatype. Plain literals and literals with a language are strings.
+func LiteralValue(t Term) (interface{}, error) {
+	l, ok := t.(*Literal)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a literal", t)
+	}
+	datatype := literalDatatype(l)
+	c := LookupDatatype(datatype)
+	if c == nil {
+		return nil, fmt.Errorf("no codec for datatype %s", datatype)
+	}
+	v, err := c.Parse(l.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q: %w", DefaultShortener.Shorten(datatype), l.Value, err)
+	}
+	return v, nil
+}
+
+// NewTypedLiteral returns a literal of the given datatype holding a Go value,
+// formatted with the codec of the datatype
+func NewTypedLiteral(v interface{}, datatype string) (Term, error) {
+	c := LookupDatatype(datatype)
+	if c == nil {
+		return nil, fmt.Errorf("no codec for datatype %s", datatype)
+	}
+	value, err := c.Format(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewLiteralWithDatatype(value, NewResource(datatype)), nil
+}
+
+// CompareLiterals orders two literals by value, returning -1, 0 or +1.
+// Numbers are compared across numeric datatypes; other literals must have
+// the same datatype (and language), which must be ordered.
+func CompareLiterals(a, b Term) (int, error) {
+	va, err := LiteralValue(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := LiteralValue(b)
+	if err != nil {
+		return 0, err
+	}
+	if ra, ok := numericRat(va); ok {
+		if rb, ok := numericRat(vb); ok {
+			return ra.Cmp(rb), nil
+		}
+	}
+	la, lb := a.(*Literal), b.(*Literal)
+	da, db := literalDatatype(la), literalDatatype(lb)
+	if da != db || la.Language != lb.Language {
+		return 0, fmt.Errorf("cannot compare %s and %s", a, b)
+	}
+	c := LookupDatatype(da)
+	if c.Compare == nil {
+		return 0, fmt.Errorf("values of %s are not ordered", da)
+	}
+	return c.Compare(va, vb), nil
+}
+
+// numericRat returns the exact value of a finite number
+func numericRat(v interface{}) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case float64:
+		r := new(big.Rat).SetFloat64(v)
+		return r, r != nil
+	case *big.Rat:
+		return v, true
+	}
+	return nil, false
+}
+
+var xsdDecimalRe = regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?|\.\d+)$`)
+
+// formatDecimal formats a *big.Rat, a *big.Int, an integer or a float64 as
+// an xsd:decimal. Rationals which have no finite
+// decimal representation, such as 1/3, cannot be formatted.
+func formatDecimal(v interface{}) (string, error) {
+	var r *big.Rat
+	switch v := v.(type) {
+	case *big.Rat:
+		r = v
+	case *big.Int:
+		r = new(big.Rat).SetInt(v)
+	case int:
+		r = new(big.Rat).SetInt64(int64(v))
+	case int64:
+		r = new(big.Rat).SetInt64(v)
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return "", fmt.Errorf("cannot format %v as a decimal", v)
+		}
+		// use the shortest form reading back as v, so that 0.1 is not
+		// written as the exact value of its binary approximation
+		r, _ = new(big.Rat).SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return "", fmt.Errorf("cannot format %T as a decimal", v)
+	}
+	// the denominator of a finite decimal only has 2 and 5 as prime factors
+	if _, ok := fractionDigits(r.Denom()); !ok {
+		return "", fmt.Errorf("%s has no finite decimal representation", r.RatString())
+	}
+	return canonicalDecimal(r), nil
+}
+
+// xsdIntegerTypes lists the datatypes derived from xsd:integer
+var xsdIntegerTypes = []string{"integer", "int", "long", "short", "byte", "nonNegativeInteger", "positiveInteger",
+	"nonPositiveInteger", "negativeInteger", "unsignedLong", "unsignedInt", "unsignedShort", "unsignedByte"}
+
+func init() {
+	stringCodec := &DatatypeCodec{
+		Parse:   func(value string) (interface{}, error) { return value, nil },
+		Format:  formatString,
+		Compare: func(a, b interface{}) int { return strings.Compare(a.(string), b.(string)) },
+	}
+	RegisterDatatype(nsXSD+"string", stringCodec)
+	RegisterDatatype(nsRDF+"langString", stringCodec)
+	RegisterDatatype(nsXSD+"boolean", &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			switch value {
+			case "true", "1":
+				return true, nil
+			case "false", "0":
+				return false, nil
+			}
+			return nil, fmt.Errorf("not a boolean")
+		},
+		Format: func(v interface{}) (string, error) {
+			b, ok := v.(bool)
+			if !ok {
+				return "", fmt.Errorf("cannot format %T as a boolean", v)
+			}
+			return strconv.FormatBool(b), nil
+		},
+		Compare: func(a, b interface{}) int {
+			x, y := a.(bool), b.(bool)
+			switch {
+			case x == y:
+				return 0
+			case y:
+				return -1
+			}
+			return 1
+		},
+	})
+	integerCodec := &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			return strconv.ParseInt(value, 10, 64)
+		},
+		Format: func(v interface{}) (string, error) {
+			switch v := v.(type) {
+			case int:
+				return strconv.Itoa(v), nil
+			case int64:
+				return strconv.FormatInt(v, 10), nil
+			}
+			return "", fmt.Errorf("cannot format %T as an integer", v)
+		},
+		Compare: func(a, b interface{}) int { return cmp.Compare(a.(int64), b.(int64)) },
+	}
+	for _, name := range xsdIntegerTypes {
+		RegisterDatatype(nsXSD+name, integerCodec)
+	}
+	floatCodec := &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			switch value {
+			case "INF", "+INF":
+				value = "+Inf"
+			case "-INF":
+				value = "-Inf"
+			}
+			return strconv.ParseFloat(value, 64)
+		},
+		Format: func(v interface{}) (string, error) {
+			switch v := v.(type) {
+			case float32:
+				return canonicalDouble(float64(v)), nil
+			case float64:
+				return canonicalDouble(v), nil
+			}
+			return "", fmt.Errorf("cannot format %T as a double", v)
+		},
+		Compare: func(a, b interface{}) int { return cmp.Compare(a.(float64), b.(float64)) },
+	}
+	RegisterDatatype(nsXSD+"double", floatCodec)
+	RegisterDatatype(nsXSD+"float", floatCodec)
+	RegisterDatatype(nsXSD+"decimal", &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			if !xsdDecimalRe.MatchString(value) {
+				return nil, fmt.Errorf("not a decimal")
+			}
+			r, _ := new(big.Rat).SetString(value)
+			return r, nil
+		},
+		Format:  formatDecimal,
+		Compare: func(a, b interface{}) int { return a.(*big.Rat).Cmp(b.(*big.Rat)) },
+	})
+	RegisterDatatype(nsXSD+"dateTime", timeCodec(time.RFC3339Nano, "dateTime", "2006-01-02T15:04:05"))
+	RegisterDatatype(nsXSD+"date", timeCodec("2006-01-02", "date", "2006-01-02Z07:00"))
+}
+
+func formatString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("cannot format %T as a string", v)
+}
+
+// timeCodec returns a codec for time.Time values, formatted in the given
+// layout. Values are parsed with the layout, or with one of the other given
+// layouts, e.g. for values without a time zone, which are taken to be UTC.
+func timeCodec(layout string, name string, more ...string) *DatatypeCodec {
+	return &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			t, err := time.Parse(layout, value)
+			for _, l := range more {
+				if err == nil {
+					break
+				}
+				t, err = time.Parse(l, value)
+			}
+			return t, err
+		},
+		Format: func(v interface{}) (string, error) {
+			t, ok := v.(time.Time)
+			if !ok {
+				return "", fmt.Errorf("cannot format %T as a %s", v, name)
+			}
+			return t.Format(layout), nil
+		},
+		Compare: func(a, b interface{}) int { return a.(time.Time).Compare(b.(time.Time)) },
+	}
+}
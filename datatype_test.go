@@ -0,0 +1,137 @@
+package rdf2go
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiteralValue(t *testing.T) {
+	v, err := LiteralValue(NewLiteralWithDatatype("42", NewResource(nsXSD+"integer")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+	v, err = LiteralValue(NewLiteralWithDatatype("1", NewResource(nsXSD+"boolean")))
+	require.NoError(t, err)
+	assert.Equal(t, true, v)
+	v, err = LiteralValue(NewLiteralWithDatatype("-INF", NewResource(nsXSD+"double")))
+	require.NoError(t, err)
+	assert.Equal(t, "-Inf", fmt.Sprint(v))
+	v, err = LiteralValue(NewLiteralWithDatatype("2024-03-01T12:00:00Z", NewResource(nsXSD+"dateTime")))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), v)
+	v, err = LiteralValue(NewLiteralWithLanguage("chat", "fr"))
+	require.NoError(t, err)
+	assert.Equal(t, "chat", v)
+
+	_, err = LiteralValue(NewLiteralWithDatatype("x", NewResource(nsXSD+"integer")))
+	assert.EqualError(t, err, `invalid xsd:integer value "x": strconv.ParseInt: parsing "x": invalid syntax`)
+	_, err = LiteralValue(NewLiteralWithDatatype("x", NewResource("http://example.org/unknown")))
+	assert.Error(t, err)
+	_, err = LiteralValue(NewResource("http://example.org/a"))
+	assert.Error(t, err)
+}
+
+func TestNewTypedLiteral(t *testing.T) {
+	l, err := NewTypedLiteral(1.5e10, nsXSD+"double")
+	require.NoError(t, err)
+	assert.Equal(t, `"1.5E10"^^<http://www.w3.org/2001/XMLSchema#double>`, l.String())
+	l, err = NewTypedLiteral(7, nsXSD+"int")
+	require.NoError(t, err)
+	assert.Equal(t, "7", l.RawValue())
+	_, err = NewTypedLiteral("7", nsXSD+"int")
+	assert.Error(t, err)
+}
+
+func TestCompareLiterals(t *testing.T) {
+	integer := func(v string) Term { return NewLiteralWithDatatype(v, NewResource(nsXSD+"integer")) }
+	double := func(v string) Term { return NewLiteralWithDatatype(v, NewResource(nsXSD+"double")) }
+
+	c, err := CompareLiterals(integer("10"), integer("9"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+	c, err = CompareLiterals(integer("2"), double("2.0E0"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, c)
+	c, err = CompareLiterals(NewLiteral("a"), NewLiteralWithDatatype("b", NewResource(nsXSD+"string")))
+	require.NoError(t, err)
+	assert.Equal(t, -1, c)
+	_, err = CompareLiterals(NewLiteral("a"), integer("1"))
+	assert.Error(t, err)
+	_, err = CompareLiterals(NewLiteralWithLanguage("a", "en"), NewLiteralWithLanguage("b", "fr"))
+	assert.Error(t, err)
+}
+
+func TestRegisterDatatype(t *testing.T) {
+	const wkt = "http://www.opengis.net/ont/geosparql#wktLiteral"
+	type point struct{ X, Y float64 }
+	RegisterDatatype(wkt, &DatatypeCodec{
+		Parse: func(value string) (interface{}, error) {
+			var p point
+			_, err := fmt.Sscanf(value, "POINT(%g %g)", &p.X, &p.Y)
+			return p, err
+		},
+		Format: func(v interface{}) (string, error) {
+			p := v.(point)
+			return fmt.Sprintf("POINT(%g %g)", p.X, p.Y), nil
+		},
+		JSON: func(v interface{}) interface{} {
+			p := v.(point)
+			return []float64{p.X, p.Y}
+		},
+	})
+	defer RegisterDatatype(wkt, nil)
+
+	l, err := NewTypedLiteral(point{2.35, 48.85}, wkt)
+	require.NoError(t, err)
+	v, err := LiteralValue(l)
+	require.NoError(t, err)
+	assert.Equal(t, point{2.35, 48.85}, v)
+	_, err = CompareLiterals(l, l)
+	assert.Error(t, err)
+
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource(testUri+"#paris"), NewResource("http://example.org/location"), l)
+	out, err := g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(out, `"@value":[2.35,48.85]`), out)
+}
+
+func TestDecimalLiterals(t *testing.T) {
+	decimal := func(v string) Term { return NewLiteralWithDatatype(v, NewResource(nsXSD+"decimal")) }
+	v, err := LiteralValue(decimal("12345678901234567890.123456789"))
+	require.NoError(t, err)
+	r := v.(*big.Rat)
+	sum := new(big.Rat).Add(r, big.NewRat(1, 1000000000))
+	l, err := NewTypedLiteral(sum, nsXSD+"decimal")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.12345679", l.RawValue())
+
+	for _, in := range []string{"1e3", "NaN", "1.2.3", "", "."} {
+		_, err := LiteralValue(decimal(in))
+		assert.Error(t, err, in)
+	}
+	l, err = NewTypedLiteral(0.1, nsXSD+"decimal")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", l.RawValue())
+	l, err = NewTypedLiteral(big.NewRat(3, 8), nsXSD+"decimal")
+	require.NoError(t, err)
+	assert.Equal(t, "0.375", l.RawValue())
+	_, err = NewTypedLiteral(big.NewRat(1, 3), nsXSD+"decimal")
+	assert.Error(t, err)
+
+	// both would be the same float64
+	c, err := CompareLiterals(decimal("0.30000000000000001"), decimal("0.3"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+	c, err = CompareLiterals(decimal("2.50"), NewLiteralWithDatatype("2.5E0", NewResource(nsXSD+"double")))
+	require.NoError(t, err)
+	assert.Equal(t, 0, c)
+	c, err = CompareLiterals(decimal("-3"), NewLiteralWithDatatype("-2", NewResource(nsXSD+"integer")))
+	require.NoError(t, err)
+	assert.Equal(t, -1, c)
+}
@@ -0,0 +1,108 @@
+package rdf2go
+
+import (
+	"sort"
+	"time"
+
+	"github.com/deiu/rdf2go/schema"
+)
+
+// dateDatatypes are the datatypes tried, in order, to read the dates written
+// as plain literals
+var dateDatatypes = []string{nsXSD + "dateTime", nsXSD + "date", nsXSD + "gYearMonth", nsXSD + "gYear"}
+
+// DateValues returns the values of p for s that are dates, sorted. Literals
+// typed as xsd:dateTime, xsd:date, xsd:gYearMonth or xsd:gYear are
+// converted with their codec, and plain literals are read in one of these
+// formats, as schema.org data often leaves dates untyped. Partial dates are
+// converted to their start, and dates without a time zone are taken to be
+// UTC. Other values are ignored.
+func (g *Graph) DateValues(s Term, p string) []time.Time {
+	var dates []time.Time
+	for _, t := range g.All(s, NewResource(p), nil) {
+		if d, ok := literalDate(t.Object); ok {
+			dates = append(dates, d)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// Created returns the creation date of s, from dct:created or
+// schema:dateCreated, the earliest one if there are several
+func (g *Graph) Created(s Term) (time.Time, bool) {
+	return g.firstDate(s, nsDCT+"created", schema.DateCreated)
+}
+
+// Published returns the publication date of s, from dct:issued or
+// schema:datePublished, the earliest one if there are several
+func (g *Graph) Published(s Term) (time.Time, bool) {
+	return g.firstDate(s, nsDCT+"issued", schema.DatePublished)
+}
+
+// Modified returns the last modification date of s, from dct:modified or
+// schema:dateModified, the latest one if there are several
+func (g *Graph) Modified(s Term) (time.Time, bool) {
+	var dates []time.Time
+	for _, p := range []string{nsDCT + "modified", schema.DateModified} {
+		dates = append(dates, g.DateValues(s, p)...)
+	}
+	if len(dates) == 0 {
+		return time.Time{}, false
+	}
+	latest := dates[0]
+	for _, d := range dates[1:] {
+		if d.After(latest) {
+			latest = d
+		}
+	}
+	return latest, true
+}
+
+// firstDate returns the earliest date among the values of the predicates
+func (g *Graph) firstDate(s Term, predicates ...string) (time.Time, bool) {
+	var first time.Time
+	found := false
+	for _, p := range predicates {
+		if dates := g.DateValues(s, p); len(dates) > 0 && (!found || dates[0].Before(first)) {
+			first, found = dates[0], true
+		}
+	}
+	return first, found
+}
+
+// literalDate converts a literal holding a date to a time
+func literalDate(t Term) (time.Time, bool) {
+	l, ok := t.(*Literal)
+	if !ok || len(l.Language) > 0 {
+		return time.Time{}, false
+	}
+	if l.datatype() != nil {
+		v, err := LiteralValue(l)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return dateValue(v)
+	}
+	for _, datatype := range dateDatatypes {
+		if v, err := LookupDatatype(datatype).Parse(l.Value); err == nil {
+			return dateValue(v)
+		}
+	}
+	return time.Time{}, false
+}
+
+// dateValue converts the value of a date literal to a time
+func dateValue(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case PartialDate:
+		if v.Year == 0 {
+			// gMonth, gDay and gMonthDay recur every year
+			return time.Time{}, false
+		}
+		return v.Time(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,47 @@
+package rdf2go
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateHelpers(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`@prefix dct: <http://purl.org/dc/terms/> .
+@prefix schema: <http://schema.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.org/doc> dct:created "2020-03-01T10:00:00Z"^^xsd:dateTime ;
+    schema:dateCreated "2019-12-31" ;
+    dct:issued "2020-04"^^xsd:gYearMonth ;
+    dct:modified "2021-01-01"^^xsd:date, "2021-06-15T08:30:00+02:00" ;
+    schema:dateModified "2020-05-05T00:00:00Z", "not a date", "--05"^^xsd:gMonth .
+<http://example.org/post> schema:datePublished "2018" ;
+    schema:dateModified "yesterday"@en .`, "text/turtle"))
+	doc, post := NewResource("http://example.org/doc"), NewResource("http://example.org/post")
+
+	created, ok := g.Created(doc)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC), created.UTC())
+
+	published, ok := g.Published(doc)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), published)
+
+	modified, ok := g.Modified(doc)
+	assert.True(t, ok)
+	assert.True(t, modified.Equal(time.Date(2021, 6, 15, 6, 30, 0, 0, time.UTC)))
+
+	dates := g.DateValues(doc, nsSDO+"dateModified")
+	assert.Len(t, dates, 1)
+
+	published, ok = g.Published(post)
+	assert.True(t, ok)
+	assert.Equal(t, 2018, published.Year())
+	_, ok = g.Modified(post)
+	assert.False(t, ok)
+	_, ok = g.Created(post)
+	assert.False(t, ok)
+}
@@ -0,0 +1,164 @@
+package rdf2go
+
+import (
+	"strconv"
+)
+
+// DCATCatalog describes a dcat:Catalog
+type DCATCatalog struct {
+	URI         string
+	Title       string
+	Description string
+	Datasets    []*DCATDataset
+}
+
+// DCATDataset describes a dcat:Dataset
+type DCATDataset struct {
+	URI           string
+	Title         string
+	Description   string
+	License       string
+	Distributions []*DCATDistribution
+}
+
+// DCATDistribution describes a dcat:Distribution
+type DCATDistribution struct {
+	URI         string
+	Title       string
+	License     string
+	AccessURL   string
+	DownloadURL string
+	MediaType   string
+	ByteSize    int64
+}
+
+// AddDCATCatalog adds the triples describing a catalog, its datasets and
+// their distributions to the graph. Entries without a URI are added as
+// blank nodes.
+func (g *Graph) AddDCATCatalog(c *DCATCatalog) Term {
+	s := subjectOrBlank(c.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(nsDCAT+"Catalog"))
+	g.addLiteral(s, nsDCT+"title", c.Title)
+	g.addLiteral(s, nsDCT+"description", c.Description)
+	for _, d := range c.Datasets {
+		g.AddTriple(s, NewResource(nsDCAT+"dataset"), g.AddDCATDataset(d))
+	}
+	return s
+}
+
+// AddDCATDataset adds the triples describing a dataset and its distributions
+// to the graph
+func (g *Graph) AddDCATDataset(d *DCATDataset) Term {
+	s := subjectOrBlank(d.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(nsDCAT+"Dataset"))
+	g.addLiteral(s, nsDCT+"title", d.Title)
+	g.addLiteral(s, nsDCT+"description", d.Description)
+	g.addResource(s, nsDCT+"license", d.License)
+	for _, dist := range d.Distributions {
+		g.AddTriple(s, NewResource(nsDCAT+"distribution"), g.AddDCATDistribution(dist))
+	}
+	return s
+}
+
+// AddDCATDistribution adds the triples describing a distribution to the graph
+func (g *Graph) AddDCATDistribution(d *DCATDistribution) Term {
+	s := subjectOrBlank(d.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(nsDCAT+"Distribution"))
+	g.addLiteral(s, nsDCT+"title", d.Title)
+	g.addResource(s, nsDCT+"license", d.License)
+	g.addResource(s, nsDCAT+"accessURL", d.AccessURL)
+	g.addResource(s, nsDCAT+"downloadURL", d.DownloadURL)
+	g.addLiteral(s, nsDCAT+"mediaType", d.MediaType)
+	if d.ByteSize > 0 {
+		g.AddTriple(s, NewResource(nsDCAT+"byteSize"),
+			NewLiteralWithDatatype(strconv.FormatInt(d.ByteSize, 10), NewResource(nsXSD+"nonNegativeInteger")))
+	}
+	return s
+}
+
+// DCATCatalogs returns all the catalogs described in the graph. Catalogs,
+// datasets and distributions described by blank nodes have an empty URI.
+func (g *Graph) DCATCatalogs() []*DCATCatalog {
+	var catalogs []*DCATCatalog
+	for _, t := range g.All(nil, NewResource(nsRDF+"type"), NewResource(nsDCAT+"Catalog")) {
+		c := &DCATCatalog{
+			URI:         dcatURI(t.Subject),
+			Title:       g.value(t.Subject, nsDCT+"title"),
+			Description: g.value(t.Subject, nsDCT+"description"),
+		}
+		for _, d := range g.All(t.Subject, NewResource(nsDCAT+"dataset"), nil) {
+			c.Datasets = append(c.Datasets, g.DCATDataset(d.Object))
+		}
+		catalogs = append(catalogs, c)
+	}
+	return catalogs
+}
+
+// DCATDataset reads the description of a dataset from the graph
+func (g *Graph) DCATDataset(s Term) *DCATDataset {
+	d := &DCATDataset{
+		URI:         dcatURI(s),
+		Title:       g.value(s, nsDCT+"title"),
+		Description: g.value(s, nsDCT+"description"),
+		License:     g.value(s, nsDCT+"license"),
+	}
+	for _, t := range g.All(s, NewResource(nsDCAT+"distribution"), nil) {
+		d.Distributions = append(d.Distributions, g.DCATDistribution(t.Object))
+	}
+	return d
+}
+
+// DCATDistribution reads the description of a distribution from the graph
+func (g *Graph) DCATDistribution(s Term) *DCATDistribution {
+	d := &DCATDistribution{
+		URI:         dcatURI(s),
+		Title:       g.value(s, nsDCT+"title"),
+		License:     g.value(s, nsDCT+"license"),
+		AccessURL:   g.value(s, nsDCAT+"accessURL"),
+		DownloadURL: g.value(s, nsDCAT+"downloadURL"),
+		MediaType:   g.value(s, nsDCAT+"mediaType"),
+	}
+	d.ByteSize, _ = strconv.ParseInt(g.value(s, nsDCAT+"byteSize"), 10, 64)
+	return d
+}
+
+// dcatURI returns the URI of a described resource, which is empty for
+// blank nodes
+func dcatURI(t Term) string {
+	if r, ok := t.(*Resource); ok {
+		return r.URI
+	}
+	return ""
+}
+
+// subjectOrBlank returns the resource for uri, or a new blank node if uri is
+// empty
+func subjectOrBlank(uri string) Term {
+	if len(uri) == 0 {
+		return NewAnonNode()
+	}
+	return NewResource(uri)
+}
+
+// value returns the raw value of the first object found for s and p
+func (g *Graph) value(s Term, p string) string {
+	t := g.One(s, NewResource(p), nil)
+	if t == nil {
+		return ""
+	}
+	return t.Object.RawValue()
+}
+
+// addLiteral adds a plain literal value, unless it is empty
+func (g *Graph) addLiteral(s Term, p string, value string) {
+	if len(value) > 0 {
+		g.AddTriple(s, NewResource(p), NewLiteral(value))
+	}
+}
+
+// addResource adds a resource value, unless it is empty
+func (g *Graph) addResource(s Term, p string, uri string) {
+	if len(uri) > 0 {
+		g.AddTriple(s, NewResource(p), NewResource(uri))
+	}
+}
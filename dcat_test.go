@@ -0,0 +1,53 @@
+package rdf2go
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDCATRoundTrip(t *testing.T) {
+	c := &DCATCatalog{
+		URI:   "http://example.org/catalog",
+		Title: "Catalog",
+		Datasets: []*DCATDataset{{
+			URI:     "http://example.org/ds",
+			Title:   "Dataset",
+			License: "http://creativecommons.org/licenses/by/4.0/",
+			Distributions: []*DCATDistribution{{
+				Title:       "Turtle dump",
+				DownloadURL: "http://example.org/ds.ttl",
+				MediaType:   "text/turtle",
+				ByteSize:    1024,
+			}},
+		}},
+	}
+	g := NewGraph(testUri)
+	s := g.AddDCATCatalog(c)
+	assert.Equal(t, NewResource("http://example.org/catalog"), s)
+	assert.Equal(t, []string{"http://example.org/ds.ttl"}, Distributions(g))
+
+	// read back after a serialization round trip
+	b := new(bytes.Buffer)
+	assert.NoError(t, g.Serialize(b, "text/turtle"))
+	g2 := NewGraph(testUri)
+	assert.NoError(t, g2.Parse(b, "text/turtle"))
+
+	catalogs := g2.DCATCatalogs()
+	assert.Equal(t, 1, len(catalogs))
+	assert.Equal(t, "Catalog", catalogs[0].Title)
+	assert.Equal(t, 1, len(catalogs[0].Datasets))
+	ds := catalogs[0].Datasets[0]
+	assert.Equal(t, "http://example.org/ds", ds.URI)
+	assert.Equal(t, "Dataset", ds.Title)
+	assert.Equal(t, "http://creativecommons.org/licenses/by/4.0/", ds.License)
+	assert.Equal(t, 1, len(ds.Distributions))
+	dist := ds.Distributions[0]
+	assert.Equal(t, "", dist.URI)
+	assert.Equal(t, "Turtle dump", dist.Title)
+	assert.Equal(t, "http://example.org/ds.ttl", dist.DownloadURL)
+	assert.Equal(t, "", dist.AccessURL)
+	assert.Equal(t, "text/turtle", dist.MediaType)
+	assert.Equal(t, int64(1024), dist.ByteSize)
+}
@@ -0,0 +1,106 @@
+package rdf2go
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultInverseFunctional lists the inverse-functional properties used by
+// FindDuplicates when none are configured
+var DefaultInverseFunctional = []Term{
+	NewResource(nsFOAF + "mbox"),
+	NewResource(nsFOAF + "mbox_sha1sum"),
+	NewResource(nsSDO + "identifier"),
+	NewResource("https://schema.org/identifier"),
+}
+
+// DedupeOptions configures how FindDuplicates detects duplicate entities
+type DedupeOptions struct {
+	// InverseFunctional lists properties whose values identify a single
+	// entity: two subjects sharing a value are considered the same.
+	// When both this and KeySets are empty, DefaultInverseFunctional is used.
+	InverseFunctional []Term
+	// KeySets lists sets of properties which, taken together, identify an
+	// entity: two subjects having the same values for all the properties of
+	// a set are considered the same.
+	KeySets [][]Term
+}
+
+// FindDuplicates computes candidate owl:sameAs links between subjects of g
+// that appear to describe the same entity, and returns them in a new graph
+// so they can be reviewed before being merged.
+func FindDuplicates(g *Graph, opts DedupeOptions) *Graph {
+	ifps := opts.InverseFunctional
+	if len(ifps) == 0 && len(opts.KeySets) == 0 {
+		ifps = DefaultInverseFunctional
+	}
+	links := NewGraph(g.URI())
+	seen := make(map[string]bool)
+	link := func(subjects []Term) {
+		sort.Slice(subjects, func(i, j int) bool {
+			return encodeTerm(subjects[i]) < encodeTerm(subjects[j])
+		})
+		for i := 1; i < len(subjects); i++ {
+			key := encodeTerm(subjects[0]) + " " + encodeTerm(subjects[i])
+			if !seen[key] {
+				seen[key] = true
+				links.AddTriple(subjects[0], NewResource(nsOWL+"sameAs"), subjects[i])
+			}
+		}
+	}
+
+	for _, p := range ifps {
+		groups := make(map[string][]Term)
+		for _, t := range g.All(nil, p, nil) {
+			key := encodeTerm(t.Object)
+			groups[key] = appendUnique(groups[key], t.Subject)
+		}
+		for _, subjects := range groups {
+			link(subjects)
+		}
+	}
+
+	for _, keys := range opts.KeySets {
+		if len(keys) == 0 {
+			continue
+		}
+		groups := make(map[string][]Term)
+		for _, t := range g.All(nil, keys[0], nil) {
+			key, ok := subjectKey(g, t.Subject, keys)
+			if ok {
+				groups[key] = appendUnique(groups[key], t.Subject)
+			}
+		}
+		for _, subjects := range groups {
+			link(subjects)
+		}
+	}
+	return links
+}
+
+// subjectKey returns a string made of the values of the subject for all the
+// given properties, or false if the subject lacks one of them
+func subjectKey(g *Graph, s Term, keys []Term) (string, bool) {
+	parts := make([]string, 0, len(keys))
+	for _, p := range keys {
+		var values []string
+		for _, t := range g.All(s, p, nil) {
+			values = append(values, encodeTerm(t.Object))
+		}
+		if len(values) == 0 {
+			return "", false
+		}
+		sort.Strings(values)
+		parts = append(parts, strings.Join(values, ","))
+	}
+	return strings.Join(parts, " "), true
+}
+
+func appendUnique(terms []Term, term Term) []Term {
+	for _, t := range terms {
+		if t.Equal(term) {
+			return terms
+		}
+	}
+	return append(terms, term)
+}
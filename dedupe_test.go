@@ -0,0 +1,40 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicatesIFP(t *testing.T) {
+	g := NewGraph(testUri)
+	mbox := NewResource(nsFOAF + "mbox")
+	g.AddTriple(NewResource("a"), mbox, NewResource("mailto:a@example.org"))
+	g.AddTriple(NewResource("b"), mbox, NewResource("mailto:a@example.org"))
+	g.AddTriple(NewResource("c"), mbox, NewResource("mailto:c@example.org"))
+	g.AddTriple(NewResource("c"), NewResource(nsSDO+"identifier"), NewLiteral("42"))
+	g.AddTriple(NewResource("d"), NewResource(nsSDO+"identifier"), NewLiteral("42"))
+
+	links := FindDuplicates(g, DedupeOptions{})
+	assert.Equal(t, 2, links.Len())
+	sameAs := NewResource(nsOWL + "sameAs")
+	assert.NotNil(t, links.One(NewResource("a"), sameAs, NewResource("b")))
+	assert.NotNil(t, links.One(NewResource("c"), sameAs, NewResource("d")))
+}
+
+func TestFindDuplicatesKeySets(t *testing.T) {
+	g := NewGraph(testUri)
+	name := NewResource(nsFOAF + "name")
+	birth := NewResource("http://example.org/birthDate")
+	g.AddTriple(NewResource("a"), name, NewLiteral("Alice"))
+	g.AddTriple(NewResource("a"), birth, NewLiteral("1990-01-01"))
+	g.AddTriple(NewResource("b"), name, NewLiteral("Alice"))
+	g.AddTriple(NewResource("b"), birth, NewLiteral("1990-01-01"))
+	g.AddTriple(NewResource("c"), name, NewLiteral("Alice"))
+	g.AddTriple(NewResource("c"), birth, NewLiteral("1985-01-01"))
+	g.AddTriple(NewResource("d"), name, NewLiteral("Alice"))
+
+	links := FindDuplicates(g, DedupeOptions{KeySets: [][]Term{{name, birth}}})
+	assert.Equal(t, 1, links.Len())
+	assert.NotNil(t, links.One(NewResource("a"), NewResource(nsOWL+"sameAs"), NewResource("b")))
+}
@@ -0,0 +1,138 @@
+package rdf2go
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// deltaMagic starts the binary form of a GraphDiff, which is followed by the
+// terms used by the diff, then by the missing and the added triples, written
+// as the indexes of their terms. All numbers are unsigned varints.
+const deltaMagic = "RDF2GOD1"
+
+// ErrDeltaFormat is returned when decoding data that is not a valid delta
+var ErrDeltaFormat = errors.New("invalid delta")
+
+// MarshalBinary encodes the diff in a compact binary form, e.g. to send the
+// changes of a graph to subscribers instead of the whole graph. Each term is
+// written once, however many triples use it.
+func (d *GraphDiff) MarshalBinary() ([]byte, error) {
+	ids := make(map[string]uint64)
+	var terms []byte
+	for _, triples := range [][]*Triple{d.Missing, d.Added} {
+		for _, triple := range triples {
+			for _, t := range []Term{triple.Subject, triple.Predicate, triple.Object} {
+				key := encodeTerm(t)
+				if _, ok := ids[key]; !ok {
+					ids[key] = uint64(len(ids))
+					terms = appendTerm(terms, t)
+				}
+			}
+		}
+	}
+	b := []byte(deltaMagic)
+	b = binary.AppendUvarint(b, uint64(len(ids)))
+	b = append(b, terms...)
+	for _, triples := range [][]*Triple{d.Missing, d.Added} {
+		b = binary.AppendUvarint(b, uint64(len(triples)))
+		for _, triple := range triples {
+			b = binary.AppendUvarint(b, ids[encodeTerm(triple.Subject)])
+			b = binary.AppendUvarint(b, ids[encodeTerm(triple.Predicate)])
+			b = binary.AppendUvarint(b, ids[encodeTerm(triple.Object)])
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a diff encoded with MarshalBinary
+func (d *GraphDiff) UnmarshalBinary(data []byte) error {
+	if len(data) < len(deltaMagic) || string(data[:len(deltaMagic)]) != deltaMagic {
+		return ErrDeltaFormat
+	}
+	b := data[len(deltaMagic):]
+	next := func() (uint64, error) {
+		v, size := binary.Uvarint(b)
+		if size <= 0 {
+			return 0, ErrDeltaFormat
+		}
+		b = b[size:]
+		return v, nil
+	}
+	n, err := next()
+	if err != nil || n > uint64(len(b)) {
+		return ErrDeltaFormat
+	}
+	terms := make([]Term, n)
+	for i := range terms {
+		terms[i], b, err = readTerm(b)
+		if err != nil {
+			return ErrDeltaFormat
+		}
+	}
+	var lists [2][]*Triple
+	for l := range lists {
+		n, err := next()
+		if err != nil || n > uint64(len(b)) {
+			return ErrDeltaFormat
+		}
+		for i := uint64(0); i < n; i++ {
+			var spo [3]Term
+			for j := range spo {
+				id, err := next()
+				if err != nil || id >= uint64(len(terms)) {
+					return ErrDeltaFormat
+				}
+				spo[j] = terms[id]
+			}
+			lists[l] = append(lists[l], NewTriple(spo[0], spo[1], spo[2]))
+		}
+	}
+	if len(b) > 0 {
+		return ErrDeltaFormat
+	}
+	d.Missing, d.Added = lists[0], lists[1]
+	return nil
+}
+
+// WriteDelta writes the binary form of the changes turning the old graph
+// into the new one, see GraphDiff.MarshalBinary
+func WriteDelta(w io.Writer, old *Graph, new *Graph) error {
+	b, err := Diff(old, new).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// ApplyDelta reads a delta written by WriteDelta and applies it to the graph
+func (g *Graph) ApplyDelta(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	d := &GraphDiff{}
+	err = d.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	g.ApplyDiff(d)
+	return nil
+}
+
+// ApplyDiff removes the missing triples of the diff from the graph and adds
+// its added triples. Blank nodes are matched by label, so a graph kept in
+// sync with diffs must start from a copy of the graph they were computed on.
+func (g *Graph) ApplyDiff(d *GraphDiff) {
+	for _, triple := range d.Missing {
+		for _, t := range g.All(triple.Subject, triple.Predicate, triple.Object) {
+			g.Remove(t)
+		}
+	}
+	for _, triple := range d.Added {
+		if g.One(triple.Subject, triple.Predicate, triple.Object) == nil {
+			g.Add(triple)
+		}
+	}
+}
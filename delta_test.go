@@ -0,0 +1,44 @@
+package rdf2go
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelta(t *testing.T) {
+	old := NewGraph(testUri)
+	require.NoError(t, old.ParseString(simpleTurtle+"\n<#me> <http://xmlns.com/foaf/0.1/knows> [ <http://xmlns.com/foaf/0.1/name> \"B\" ] .", "text/turtle"))
+	subscriber := old.Snapshot()
+
+	updated := old.Snapshot()
+	me := NewResource(testUri + "#me")
+	updated.Remove(updated.One(me, NewResource(nsFOAF+"name"), nil))
+	updated.AddTriple(me, NewResource(nsFOAF+"name"), NewLiteralWithLanguage("Tést", "fr"))
+	updated.AddTriple(me, NewResource(nsFOAF+"age"), NewLiteralWithDatatype("42", NewResource(nsXSD+"integer")))
+	updated.AddTriple(me, NewResource(nsFOAF+"nick"), NewLiteralWithDatatype("42", NewResource(nsXSD+"integer")))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDelta(&buf, old, updated))
+	full, _ := updated.SerializeString("text/turtle")
+	assert.Less(t, buf.Len(), len(full))
+
+	require.NoError(t, subscriber.ApplyDelta(&buf))
+	assert.True(t, subscriber.Equal(updated), Diff(subscriber, updated).String())
+	assert.Equal(t, 4, old.Len())
+}
+
+func TestDeltaErrors(t *testing.T) {
+	d := &GraphDiff{Added: []*Triple{NewTriple(NewResource("a"), NewResource("b"), NewLiteral("c"))}}
+	data, err := d.MarshalBinary()
+	require.NoError(t, err)
+	for i := 0; i < len(data); i++ {
+		assert.ErrorIs(t, new(GraphDiff).UnmarshalBinary(data[:i]), ErrDeltaFormat, "%d", i)
+	}
+	assert.ErrorIs(t, new(GraphDiff).UnmarshalBinary(append(data, 0)), ErrDeltaFormat)
+	decoded := new(GraphDiff)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, d.Added, decoded.Added)
+}
@@ -0,0 +1,249 @@
+package rdf2go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const nsDID = "https://www.w3.org/ns/did#"
+
+// didTerms maps the terms defined by the DID context to their IRIs
+var didTerms = map[string]string{
+	"service":              nsDID + "service",
+	"serviceEndpoint":      nsDID + "serviceEndpoint",
+	"verificationMethod":   nsSec + "verificationMethod",
+	"controller":           nsSec + "controller",
+	"authentication":       nsSec + "authenticationMethod",
+	"assertionMethod":      nsSec + "assertionMethod",
+	"keyAgreement":         nsSec + "keyAgreementMethod",
+	"capabilityInvocation": nsSec + "capabilityInvocationMethod",
+	"capabilityDelegation": nsSec + "capabilityDelegationMethod",
+	"publicKeyJwk":         nsSec + "publicKeyJwk",
+	"publicKeyMultibase":   nsSec + "publicKeyMultibase",
+	"alsoKnownAs":          "https://www.w3.org/ns/activitystreams#alsoKnownAs",
+}
+
+// VerificationMethod describes a key listed in a DID document
+type VerificationMethod struct {
+	ID                 string
+	Type               string
+	Controller         string
+	PublicKeyMultibase string
+	PublicKeyJwk       string
+}
+
+// DIDService describes a service endpoint listed in a DID document
+type DIDService struct {
+	ID        string
+	Type      string
+	Endpoints []string
+}
+
+// DIDResolver is used to fetch the DID document of a DID
+type DIDResolver interface {
+	ResolveDID(did string) (*Graph, error)
+}
+
+// DIDResolvers dispatches resolution to a resolver based on the DID method
+// (e.g. "web" for did:web)
+type DIDResolvers map[string]DIDResolver
+
+// ResolveDID resolves a DID using the resolver registered for its method
+func (r DIDResolvers) ResolveDID(did string) (*Graph, error) {
+	parts := strings.SplitN(did, ":", 3)
+	if len(parts) < 3 || parts[0] != "did" {
+		return nil, fmt.Errorf("invalid DID %s", did)
+	}
+	resolver, ok := r[parts[1]]
+	if !ok {
+		return nil, fmt.Errorf("DID method %s is not supported", parts[1])
+	}
+	return resolver.ResolveDID(did)
+}
+
+// WebDIDResolver resolves did:web DIDs by fetching did.json over HTTPS
+type WebDIDResolver struct {
+	httpClient *http.Client
+}
+
+// NewWebDIDResolver creates a resolver for the did:web method
+func NewWebDIDResolver(skipVerify ...bool) *WebDIDResolver {
+	skip := false
+	if len(skipVerify) > 0 {
+		skip = skipVerify[0]
+	}
+	return &WebDIDResolver{httpClient: NewHttpClient(skip)}
+}
+
+// ResolveDID fetches and parses the DID document of a did:web DID
+func (r *WebDIDResolver) ResolveDID(did string) (*Graph, error) {
+	uri, err := didWebURL(did)
+	if err != nil {
+		return nil, err
+	}
+	q, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	q.Header.Set("Accept", "application/did+ld+json, application/json")
+	resp, err := r.httpClient.Do(q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Could not resolve %s from %s - HTTP %d", did, uri, resp.StatusCode)
+	}
+	g, err := ParseDIDDocument(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if g.URI() != did {
+		return nil, fmt.Errorf("DID document at %s describes %s instead of %s", uri, g.URI(), did)
+	}
+	return g, nil
+}
+
+// didWebURL returns the location of the DID document of a did:web DID. The
+// first segment is the host, where only the colon of a port may be percent
+// encoded (as %3A). The other segments are path segments, kept as they are.
+func didWebURL(did string) (string, error) {
+	if !strings.HasPrefix(did, "did:web:") {
+		return "", fmt.Errorf("%s is not a did:web DID", did)
+	}
+	parts := strings.Split(did[len("did:web:"):], ":")
+	host, port, hasPort := strings.Cut(strings.ReplaceAll(parts[0], "%3a", "%3A"), "%3A")
+	if len(host) == 0 || strings.Trim(host, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789.-") != "" {
+		return "", fmt.Errorf("invalid host in DID %s", did)
+	}
+	if hasPort {
+		if len(port) == 0 || strings.Trim(port, "0123456789") != "" {
+			return "", fmt.Errorf("invalid port in DID %s", did)
+		}
+		host += ":" + port
+	}
+	for _, part := range parts[1:] {
+		if !validDIDSegment(part) {
+			return "", fmt.Errorf("invalid DID %s", did)
+		}
+	}
+	if len(parts) == 1 {
+		return "https://" + host + "/.well-known/did.json", nil
+	}
+	return "https://" + host + "/" + strings.Join(parts[1:], "/") + "/did.json", nil
+}
+
+// validDIDSegment returns true if s is made of DID idchars: letters,
+// digits, ".", "-", "_" and percent encoded bytes
+func validDIDSegment(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9'):
+		case c == '.' || c == '-' || c == '_':
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ParseDIDDocument parses a JSON-LD DID document into a graph named after
+// the DID. Relative references (e.g. #key-1) are resolved against the DID.
+func ParseDIDDocument(reader io.Reader) (*Graph, error) {
+	var doc map[string]interface{}
+	err := json.NewDecoder(reader).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := doc["id"].(string)
+	if !strings.HasPrefix(id, "did:") {
+		return nil, errors.New("DID document has no valid id")
+	}
+	contexts, ok := doc["@context"].([]interface{})
+	if !ok {
+		contexts = []interface{}{doc["@context"]}
+	}
+	ctx := newVCContext(didTerms, contexts).securityContext()
+	ctx.base = id
+	g := NewGraph(id)
+	_, err = g.addVCNode(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// VerificationMethods returns the verification methods of a DID. If a
+// relationship is given (e.g. "authentication"), only the methods listed
+// for it are returned. Unknown relationships are ignored.
+func (g *Graph) VerificationMethods(did string, relationship ...string) []*VerificationMethod {
+	s := NewResource(did)
+	predicates := []string{nsSec + "verificationMethod"}
+	if len(relationship) > 0 {
+		predicates = nil
+		for _, r := range relationship {
+			if p, ok := didTerms[r]; ok {
+				predicates = append(predicates, p)
+			}
+		}
+	}
+	var methods []*VerificationMethod
+	seen := make(map[string]bool)
+	for _, p := range predicates {
+		for _, t := range g.All(s, NewResource(p), nil) {
+			key := encodeTerm(t.Object)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			m := &VerificationMethod{
+				Type:               didType(g.value(t.Object, nsRDF+"type")),
+				Controller:         g.value(t.Object, nsSec+"controller"),
+				PublicKeyMultibase: g.value(t.Object, nsSec+"publicKeyMultibase"),
+				PublicKeyJwk:       g.value(t.Object, nsSec+"publicKeyJwk"),
+			}
+			if r, ok := t.Object.(*Resource); ok {
+				m.ID = r.URI
+			}
+			methods = append(methods, m)
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].ID < methods[j].ID })
+	return methods
+}
+
+// DIDServices returns the services listed in the DID document of a DID
+func (g *Graph) DIDServices(did string) []*DIDService {
+	var services []*DIDService
+	for _, t := range g.All(NewResource(did), NewResource(nsDID+"service"), nil) {
+		svc := &DIDService{
+			Type: didType(g.value(t.Object, nsRDF+"type")),
+		}
+		if r, ok := t.Object.(*Resource); ok {
+			svc.ID = r.URI
+		}
+		for _, e := range g.All(t.Object, NewResource(nsDID+"serviceEndpoint"), nil) {
+			svc.Endpoints = append(svc.Endpoints, e.Object.RawValue())
+		}
+		sort.Strings(svc.Endpoints)
+		services = append(services, svc)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+	return services
+}
+
+// didType shortens types from the security vocabulary to their local name
+func didType(iri string) string {
+	return strings.TrimPrefix(iri, nsSec)
+}
@@ -0,0 +1,117 @@
+package rdf2go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const exampleDIDDocument = `{
+  "@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/ed25519-2020/v1"],
+  "id": "did:web:example.com",
+  "verificationMethod": [{
+    "id": "#key-1",
+    "type": "Ed25519VerificationKey2020",
+    "controller": "did:web:example.com",
+    "publicKeyMultibase": "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+  }, {
+    "id": "did:web:example.com#key-2",
+    "type": "JsonWebKey2020",
+    "controller": "did:web:example.com",
+    "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "VCpo2LMLhn6iWku8MKvSLg2ZAoC-nlOyPVQaO3FxVeQ"}
+  }],
+  "authentication": ["#key-1"],
+  "service": [{
+    "id": "#linked-domain",
+    "type": "LinkedDomains",
+    "serviceEndpoint": "https://bar.example.com"
+  }]
+}`
+
+func TestParseDIDDocument(t *testing.T) {
+	g, err := ParseDIDDocument(strings.NewReader(exampleDIDDocument))
+	assert.NoError(t, err)
+	assert.Equal(t, "did:web:example.com", g.URI())
+
+	methods := g.VerificationMethods("did:web:example.com")
+	assert.Equal(t, 2, len(methods))
+	assert.Equal(t, &VerificationMethod{
+		ID:                 "did:web:example.com#key-1",
+		Type:               "Ed25519VerificationKey2020",
+		Controller:         "did:web:example.com",
+		PublicKeyMultibase: "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH",
+	}, methods[0])
+	assert.Equal(t, "JsonWebKey2020", methods[1].Type)
+	assert.JSONEq(t, `{"kty": "OKP", "crv": "Ed25519", "x": "VCpo2LMLhn6iWku8MKvSLg2ZAoC-nlOyPVQaO3FxVeQ"}`, methods[1].PublicKeyJwk)
+
+	assert.Nil(t, g.VerificationMethods("did:web:example.com", "unknown"))
+	auth := g.VerificationMethods("did:web:example.com", "authentication")
+	assert.Equal(t, 1, len(auth))
+	assert.Equal(t, "did:web:example.com#key-1", auth[0].ID)
+
+	services := g.DIDServices("did:web:example.com")
+	assert.Equal(t, []*DIDService{{
+		ID:        "did:web:example.com#linked-domain",
+		Type:      "LinkedDomains",
+		Endpoints: []string{"https://bar.example.com"},
+	}}, services)
+
+	_, err = ParseDIDDocument(strings.NewReader(`{"id": "http://example.com"}`))
+	assert.Error(t, err)
+}
+
+func TestDIDWebURL(t *testing.T) {
+	uri, err := didWebURL("did:web:w3c-ccg.github.io")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://w3c-ccg.github.io/.well-known/did.json", uri)
+	uri, err = didWebURL("did:web:example.com%3A3000:user:alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com:3000/user/alice/did.json", uri)
+	_, err = didWebURL("did:key:z6Mk")
+	assert.Error(t, err)
+
+	uri, err = didWebURL("did:web:example.com:user%20name")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/user%20name/did.json", uri)
+	uri, err = didWebURL("did:web:example.com:a%2Fb")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/a%2Fb/did.json", uri)
+	for _, did := range []string{
+		"did:web:evil.com%2Fpath",
+		"did:web:user%40evil.com",
+		"did:web:evil.com%3Aabc",
+		"did:web:evil.com%3A",
+		"did:web:",
+		"did:web:example.com::x",
+		"did:web:example.com:a/b",
+	} {
+		_, err = didWebURL(did)
+		assert.Error(t, err, did)
+	}
+}
+
+func TestWebDIDResolver(t *testing.T) {
+	var did string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/.well-known/did.json" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(strings.Replace(exampleDIDDocument, "did:web:example.com", did, -1)))
+	}))
+	defer ts.Close()
+	did = "did:web:" + strings.Replace(strings.TrimPrefix(ts.URL, "https://"), ":", "%3A", 1)
+
+	resolvers := DIDResolvers{"web": NewWebDIDResolver(true)}
+	g, err := resolvers.ResolveDID(did)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(g.DIDServices(did)))
+
+	_, err = resolvers.ResolveDID("did:key:z6Mk")
+	assert.Error(t, err)
+	_, err = resolvers.ResolveDID(did + ":missing")
+	assert.Error(t, err)
+}
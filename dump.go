@@ -0,0 +1,55 @@
+package rdf2go
+
+import (
+	"io"
+	"text/tabwriter"
+)
+
+// DumpOptions is used to configure Dump
+type DumpOptions struct {
+	// Compact shortens IRIs to prefixed names
+	Compact bool
+	// Prefixes maps prefixes to the namespaces used by Compact. If nil, the
+	// common vocabularies (rdf, rdfs, foaf, schema, etc.) are used.
+	Prefixes map[string]string
+	// Shortener replaces Prefixes to shorten IRIs differently, e.g. with a
+	// LabelShortener
+	Shortener Shortener
+}
+
+// Dump prints the triples of the graph as a table with aligned subject,
+// predicate and object columns, which is easier to read than N-Triples when
+// debugging
+func (g *Graph) Dump(w io.Writer, opts DumpOptions) error {
+	shortener := optionShortener(opts.Shortener, opts.Prefixes)
+	format := encodeTerm
+	if opts.Compact {
+		format = func(term Term) string {
+			return compactTerm(term, shortener)
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	io.WriteString(tw, "SUBJECT\tPREDICATE\tOBJECT\n")
+	for _, t := range g.Triples(true) {
+		io.WriteString(tw, format(t.Subject)+"\t"+format(t.Predicate)+"\t"+format(t.Object)+"\n")
+	}
+	return tw.Flush()
+}
+
+// compactTerm encodes a term like encodeTerm, but using the short form of
+// IRIs given by s
+func compactTerm(term Term, s Shortener) string {
+	switch term := term.(type) {
+	case *Resource:
+		if short := s.Shorten(term.URI); short != term.URI {
+			return short
+		}
+	case *Literal:
+		if datatype := term.datatype(); datatype != nil {
+			lit := *term
+			lit.Datatype = nil
+			return lit.String() + "^^" + compactTerm(datatype, s)
+		}
+	}
+	return encodeTerm(term)
+}
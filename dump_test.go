@@ -0,0 +1,28 @@
+package rdf2go
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDump(t *testing.T) {
+	g := NewGraph(testUri)
+	alice := NewResource("http://example.org/alice")
+	g.AddTriple(alice, NewResource(nsFOAF+"name"), NewLiteralWithLanguage("Alice", "en"))
+	g.AddTriple(alice, NewResource(nsFOAF+"age"), NewLiteralWithDatatype("42", NewResource(nsXSD+"integer")))
+	g.AddTriple(alice, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, g.Dump(&buf, DumpOptions{Compact: true, Prefixes: map[string]string{"ex": "http://example.org/", "foaf": nsFOAF, "xsd": nsXSD}}))
+	assert.Equal(t, `SUBJECT   PREDICATE                                          OBJECT
+ex:alice  <http://www.w3.org/1999/02/22-rdf-syntax-ns#type>  foaf:Person
+ex:alice  foaf:age                                           "42"^^xsd:integer
+ex:alice  foaf:name                                          "Alice"@en
+`, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, g.Dump(&buf, DumpOptions{}))
+	assert.Contains(t, buf.String(), `<http://example.org/alice>  <http://xmlns.com/foaf/0.1/age>`)
+}
@@ -0,0 +1,62 @@
+package rdf2go
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// errUTF16 is returned for UTF-16 input that does not start with a byte order mark
+var errUTF16 = errors.New("input looks like UTF-16 without a byte order mark; convert it to UTF-8")
+
+// decodeInput prepares raw input for the parsers: a UTF-8 byte order mark
+// is stripped, and UTF-16 input starting with a byte order mark is
+// transcoded to UTF-8. UTF-16 without a byte order mark is rejected.
+func decodeInput(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return data[len(bomUTF8):], nil
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return decodeUTF16(data[2:], false)
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return decodeUTF16(data[2:], true)
+	case looksUTF16(data):
+		return nil, errUTF16
+	}
+	return data, nil
+}
+
+func decodeUTF16(data []byte, bigEndian bool) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, errors.New("invalid UTF-16 input: odd number of bytes")
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	out := make([]byte, 0, len(units))
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+	return out, nil
+}
+
+// looksUTF16 guesses whether data is UTF-16 encoded ASCII-range text, which
+// has a NUL in every other byte. Neither Turtle nor JSON-LD can contain NULs.
+func looksUTF16(data []byte) bool {
+	if len(data) < 2 {
+		return false
+	}
+	return data[0] == 0 || data[1] == 0
+}
@@ -0,0 +1,54 @@
+package rdf2go
+
+import (
+	"bytes"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeUTF16(s string, bigEndian bool) []byte {
+	var out []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	return out
+}
+
+func TestParseBOM(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.Parse(bytes.NewReader(append([]byte{0xEF, 0xBB, 0xBF}, simpleTurtle...)), "text/turtle")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.Len())
+
+	g = NewGraph(testUri)
+	err = g.Parse(bytes.NewReader(append([]byte{0xEF, 0xBB, 0xBF}, `{"@id":"http://a","http://b":"c"}`...)), "application/ld+json")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.Len())
+}
+
+func TestParseUTF16(t *testing.T) {
+	data := "<a> <b> \"café \U0001F600\" ."
+	for _, bigEndian := range []bool{false, true} {
+		bom := []byte{0xFF, 0xFE}
+		if bigEndian {
+			bom = []byte{0xFE, 0xFF}
+		}
+		g := NewGraph(testUri)
+		err := g.Parse(bytes.NewReader(append(bom, encodeUTF16(data, bigEndian)...)), "text/turtle")
+		assert.NoError(t, err)
+		assert.Equal(t, "café \U0001F600", g.One(nil, nil, nil).Object.RawValue())
+	}
+
+	g := NewGraph(testUri)
+	err := g.Parse(bytes.NewReader(encodeUTF16(data, false)), "text/turtle")
+	assert.Equal(t, errUTF16, err)
+
+	_, err = decodeInput([]byte{0xFF, 0xFE, 'a'})
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package rdf2go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrUnsupportedFormat is returned when parsing data whose mime type has
+	// no parser
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	// ErrEmptyInput is returned in ParseStrict mode when the data holds no
+	// triples
+	ErrEmptyInput = errors.New("empty input")
+	// ErrInvalidIRI is returned when serializing an IRI that cannot be
+	// written as it is with SetStrictIRIs enabled
+	ErrInvalidIRI = errors.New("invalid IRI")
+)
+
+// ParseMode sets how parsing handles inputs holding no triples, see
+// SetParseMode
+type ParseMode int
+
+const (
+	// ParseLenient, the default mode, accepts inputs holding no triples,
+	// including empty ones, which leave the graph unchanged
+	ParseLenient ParseMode = iota
+	// ParseStrict rejects inputs holding no triples, such as an empty body
+	// or the JSON-LD document {}, with ErrEmptyInput
+	ParseStrict
+)
+
+// SetParseMode sets the mode used when parsing data into the graph. In both
+// modes, parsing data whose mime type has no parser fails with
+// ErrUnsupportedFormat, which can be checked with errors.Is.
+func (g *Graph) SetParseMode(mode ParseMode) {
+	g.parseMode = mode
+}
+
+// ParseError is returned by the parsers for syntax errors in the input.
+// Line and Col start at 1; both are 0 when the position is not known (e.g.
+// for JSON-LD processing errors).
+type ParseError struct {
+	Line int
+	Col  int
+	// Token is the input found at the position of the error, if any
+	Token string
+	// Hint suggests how the input could be fixed, if any
+	Hint string
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	msg := e.Msg
+	if e.Line > 0 {
+		msg = fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, msg)
+	}
+	if len(e.Hint) > 0 {
+		msg += " (" + e.Hint + ")"
+	}
+	return msg
+}
+
+// position returns the line and column (both starting at 1) of an offset
+func position(data string, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line := 1 + strings.Count(data[:offset], "\n")
+	start := strings.LastIndexByte(data[:offset], '\n') + 1
+	return line, 1 + utf8.RuneCountInString(data[start:offset])
+}
+
+// jsonParseError converts errors from encoding/json to a *ParseError
+func jsonParseError(data []byte, err error) error {
+	offset := -1
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = int(e.Offset) - 1
+		if strings.HasPrefix(e.Error(), "unexpected end") {
+			offset = len(data)
+		}
+	case *json.UnmarshalTypeError:
+		offset = int(e.Offset) - 1
+	}
+	if offset < 0 {
+		return &ParseError{Msg: err.Error()}
+	}
+	line, col := position(string(data), offset)
+	perr := &ParseError{Line: line, Col: col, Msg: err.Error()}
+	if offset < len(data) {
+		r, _ := utf8.DecodeRune(data[offset:])
+		perr.Token = string(r)
+	}
+	return perr
+}
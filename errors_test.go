@@ -0,0 +1,76 @@
+package rdf2go
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorTurtle(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader("<a> <b> <c> .\n<a> ex:b <c> ."), "text/turtle")
+	perr, ok := err.(*ParseError)
+	require.True(t, ok)
+	assert.Equal(t, 2, perr.Line)
+	assert.Equal(t, 5, perr.Col)
+	assert.Equal(t, "ex:b", perr.Token)
+	assert.Equal(t, "declare it with @prefix ex: <...> .", perr.Hint)
+	assert.Equal(t, `line 2, column 5: undefined prefix "ex" (declare it with @prefix ex: <...> .)`, err.Error())
+
+	err = g.Parse(strings.NewReader("<a> <b> <c>"), "text/turtle")
+	perr, ok = err.(*ParseError)
+	require.True(t, ok)
+	assert.Equal(t, 1, perr.Line)
+	assert.Equal(t, 12, perr.Col)
+	assert.Equal(t, "", perr.Token)
+}
+
+func TestParseErrorJSONLD(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader("{\n  \"@id\": \"http://a\",\n  \"http://b\" \"c\"\n}"), "application/ld+json")
+	perr, ok := err.(*ParseError)
+	require.True(t, ok)
+	assert.Equal(t, 3, perr.Line)
+	assert.Equal(t, 14, perr.Col)
+	assert.Equal(t, `"`, perr.Token)
+
+	err = g.Parse(strings.NewReader(`{"@id": "http://a"`), "application/ld+json")
+	perr, ok = err.(*ParseError)
+	require.True(t, ok)
+	assert.Equal(t, 1, perr.Line)
+	assert.Equal(t, 19, perr.Col)
+
+	err = g.Parse(strings.NewReader(`{"@context": 5, "@id": "http://a"}`), "application/ld+json")
+	perr, ok = err.(*ParseError)
+	require.True(t, ok)
+	assert.Equal(t, 0, perr.Line)
+	assert.NotEmpty(t, perr.Msg)
+}
+
+func TestParseMode(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader("<a> <b> <c> ."), "text/plain")
+	assert.True(t, errors.Is(err, ErrUnsupportedFormat))
+	assert.Equal(t, `unsupported format: "text/plain"`, err.Error())
+	assert.True(t, errors.Is(g.Parse(strings.NewReader("{}"), "internal"), ErrUnsupportedFormat))
+
+	for _, mime := range []string{"text/turtle", "application/ld+json"} {
+		assert.NoError(t, g.Parse(strings.NewReader(""), mime))
+		assert.NoError(t, g.Parse(strings.NewReader(" \n"), mime))
+	}
+	assert.NoError(t, g.Parse(strings.NewReader("{}"), "application/ld+json"))
+	assert.Equal(t, 0, g.Len())
+
+	g.SetParseMode(ParseStrict)
+	for _, mime := range []string{"text/turtle", "application/ld+json"} {
+		assert.True(t, errors.Is(g.Parse(strings.NewReader(""), mime), ErrEmptyInput))
+	}
+	assert.True(t, errors.Is(g.Parse(strings.NewReader("{}"), "application/ld+json"), ErrEmptyInput))
+	assert.True(t, errors.Is(g.Parse(strings.NewReader("@prefix ex: <http://ex/> ."), "text/turtle"), ErrEmptyInput))
+	assert.True(t, errors.Is(g.Parse(strings.NewReader("<a> <b> <c> ."), "text/plain"), ErrUnsupportedFormat))
+	assert.NoError(t, g.Parse(strings.NewReader("<a> <b> <c> ."), "text/turtle"))
+	assert.Equal(t, 1, g.Len())
+}
@@ -0,0 +1,227 @@
+package rdf2go
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// labelPredicates lists the predicates used to find human readable labels,
+// in order of preference
+var labelPredicates = []string{
+	nsRDFS + "label",
+	"http://www.w3.org/2004/02/skos/core#prefLabel",
+	nsFOAF + "name",
+	nsSDO + "name",
+	nsDCT + "title",
+}
+
+// ExportOptions is used to configure the visualization exporters
+type ExportOptions struct {
+	// Prefixes maps prefixes to the namespaces used to shorten IRIs. If nil,
+	// the common vocabularies (rdf, rdfs, foaf, schema, etc.) are used.
+	Prefixes map[string]string
+	// Shortener replaces Prefixes to shorten IRIs differently, e.g. with a
+	// LastSegmentShortener
+	Shortener Shortener
+	// Labels uses rdfs:label (or similar) values as node labels instead of
+	// IRIs. The label triples are then not drawn as edges.
+	Labels bool
+}
+
+type exportNode struct {
+	ID    string
+	Label string
+	Kind  string
+}
+
+type exportEdge struct {
+	ID     string
+	Source string
+	Target string
+	Label  string
+}
+
+// exportGraph turns the triples of the graph into nodes and edges. Resources
+// and blank nodes map to a single node each, while every literal gets its own
+// node.
+func (g *Graph) exportGraph(opts ExportOptions) ([]*exportNode, []*exportEdge) {
+	shortener := optionShortener(opts.Shortener, opts.Prefixes)
+	isLabel := make(map[string]bool)
+	if opts.Labels {
+		for _, p := range labelPredicates {
+			isLabel[p] = true
+		}
+	}
+
+	var nodes []*exportNode
+	var edges []*exportEdge
+	ids := make(map[string]*exportNode)
+	node := func(term Term) *exportNode {
+		key := encodeTerm(term)
+		if n, ok := ids[key]; ok {
+			if _, literal := term.(*Literal); !literal {
+				return n
+			}
+		}
+		n := &exportNode{ID: "n" + strconv.Itoa(len(nodes))}
+		switch term := term.(type) {
+		case *Literal:
+			n.Kind = "literal"
+			n.Label = term.Value
+		case *BlankNode:
+			n.Kind = "bnode"
+			n.Label = term.String()
+		default:
+			n.Kind = "resource"
+			n.Label = shortener.Shorten(term.RawValue())
+		}
+		if opts.Labels && n.Kind != "literal" {
+			if label := g.label(term); len(label) > 0 {
+				n.Label = label
+			}
+		}
+		ids[key] = n
+		nodes = append(nodes, n)
+		return n
+	}
+	for _, t := range g.Triples(true) {
+		if isLabel[t.Predicate.RawValue()] {
+			if _, ok := t.Object.(*Literal); ok {
+				node(t.Subject)
+				continue
+			}
+		}
+		s := node(t.Subject)
+		o := node(t.Object)
+		edges = append(edges, &exportEdge{
+			ID:     "e" + strconv.Itoa(len(edges)),
+			Source: s.ID,
+			Target: o.ID,
+			Label:  shortener.Shorten(t.Predicate.RawValue()),
+		})
+	}
+	return nodes, edges
+}
+
+// label returns the preferred human readable label of a term, if any
+func (g *Graph) label(term Term) string {
+	for _, p := range labelPredicates {
+		if label := g.value(term, p); len(label) > 0 {
+			return label
+		}
+	}
+	return ""
+}
+
+// ExportDOT writes the graph in the Graphviz DOT format
+func (g *Graph) ExportDOT(w io.Writer, opts ExportOptions) error {
+	nodes, edges := g.exportGraph(opts)
+	var b strings.Builder
+	b.WriteString("digraph G {\n  rankdir=LR;\n")
+	for _, n := range nodes {
+		shape := "ellipse"
+		switch n.Kind {
+		case "literal":
+			shape = "box"
+		case "bnode":
+			shape = "point"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", n.ID, dotQuote(n.Label), shape)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", e.Source, e.Target, dotQuote(e.Label))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return `"` + s + `"`
+}
+
+// ExportGraphML writes the graph in the GraphML format used by Gephi, yEd, etc.
+func (g *Graph) ExportGraphML(w io.Writer, opts ExportOptions) error {
+	nodes, edges := g.exportGraph(opts)
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="kind" for="node" attr.name="kind" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="predicate" for="edge" attr.name="label" attr.type="string"/>` + "\n")
+	b.WriteString(`  <graph edgedefault="directed">` + "\n")
+	for _, n := range nodes {
+		fmt.Fprintf(&b, `    <node id="%s"><data key="label">%s</data><data key="kind">%s</data></node>`+"\n", n.ID, xmlEscape(n.Label), n.Kind)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, `    <edge id="%s" source="%s" target="%s"><data key="predicate">%s</data></edge>`+"\n", e.ID, e.Source, e.Target, xmlEscape(e.Label))
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// ExportCytoscape writes the graph in the Cytoscape.js JSON elements format
+func (g *Graph) ExportCytoscape(w io.Writer, opts ExportOptions) error {
+	nodes, edges := g.exportGraph(opts)
+	type element struct {
+		Data map[string]string `json:"data"`
+	}
+	elements := struct {
+		Nodes []element `json:"nodes"`
+		Edges []element `json:"edges"`
+	}{
+		Nodes: []element{},
+		Edges: []element{},
+	}
+	for _, n := range nodes {
+		elements.Nodes = append(elements.Nodes, element{map[string]string{"id": n.ID, "label": n.Label, "kind": n.Kind}})
+	}
+	for _, e := range edges {
+		elements.Edges = append(elements.Edges, element{map[string]string{"id": e.ID, "source": e.Source, "target": e.Target, "label": e.Label}})
+	}
+	return json.NewEncoder(w).Encode(map[string]interface{}{"elements": elements})
+}
+
+// ExportMermaid writes the graph as a Mermaid flowchart, which can be
+// embedded in Markdown documents
+func (g *Graph) ExportMermaid(w io.Writer, opts ExportOptions) error {
+	nodes, edges := g.exportGraph(opts)
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range nodes {
+		label := mermaidQuote(n.Label)
+		switch n.Kind {
+		case "literal":
+			fmt.Fprintf(&b, "  %s(%s)\n", n.ID, label)
+		case "bnode":
+			fmt.Fprintf(&b, "  %s((%s))\n", n.ID, label)
+		default:
+			fmt.Fprintf(&b, "  %s[%s]\n", n.ID, label)
+		}
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", e.Source, mermaidQuote(e.Label), e.Target)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func mermaidQuote(s string) string {
+	s = strings.Replace(s, `"`, "#quot;", -1)
+	s = strings.Replace(s, "\n", "<br/>", -1)
+	return `"` + s + `"`
+}
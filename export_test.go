@@ -0,0 +1,86 @@
+package rdf2go
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportTestGraph() *Graph {
+	g := NewGraph(testUri)
+	alice, bob := NewResource("http://example.org/alice"), NewResource("http://example.org/bob")
+	g.AddTriple(alice, NewResource(nsFOAF+"knows"), bob)
+	g.AddTriple(alice, NewResource(nsFOAF+"name"), NewLiteral(`Alice "A"`))
+	g.AddTriple(bob, NewResource(nsFOAF+"age"), NewLiteral("42"))
+	return g
+}
+
+func TestExportDOT(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, exportTestGraph().ExportDOT(&buf, ExportOptions{}))
+	assert.Equal(t, `digraph G {
+  rankdir=LR;
+  n0 [label="http://example.org/alice", shape=ellipse];
+  n1 [label="http://example.org/bob", shape=ellipse];
+  n2 [label="Alice \"A\"", shape=box];
+  n3 [label="42", shape=box];
+  n0 -> n1 [label="foaf:knows"];
+  n0 -> n2 [label="foaf:name"];
+  n1 -> n3 [label="foaf:age"];
+}
+`, buf.String())
+
+	buf.Reset()
+	opts := ExportOptions{Labels: true, Prefixes: map[string]string{"ex": "http://example.org/"}}
+	assert.NoError(t, exportTestGraph().ExportDOT(&buf, opts))
+	assert.Contains(t, buf.String(), `n0 [label="Alice \"A\"", shape=ellipse];`)
+	assert.Contains(t, buf.String(), `n1 [label="ex:bob", shape=ellipse];`)
+	assert.Contains(t, buf.String(), `n0 -> n1 [label="http://xmlns.com/foaf/0.1/knows"];`)
+	assert.NotContains(t, buf.String(), "name")
+}
+
+func TestExportGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, exportTestGraph().ExportGraphML(&buf, ExportOptions{}))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "<?xml"))
+	assert.Contains(t, out, `<node id="n2"><data key="label">Alice &#34;A&#34;</data><data key="kind">literal</data></node>`)
+	assert.Contains(t, out, `<edge id="e0" source="n0" target="n1"><data key="predicate">foaf:knows</data></edge>`)
+}
+
+func TestExportCytoscape(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, exportTestGraph().ExportCytoscape(&buf, ExportOptions{}))
+	var doc struct {
+		Elements struct {
+			Nodes []struct{ Data map[string]string }
+			Edges []struct{ Data map[string]string }
+		}
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, 4, len(doc.Elements.Nodes))
+	assert.Equal(t, 3, len(doc.Elements.Edges))
+	assert.Equal(t, map[string]string{"id": "e2", "source": "n1", "target": "n3", "label": "foaf:age"}, doc.Elements.Edges[2].Data)
+}
+
+func TestShortenIRI(t *testing.T) {
+	prefixes := map[string]string{"ex": "http://example.org/", "exv": "http://example.org/vocab#"}
+	assert.Equal(t, "exv:p", shortenIRI("http://example.org/vocab#p", prefixes))
+	assert.Equal(t, "ex:a", shortenIRI("http://example.org/a", prefixes))
+	assert.Equal(t, "http://other.org/a", shortenIRI("http://other.org/a", prefixes))
+}
+
+func TestExportMermaid(t *testing.T) {
+	g := exportTestGraph()
+	g.AddTriple(NewResource("http://example.org/bob"), NewResource(nsFOAF+"account"), NewAnonNode())
+	var buf bytes.Buffer
+	assert.NoError(t, g.ExportMermaid(&buf, ExportOptions{Prefixes: map[string]string{"ex": "http://example.org/", "foaf": nsFOAF}}))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "flowchart LR\n  n0[\"ex:alice\"]\n"))
+	assert.Contains(t, out, "  n2(\"Alice #quot;A#quot;\")\n")
+	assert.Contains(t, out, "))\n")
+	assert.Contains(t, out, "  n0 -->|\"foaf:knows\"| n1\n")
+}
@@ -0,0 +1,80 @@
+package rdf2go
+
+// FilteredGraph returns a view of g in which only the triples accepted by
+// allow are visible, e.g. to serialize the part of a graph an agent may read
+// without copying it. Changes to g are seen through the view. Triples added
+// to or removed from the view are added to or removed from g, if allow
+// accepts them; others are ignored.
+func FilteredGraph(g *Graph, allow func(*Triple) bool) *Graph {
+	view := NewGraphWithStore(g.uri, &filteredStore{store: g.store, allow: allow})
+	view.httpClient = g.httpClient
+	view.limits = g.limits
+	view.warn = g.warn
+	view.meta = g.meta.copy()
+	view.noAutoPrefixes = g.noAutoPrefixes
+	view.jsonldContext = g.jsonldContext
+	view.prefixes = g.Prefixes()
+	return view
+}
+
+// filteredStore is a Store hiding the triples of another store rejected by
+// a filter
+type filteredStore struct {
+	store Store
+	allow func(*Triple) bool
+}
+
+func (s *filteredStore) Add(t *Triple) {
+	if s.allow(t) {
+		s.store.Add(t)
+	}
+}
+
+func (s *filteredStore) Remove(t *Triple) {
+	if s.allow(t) {
+		s.store.Remove(t)
+	}
+}
+
+func (s *filteredStore) One(subject Term, p Term, o Term) *Triple {
+	for _, t := range s.store.All(subject, p, o) {
+		if s.allow(t) {
+			return t
+		}
+	}
+	if subject == nil && p == nil && o == nil {
+		for t := range s.IterTriples() {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *filteredStore) All(subject Term, p Term, o Term) []*Triple {
+	var triples []*Triple
+	for _, t := range s.store.All(subject, p, o) {
+		if s.allow(t) {
+			triples = append(triples, t)
+		}
+	}
+	return triples
+}
+
+func (s *filteredStore) IterTriples() chan *Triple {
+	var triples []*Triple
+	for t := range s.store.IterTriples() {
+		if s.allow(t) {
+			triples = append(triples, t)
+		}
+	}
+	ch := make(chan *Triple, len(triples))
+	for _, t := range triples {
+		ch <- t
+	}
+	close(ch)
+	return ch
+}
+
+func (s *filteredStore) Len() int {
+	return len(s.IterTriples())
+}
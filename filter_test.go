@@ -0,0 +1,32 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilteredGraph(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.Parse(strings.NewReader(largeTurtle(3)), "text/turtle"))
+	secret := NewResource(testUri + "#secret")
+	g.AddTriple(NewResource(testUri+"#p0"), secret, NewLiteral("s3cr3t"))
+
+	public := FilteredGraph(g, func(t *Triple) bool { return !t.Predicate.Equal(secret) })
+	assert.Equal(t, 7, g.Len())
+	assert.Equal(t, 6, public.Len())
+	assert.Len(t, public.All(NewResource(testUri+"#p0"), nil, nil), 2)
+	assert.Nil(t, public.One(nil, secret, nil))
+	assert.NotNil(t, public.One(nil, nil, nil))
+	out, err := public.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "s3cr3t")
+
+	// changes go through to g when allowed
+	g.AddTriple(NewResource(testUri+"#p9"), NewResource(nsFOAF+"name"), NewLiteral("new"))
+	assert.Equal(t, 7, public.Len())
+	public.AddTriple(NewResource(testUri+"#p1"), secret, NewLiteral("ignored"))
+	assert.Equal(t, 8, g.Len())
+}
@@ -0,0 +1,101 @@
+package rdf2go
+
+import "sort"
+
+// FindSubjects returns the subjects that have all the given predicate and
+// object pairs, e.g. the node of a person given their name and email, sorted
+// and each once. A nil object matches any value of its predicate. An empty
+// example matches no subject.
+func (g *Graph) FindSubjects(example map[Term]Term) []Term {
+	if len(example) == 0 {
+		return nil
+	}
+	// start from a pair with an object, which usually matches few triples
+	var first Term
+	for p, o := range example {
+		if first == nil || o != nil {
+			first = p
+		}
+		if o != nil {
+			break
+		}
+	}
+	var subjects []Term
+	for _, s := range g.SubjectsWith(first, example[first]) {
+		if g.hasAll(s, example) {
+			subjects = append(subjects, s)
+		}
+	}
+	return subjects
+}
+
+// hasAll returns whether s has all the predicate and object pairs
+func (g *Graph) hasAll(s Term, example map[Term]Term) bool {
+	for p, o := range example {
+		if g.One(s, p, o) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// SubjectsWithObject returns the subjects of the triples whose object is o,
+// i.e. the nodes linking to o, sorted and each once
+func (g *Graph) SubjectsWithObject(o Term) []Term {
+	return g.SubjectsWith(nil, o)
+}
+
+// SubjectsWith returns the subjects of the triples with predicate p and
+// object o, sorted and each once. A nil predicate matches any predicate.
+func (g *Graph) SubjectsWith(p Term, o Term) []Term {
+	var subjects []Term
+	for _, t := range g.All(nil, p, o) {
+		subjects = append(subjects, t.Subject)
+	}
+	return sortedUniqueTerms(subjects)
+}
+
+// PredicatesTo returns the predicates of the triples whose object is o,
+// sorted and each once
+func (g *Graph) PredicatesTo(o Term) []Term {
+	var predicates []Term
+	for _, t := range g.All(nil, nil, o) {
+		predicates = append(predicates, t.Predicate)
+	}
+	return sortedUniqueTerms(predicates)
+}
+
+// PredicatesBetween returns the predicates linking s to o, sorted and each
+// once. Use PredicatesBetween(o, s) for the links from o to s.
+func (g *Graph) PredicatesBetween(s Term, o Term) []Term {
+	var predicates []Term
+	for _, t := range g.All(nil, nil, o) {
+		if t.Subject.Equal(s) {
+			predicates = append(predicates, t.Predicate)
+		}
+	}
+	return sortedUniqueTerms(predicates)
+}
+
+// Backlinks returns the triples whose object is o, sorted
+func (g *Graph) Backlinks(o Term) []*Triple {
+	triples := g.All(nil, nil, o)
+	sort.Slice(triples, func(i, j int) bool { return lessTriple(triples[i], triples[j]) })
+	return triples
+}
+
+// sortedUniqueTerms sorts terms and removes the duplicates
+func sortedUniqueTerms(terms []Term) []Term {
+	keys := make(map[Term]string, len(terms))
+	for _, t := range terms {
+		keys[t] = encodeTerm(t)
+	}
+	sort.Slice(terms, func(i, j int) bool { return keys[terms[i]] < keys[terms[j]] })
+	var unique []Term
+	for i, t := range terms {
+		if i == 0 || keys[t] != keys[terms[i-1]] {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
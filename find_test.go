@@ -0,0 +1,58 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSubjects(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix ex: <http://example.org/> .
+ex:alice a foaf:Person ; foaf:name "Alice" ; foaf:mbox <mailto:alice@example.org> .
+ex:bob a foaf:Person ; foaf:name "Bob" .
+ex:carol a foaf:Person ; foaf:name "Alice" ; foaf:nick "caz" .
+ex:acme a foaf:Organization ; foaf:name "Alice" .`, "text/turtle"))
+	foaf := func(name string) Term { return NewResource(nsFOAF + name) }
+	ex := func(name string) Term { return NewResource("http://example.org/" + name) }
+	rdfType := NewResource(nsRDF + "type")
+
+	assert.Equal(t, []Term{ex("alice"), ex("carol")}, g.FindSubjects(map[Term]Term{
+		rdfType:      foaf("Person"),
+		foaf("name"): NewLiteral("Alice"),
+	}))
+	assert.Equal(t, []Term{ex("alice")}, g.FindSubjects(map[Term]Term{
+		foaf("name"): NewLiteral("Alice"),
+		foaf("mbox"): NewResource("mailto:alice@example.org"),
+	}))
+	assert.Equal(t, []Term{ex("carol")}, g.FindSubjects(map[Term]Term{
+		foaf("name"): NewLiteral("Alice"),
+		foaf("nick"): nil,
+	}))
+	assert.Equal(t, []Term{ex("alice")}, g.FindSubjects(map[Term]Term{foaf("mbox"): nil}))
+	assert.Empty(t, g.FindSubjects(map[Term]Term{foaf("name"): NewLiteral("Dave")}))
+	assert.Empty(t, g.FindSubjects(nil))
+}
+
+func TestInverseLookups(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix ex: <http://example.org/> .
+ex:alice foaf:knows ex:bob ; foaf:based_near ex:paris .
+ex:carol foaf:knows ex:bob ; foaf:made ex:bob .
+ex:bob foaf:knows ex:alice .`, "text/turtle"))
+	foaf := func(name string) Term { return NewResource(nsFOAF + name) }
+	ex := func(name string) Term { return NewResource("http://example.org/" + name) }
+
+	assert.Equal(t, []Term{ex("alice"), ex("carol")}, g.SubjectsWithObject(ex("bob")))
+	assert.Equal(t, []Term{ex("alice"), ex("carol")}, g.SubjectsWith(foaf("knows"), ex("bob")))
+	assert.Equal(t, []Term{ex("carol")}, g.SubjectsWith(foaf("made"), ex("bob")))
+	assert.Equal(t, []Term{foaf("knows"), foaf("made")}, g.PredicatesTo(ex("bob")))
+	assert.Equal(t, []Term{foaf("knows"), foaf("made")}, g.PredicatesBetween(ex("carol"), ex("bob")))
+	assert.Equal(t, []Term{foaf("knows")}, g.PredicatesBetween(ex("bob"), ex("alice")))
+	assert.Empty(t, g.PredicatesBetween(ex("alice"), ex("carol")))
+	assert.Len(t, g.Backlinks(ex("bob")), 3)
+	assert.Empty(t, g.SubjectsWithObject(ex("carol")))
+}
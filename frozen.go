@@ -0,0 +1,214 @@
+package rdf2go
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrFrozen is returned when trying to modify a frozen graph
+var ErrFrozen = errors.New("graph is frozen and cannot be modified")
+
+// FrozenGraph is a read-only graph. Triples are kept in a slice sorted by
+// subject, predicate and object, which uses less memory than a Graph and
+// allows lookups by subject (with a binary search) without scanning all the
+// triples.
+type FrozenGraph struct {
+	triples []*Triple
+	uri     string
+	term    Term
+}
+
+// Freeze returns a read-only copy of the graph
+func (g *Graph) Freeze() *FrozenGraph {
+	return &FrozenGraph{
+		triples: g.Triples(true),
+		uri:     g.uri,
+		term:    g.term,
+	}
+}
+
+// Triples returns the triples of the graph in a new slice, which the caller
+// may modify, e.g. to run algorithms without the overhead of IterTriples.
+// When sorted is set, the triples are ordered by subject, predicate and
+// object, which is the same order from one call to the next.
+func (g *Graph) Triples(sorted bool) []*Triple {
+	var triples []*Triple
+	if l, ok := g.store.(lister); ok {
+		triples = l.Triples()
+	} else {
+		triples = make([]*Triple, 0, g.Len())
+		for triple := range g.IterTriples() {
+			triples = append(triples, triple)
+		}
+	}
+	if sorted {
+		sort.Slice(triples, func(i, j int) bool {
+			return lessTriple(triples[i], triples[j])
+		})
+	}
+	return triples
+}
+
+// lessTriple orders triples by subject, predicate and object
+func lessTriple(a *Triple, b *Triple) bool {
+	if s1, s2 := encodeTerm(a.Subject), encodeTerm(b.Subject); s1 != s2 {
+		return s1 < s2
+	}
+	if p1, p2 := encodeTerm(a.Predicate), encodeTerm(b.Predicate); p1 != p2 {
+		return p1 < p2
+	}
+	return encodeTerm(a.Object) < encodeTerm(b.Object)
+}
+
+// Thaw returns a modifiable copy of the frozen graph
+func (f *FrozenGraph) Thaw() *Graph {
+	g := NewGraph(f.uri)
+	g.BulkAdd(f.triples)
+	return g
+}
+
+// Len returns the number of triples in the graph
+func (f *FrozenGraph) Len() int {
+	return len(f.triples)
+}
+
+// Term returns the graph Term object
+func (f *FrozenGraph) Term() Term {
+	return f.term
+}
+
+// URI returns the graph URI
+func (f *FrozenGraph) URI() string {
+	return f.uri
+}
+
+// IterTriples provides a channel containing all the triples in the graph,
+// sorted by subject, predicate and object.
+// Note that the returned channel is already closed.
+func (f *FrozenGraph) IterTriples() (ch chan *Triple) {
+	ch = make(chan *Triple, len(f.triples))
+	for _, triple := range f.triples {
+		ch <- triple
+	}
+	close(ch)
+	return ch
+}
+
+// candidates returns the triples that may match the given subject
+func (f *FrozenGraph) candidates(s Term) []*Triple {
+	if s == nil {
+		return f.triples
+	}
+	key := encodeTerm(s)
+	start := sort.Search(len(f.triples), func(i int) bool {
+		return encodeTerm(f.triples[i].Subject) >= key
+	})
+	end := start + sort.Search(len(f.triples)-start, func(i int) bool {
+		return encodeTerm(f.triples[start+i].Subject) > key
+	})
+	return f.triples[start:end]
+}
+
+// One returns one triple based on a triple pattern of S, P, O objects
+func (f *FrozenGraph) One(s Term, p Term, o Term) *Triple {
+	for _, triple := range f.candidates(s) {
+		if matchTriple(triple, s, p, o) {
+			return triple
+		}
+	}
+	return nil
+}
+
+// All is used to return all triples that match a given pattern of S, P, O objects
+func (f *FrozenGraph) All(s Term, p Term, o Term) []*Triple {
+	var triples []*Triple
+	if s == nil && p == nil && o == nil {
+		return triples
+	}
+	for _, triple := range f.candidates(s) {
+		if matchTriple(triple, s, p, o) {
+			triples = append(triples, triple)
+		}
+	}
+	return triples
+}
+
+// Add always fails with ErrFrozen
+func (f *FrozenGraph) Add(t *Triple) error {
+	return ErrFrozen
+}
+
+// AddTriple always fails with ErrFrozen
+func (f *FrozenGraph) AddTriple(s Term, p Term, o Term) error {
+	return ErrFrozen
+}
+
+// Remove always fails with ErrFrozen
+func (f *FrozenGraph) Remove(t *Triple) error {
+	return ErrFrozen
+}
+
+// Merge always fails with ErrFrozen
+func (f *FrozenGraph) Merge(toMerge *Graph) error {
+	return ErrFrozen
+}
+
+// Parse always fails with ErrFrozen
+func (f *FrozenGraph) Parse(reader io.Reader, mime string) error {
+	return ErrFrozen
+}
+
+// String is used to serialize the graph object using NTriples
+func (f *FrozenGraph) String() string {
+	var b strings.Builder
+	b.Grow(len(f.triples) * nTripleSizeHint)
+	for _, triple := range f.triples {
+		b.WriteString(triple.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Serialize is used to serialize a graph based on a given mime type
+func (f *FrozenGraph) Serialize(w io.Writer, mime string) error {
+	return NewGraphWithStore(f.uri, readOnlyStore{f}).Serialize(w, mime)
+}
+
+// readOnlyGraph is implemented by the graphs that cannot be modified
+type readOnlyGraph interface {
+	One(s Term, p Term, o Term) *Triple
+	All(s Term, p Term, o Term) []*Triple
+	IterTriples() chan *Triple
+	Len() int
+}
+
+// readOnlyStore exposes the triples of a read-only graph as a Store without
+// copying them, for the read-only uses of a Graph such as serialization
+type readOnlyStore struct {
+	readOnlyGraph
+}
+
+func (s readOnlyStore) Add(t *Triple) {
+	panic(ErrFrozen)
+}
+
+func (s readOnlyStore) Remove(t *Triple) {
+	panic(ErrFrozen)
+}
+
+// matchTriple returns whether a triple matches a pattern of S, P, O objects,
+// where nil matches anything
+func matchTriple(triple *Triple, s Term, p Term, o Term) bool {
+	if s != nil && !triple.Subject.Equal(s) {
+		return false
+	}
+	if p != nil && !triple.Predicate.Equal(p) {
+		return false
+	}
+	if o != nil && !triple.Object.Equal(o) {
+		return false
+	}
+	return true
+}
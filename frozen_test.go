@@ -0,0 +1,81 @@
+package rdf2go
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("b"), NewResource("p"), NewResource("c"))
+	g.AddTriple(NewResource("a"), NewResource("p"), NewResource("c"))
+	g.AddTriple(NewResource("a"), NewResource("q"), NewLiteral("d"))
+
+	f := g.Freeze()
+	assert.Equal(t, 3, f.Len())
+	assert.Equal(t, testUri, f.URI())
+	assert.Equal(t, g.Term(), f.Term())
+	assert.Equal(t, "<a> <p> <c> .\n<a> <q> \"d\" .\n<b> <p> <c> .\n", f.String())
+
+	// the frozen graph does not change along with the original
+	g.AddTriple(NewResource("a"), NewResource("r"), NewResource("e"))
+	assert.Equal(t, 3, f.Len())
+
+	assert.Equal(t, 2, len(f.All(NewResource("a"), nil, nil)))
+	assert.Equal(t, 2, len(f.All(nil, NewResource("p"), nil)))
+	assert.Equal(t, 0, len(f.All(nil, nil, nil)))
+	assert.Equal(t, 0, len(f.All(NewResource("z"), nil, nil)))
+	assert.NotNil(t, f.One(NewResource("a"), NewResource("q"), NewLiteral("d")))
+	assert.Nil(t, f.One(NewResource("b"), NewResource("q"), nil))
+	assert.NotNil(t, f.One(nil, nil, nil))
+	assert.Equal(t, 3, len(f.IterTriples()))
+}
+
+func TestFrozenMutations(t *testing.T) {
+	f := NewGraph(testUri).Freeze()
+	triple := NewTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	assert.Equal(t, ErrFrozen, f.Add(triple))
+	assert.Equal(t, ErrFrozen, f.AddTriple(NewResource("a"), NewResource("b"), NewResource("c")))
+	assert.Equal(t, ErrFrozen, f.Remove(triple))
+	assert.Equal(t, ErrFrozen, f.Merge(NewGraph(testUri)))
+	assert.Equal(t, ErrFrozen, f.Parse(strings.NewReader(simpleTurtle), "text/turtle"))
+	assert.Equal(t, 0, f.Len())
+}
+
+func TestFrozenThawAndSerialize(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	f := g.Freeze()
+
+	g2 := f.Thaw()
+	g2.AddTriple(NewResource("a"), NewResource("b"), NewResource("d"))
+	assert.Equal(t, 2, g2.Len())
+	assert.Equal(t, 1, f.Len())
+
+	b := new(bytes.Buffer)
+	assert.NoError(t, f.Serialize(b, "text/turtle"))
+	assert.Equal(t, "<a>\n  <b> <c> .", b.String())
+}
+
+func TestFrozenLookupBySubject(t *testing.T) {
+	g := NewGraph(testUri)
+	for i := 0; i < 100; i++ {
+		for j := 0; j < i%4; j++ {
+			g.AddTriple(NewResource(fmt.Sprint("http://ex.org/s", i)), NewResource(fmt.Sprint("http://ex.org/p", j)), NewLiteral("o"))
+		}
+	}
+	f := g.Freeze()
+	for i := 0; i < 100; i++ {
+		assert.Len(t, f.All(NewResource(fmt.Sprint("http://ex.org/s", i)), nil, nil), i%4)
+	}
+
+	b := new(bytes.Buffer)
+	assert.NoError(t, f.Serialize(b, "application/ld+json"))
+	g2 := NewGraph(testUri)
+	assert.NoError(t, g2.Parse(b, "application/ld+json"))
+	assert.Equal(t, f.Len(), g2.Len())
+}
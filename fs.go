@@ -0,0 +1,52 @@
+package rdf2go
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// LoadFS parses the files of fsys whose names match glob (see fs.Glob) and
+// adds their triples to the graph, e.g. files embedded with go:embed. The
+// format of each file is detected from its extension, and relative IRIs are
+// resolved against the URI of the graph. Files with an unknown extension or
+// invalid content are skipped, and each of them adds an entry, prefixed with
+// the file name, to the returned error. The blank nodes of each file are
+// kept apart, so that files using the same labels do not share them.
+func (g *Graph) LoadFS(fsys fs.FS, glob string) error {
+	names, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, name := range names {
+		mime := mimeRdfExt[path.Ext(name)]
+		if _, ok := mimeParser[mime]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unsupported file format", name))
+			continue
+		}
+		err := g.loadFile(fsys, name, mime)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *Graph) loadFile(fsys fs.FS, name string, mime string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	doc := NewGraph(g.uri)
+	doc.limits = g.limits
+	doc.warn = g.warn
+	doc.parseMode = g.parseMode
+	doc.keepPartial = g.keepPartial
+	err = doc.Parse(f, mime)
+	// with SetKeepPartial, doc holds the triples parsed before an error
+	g.mergeDocument(doc)
+	return err
+}
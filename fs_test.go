@@ -0,0 +1,57 @@
+package rdf2go
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"data/a.ttl":    {Data: []byte(simpleTurtle)},
+		"data/b.jsonld": {Data: []byte(`{"@id": "http://example.org/b", "http://xmlns.com/foaf/0.1/name": "B"}`)},
+		"data/c.txt":    {Data: []byte("not rdf")},
+	}
+	g := NewGraph(testUri)
+	err := g.LoadFS(fsys, "data/*.ttl")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.Len())
+
+	err = g.LoadFS(fsys, "data/*.jsonld")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, g.Len())
+	assert.NotNil(t, g.One(NewResource("http://example.org/b"), nil, nil))
+}
+
+func TestLoadFSErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.ttl": {Data: []byte(simpleTurtle)},
+		"b.ttl": {Data: []byte("<a> <b")},
+		"c.n3":  {Data: []byte(simpleTurtle)},
+	}
+	g := NewGraph(testUri)
+	err := g.LoadFS(fsys, "*")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "b.ttl: line 1")
+	assert.Contains(t, err.Error(), "c.n3: unsupported file format")
+	assert.Equal(t, 2, g.Len())
+
+	err = g.LoadFS(fsys, "[")
+	assert.Error(t, err)
+}
+
+func TestLoadFSBlankNodes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.ttl": {Data: []byte(`<http://example.org/a> <http://example.org/p> [ <http://example.org/name> "A" ] .`)},
+		"b.ttl": {Data: []byte(`<http://example.org/b> <http://example.org/p> [ <http://example.org/name> "B" ] .`)},
+	}
+	g := NewGraph(testUri)
+	assert.NoError(t, g.LoadFS(fsys, "*.ttl"))
+	assert.Equal(t, 4, g.Len())
+	a := g.One(NewResource("http://example.org/a"), nil, nil).Object
+	b := g.One(NewResource("http://example.org/b"), nil, nil).Object
+	assert.False(t, a.Equal(b))
+	assert.Len(t, g.All(a, NewResource("http://example.org/name"), nil), 1)
+	assert.Len(t, g.All(b, NewResource("http://example.org/name"), nil), 1)
+}
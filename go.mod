@@ -6,6 +6,7 @@ require (
 	github.com/deiu/gon3 v0.0.0-20241212124032-93153c038193
 	github.com/linkeddata/gojsonld v0.0.0-20170418210642-4f5db6791326
 	github.com/stretchr/testify v1.8.2
+	golang.org/x/text v0.14.0
 )
 
 require (
@@ -1,24 +1,51 @@
 package rdf2go
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"crypto/tls"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
-	rdf "github.com/deiu/gon3"
 	jsonld "github.com/linkeddata/gojsonld"
 )
 
 // Graph structure
 type Graph struct {
-	triples    map[*Triple]bool
+	store      Store
 	httpClient *http.Client
 	uri        string
 	term       Term
+	limits     Limits
+	prov       *provenance
+	warn       func(Warning)
+	cache      *GraphCache
+	meta       Metadata
+	// minted holds the IRIs minted by MintIRI and AddSingleton, and
+	// mintNext the counter to try next for each of their prefixes, both
+	// guarded by mintMu
+	mintMu   sync.Mutex
+	minted   map[string]bool
+	mintNext map[string]int
+	// noAutoPrefixes disables the prefixes of well-known vocabularies in
+	// the serialized graph
+	noAutoPrefixes bool
+	// jsonldContext is used to compact the graph serialized to JSON-LD
+	jsonldContext *JSONLDContext
+	// prefixes holds the prefixes declared in the parsed documents
+	prefixes map[string]string
+	// keepPartial keeps the triples parsed before an error, see
+	// SetKeepPartial
+	keepPartial bool
+	// parseMode is set with SetParseMode
+	parseMode ParseMode
+	// strictIRIs is set with SetStrictIRIs
+	strictIRIs bool
 }
 
 // NewHttpClient creates an http.Client to be used for parsing resources
@@ -35,12 +62,17 @@ func NewHttpClient(skip bool) *http.Client {
 
 // NewGraph creates a Graph object
 func NewGraph(uri string, skipVerify ...bool) *Graph {
+	return NewGraphWithStore(uri, NewMapStore(), skipVerify...)
+}
+
+// NewGraphWithStore creates a Graph object backed by the given Store
+func NewGraphWithStore(uri string, store Store, skipVerify ...bool) *Graph {
 	skip := false
 	if len(skipVerify) > 0 {
 		skip = skipVerify[0]
 	}
 	g := &Graph{
-		triples:    make(map[*Triple]bool),
+		store:      store,
 		httpClient: NewHttpClient(skip),
 		uri:        uri,
 		term:       NewResource(uri),
@@ -50,7 +82,7 @@ func NewGraph(uri string, skipVerify ...bool) *Graph {
 
 // Len returns the length of the graph as number of triples in the graph
 func (g *Graph) Len() int {
-	return len(g.triples)
+	return g.store.Len()
 }
 
 // Term returns a Graph Term object
@@ -65,53 +97,35 @@ func (g *Graph) URI() string {
 
 // One returns one triple based on a triple pattern of S, P, O objects
 func (g *Graph) One(s Term, p Term, o Term) *Triple {
-	for triple := range g.IterTriples() {
-		if s != nil {
-			if p != nil {
-				if o != nil {
-					if triple.Subject.Equal(s) && triple.Predicate.Equal(p) && triple.Object.Equal(o) {
-						return triple
-					}
-				} else {
-					if triple.Subject.Equal(s) && triple.Predicate.Equal(p) {
-						return triple
-					}
-				}
-			} else {
-				if triple.Subject.Equal(s) {
-					return triple
-				}
-			}
-		} else if p != nil {
-			if o != nil {
-				if triple.Predicate.Equal(p) && triple.Object.Equal(o) {
-					return triple
-				}
-			} else {
-				if triple.Predicate.Equal(p) {
-					return triple
-				}
-			}
-		} else if o != nil {
-			if triple.Object.Equal(o) {
-				return triple
-			}
-		} else {
-			return triple
-		}
-	}
-	return nil
+	return g.store.One(s, p, o)
 }
 
 // IterTriples provides a channel containing all the triples in the graph.
 // Note that the returned channel is already closed.
 func (g *Graph) IterTriples() (ch chan *Triple) {
-	// This function returns a channel rather than a slice for backwards compatibility.
-	// It does not use a goroutine to populate the channel because that can trigger Go's 'concurrent map misuse'
-	// detector, and would have little performance benefit.
-	ch = make(chan *Triple, len(g.triples))
-	for triple := range g.triples {
-		ch <- triple
+	return g.store.IterTriples()
+}
+
+// IterBySubject provides a channel containing the triples of the graph
+// grouped by subject: each value holds all the triples of a subject, sorted
+// by predicate and object, and subjects are sorted too. The groups share a
+// single sorted slice of the triples rather than an index of the graph.
+// Note that the returned channel is already closed.
+func (g *Graph) IterBySubject() (ch chan []*Triple) {
+	triples := g.Triples(true)
+	n := 0
+	for i, triple := range triples {
+		if i == 0 || !triple.Subject.Equal(triples[i-1].Subject) {
+			n++
+		}
+	}
+	ch = make(chan []*Triple, n)
+	start := 0
+	for i := 1; i <= len(triples); i++ {
+		if i == len(triples) || !triples[i].Subject.Equal(triples[start].Subject) {
+			ch <- triples[start:i:i]
+			start = i
+		}
 	}
 	close(ch)
 	return ch
@@ -119,185 +133,548 @@ func (g *Graph) IterTriples() (ch chan *Triple) {
 
 // Add is used to add a Triple object to the graph
 func (g *Graph) Add(t *Triple) {
-	g.triples[t] = true
+	g.store.Add(t)
+}
+
+// BulkAdd is used to add a large number of Triple objects at once, which is
+// faster than adding them one by one for stores supporting it.
+func (g *Graph) BulkAdd(triples []*Triple) {
+	if b, ok := g.store.(bulkAdder); ok {
+		b.BulkAdd(triples)
+		return
+	}
+	for _, t := range triples {
+		g.store.Add(t)
+	}
 }
 
-// AddTriple is used to add a triple made of individual S, P, O objects
+// AddTriple is used to add a triple made of individual S, P, O objects. No
+// triple is allocated if an equal one is already in the graph.
 func (g *Graph) AddTriple(s Term, p Term, o Term) {
-	g.triples[NewTriple(s, p, o)] = true
+	g.AddTripleIfAbsent(s, p, o)
+}
+
+// AddTripleIfAbsent adds the triple made of s, p, o unless an equal triple
+// is already in the graph, and returns the triple of the graph along with
+// whether it was added
+func (g *Graph) AddTripleIfAbsent(s Term, p Term, o Term) (*Triple, bool) {
+	if a, ok := g.store.(absentAdder); ok {
+		return a.AddIfAbsent(s, p, o)
+	}
+	if t := g.store.One(s, p, o); t != nil {
+		return t, false
+	}
+	t := NewTriple(s, p, o)
+	g.store.Add(t)
+	return t, true
+}
+
+// Contains returns whether the graph holds a triple matching a pattern of
+// S, P, O objects, where nil matches anything. The default store stops at
+// the first match, and looks up complete patterns directly.
+func (g *Graph) Contains(s Term, p Term, o Term) bool {
+	return g.store.One(s, p, o) != nil
+}
+
+// Count returns the number of triples matching a pattern of S, P, O
+// objects, where nil matches anything. The default store counts them
+// without collecting them in a slice as All does.
+func (g *Graph) Count(s Term, p Term, o Term) int {
+	if c, ok := g.store.(counter); ok {
+		return c.Count(s, p, o)
+	}
+	if s == nil && p == nil && o == nil {
+		return g.store.Len()
+	}
+	n := 0
+	for _, t := range g.store.All(s, p, o) {
+		if matchTriple(t, s, p, o) {
+			n++
+		}
+	}
+	return n
 }
 
 // Remove is used to remove a Triple object
 func (g *Graph) Remove(t *Triple) {
-	delete(g.triples, t)
+	g.store.Remove(t)
 }
 
-// All is used to return all triples that match a given pattern of S, P, O objects
-func (g *Graph) All(s Term, p Term, o Term) []*Triple {
-	var triples []*Triple
-	for triple := range g.IterTriples() {
-		if s != nil {
-			if p != nil {
-				if o != nil {
-					if triple.Subject.Equal(s) && triple.Predicate.Equal(p) && triple.Object.Equal(o) {
-						triples = append(triples, triple)
-					}
-				} else {
-					if triple.Subject.Equal(s) && triple.Predicate.Equal(p) {
-						triples = append(triples, triple)
-					}
-				}
-			} else {
-				if triple.Subject.Equal(s) {
-					triples = append(triples, triple)
-				}
-			}
-		} else if p != nil {
-			if o != nil {
-				if triple.Predicate.Equal(p) && triple.Object.Equal(o) {
-					triples = append(triples, triple)
-				}
-			} else {
-				if triple.Predicate.Equal(p) {
-					triples = append(triples, triple)
-				}
-			}
-		} else if o != nil {
-			if triple.Object.Equal(o) {
-				triples = append(triples, triple)
-			}
+// Snapshot returns a view of the graph as it is now. For the default store,
+// creating a snapshot is cheap, as the triples are only copied once either
+// graph is modified, so readers can keep using a consistent snapshot while
+// writers update g.
+func (g *Graph) Snapshot() *Graph {
+	var store Store
+	if s, ok := g.store.(snapshotter); ok {
+		store = s.Snapshot()
+	} else {
+		store = NewMapStore()
+		for triple := range g.store.IterTriples() {
+			store.Add(triple)
 		}
 	}
-	return triples
+	return &Graph{
+		store:      store,
+		httpClient: g.httpClient,
+		uri:        g.uri,
+		term:       g.term,
+		limits:     g.limits,
+		prov:       g.prov,
+		warn:       g.warn,
+		cache:      g.cache,
+		meta:       g.meta.copy(),
+
+		noAutoPrefixes: g.noAutoPrefixes,
+		jsonldContext:  g.jsonldContext,
+		prefixes:       g.Prefixes(),
+		keepPartial:    g.keepPartial,
+		parseMode:      g.parseMode,
+		strictIRIs:     g.strictIRIs,
+	}
+}
+
+// Store returns the Store backing the graph
+func (g *Graph) Store() Store {
+	return g.store
+}
+
+// All is used to return all triples that match a given pattern of S, P, O objects
+func (g *Graph) All(s Term, p Term, o Term) []*Triple {
+	return g.store.All(s, p, o)
 }
 
 // Merge is used to add all the triples form another graph to this one
 func (g *Graph) Merge(toMerge *Graph) {
+	started := time.Now()
+	g.merge(toMerge)
+	g.recordActivity("merge", toMerge.Term(), started)
+}
+
+func (g *Graph) merge(toMerge *Graph) {
 	for triple := range toMerge.IterTriples() {
 		g.Add(triple)
 	}
+	g.addPrefixes(toMerge.prefixes)
 }
 
-// Parse is used to parse RDF data from a reader, using the provided mime type
+// Parse is used to parse RDF data from a reader, using the provided mime type.
+// Blank nodes keep the labels given by the parser, so the blank nodes of
+// documents parsed into the same graph one after the other may be merged;
+// LoadFS, LoadURIs and LoadPaged keep the documents they load apart.
 func (g *Graph) Parse(reader io.Reader, mime string) error {
+	return g.ParseContext(context.Background(), reader, mime)
+}
+
+// ParseContext is like Parse, but parsing stops with the error of ctx once
+// it is done, which protects services from slow or endless request bodies.
+// The input is checked between reads, and the parser between Turtle
+// statements or JSON-LD triples.
+// Readers with a SetReadDeadline method, such as net.Conn, also have a
+// blocked read interrupted. The parse span is created as a child of the
+// span found in ctx.
+func (g *Graph) ParseContext(ctx context.Context, reader io.Reader, mime string) error {
+	return g.parseAdd(ctx, reader, mime)
+}
+
+// ParseString parses RDF data from a string, using the provided mime type
+func (g *Graph) ParseString(data string, mime string) error {
+	return g.Parse(strings.NewReader(data), mime)
+}
+
+// parseAdd parses the triples found in the reader and adds them to the graph
+func (g *Graph) parseAdd(ctx context.Context, reader io.Reader, mime string) error {
+	_, span := startSpan(ctx, "rdf2go.Parse")
+	defer span.End()
+	span.SetAttribute("mime", mime)
+	started := time.Now()
+	triples, prefixes, err := g.parse(ctx, reader, mime)
+	if m := currentMetrics(); m != nil {
+		m.ObserveParse(mime, len(triples), time.Since(started), err)
+	}
+	if err != nil {
+		span.RecordError(err)
+	} else {
+		span.SetAttribute("triples", len(triples))
+	}
+	return g.addParsed(triples, prefixes, err)
+}
+
+// addParsed adds the triples and prefixes returned by parse to the graph.
+// On errors, the triples parsed before the error are only added if
+// SetKeepPartial is set, and the error is returned as a PartialParseError.
+func (g *Graph) addParsed(triples []*Triple, prefixes map[string]string, err error) error {
+	if err != nil {
+		if !g.keepPartial || len(triples) == 0 {
+			return err
+		}
+		n := g.Len()
+		g.BulkAdd(triples)
+		g.addPrefixes(prefixes)
+		return &PartialParseError{Parsed: len(triples), Added: g.Len() - n, Err: err}
+	}
+	g.BulkAdd(triples)
+	g.addPrefixes(prefixes)
+	return nil
+}
+
+// parse reads all the triples found in the reader, and the prefixes it
+// declares, without adding them to the graph. On syntax errors, it returns
+// the triples parsed before the error along with it.
+func (g *Graph) parse(ctx context.Context, reader io.Reader, mime string) (triples []*Triple, prefixes map[string]string, err error) {
+	// the underlying parsers are not hardened against arbitrary input and may
+	// panic (e.g. on malformed \u escapes), so we turn panics into errors
+	defer func() {
+		if r := recover(); r != nil {
+			triples, prefixes = nil, nil
+			err = fmt.Errorf("could not parse %s data: %v", mime, r)
+		}
+	}()
 	parserName := mimeParser[mime]
-	if len(parserName) == 0 {
-		parserName = "guess"
+	if parserName != "jsonld" && parserName != "ndjsonld" && parserName != "turtle" {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, mime)
+	}
+	reader, done := withContext(ctx, reader)
+	defer done()
+	reader = g.limits.reader(reader)
+	b := newTripleBuilder()
+	if parserName == "ndjsonld" {
+		err = g.parseNDJSONLD(ctx, reader, b, nil)
+		if err != nil {
+			return b.triples, nil, err
+		}
+		return g.checkParsed(b.triples, nil)
+	}
+	if parserName == "jsonld" {
+		br := bufio.NewReader(reader)
+		if startsJSONArray(br) {
+			err = g.parseJSONLDStream(ctx, br, b, nil)
+			if err != nil {
+				return b.triples, nil, err
+			}
+			return g.checkParsed(b.triples, nil)
+		}
+		reader = br
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	_, err = buf.ReadFrom(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := decodeInput(buf.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		if g.parseMode == ParseStrict {
+			return nil, nil, ErrEmptyInput
+		}
+		return nil, nil, nil
 	}
 	if parserName == "jsonld" {
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(reader)
-		jsonData, err := jsonld.ReadJSON(buf.Bytes())
+		jsonData, err := jsonld.ReadJSON(data)
 		if err != nil {
-			return err
+			return nil, nil, jsonParseError(data, err)
 		}
-		options := &jsonld.Options{}
-		options.Base = ""
-		options.ProduceGeneralizedRdf = false
-		dataSet, err := jsonld.ToRDF(jsonData, options)
+		err = g.parseJSONLD(ctx, jsonData, b)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		for t := range dataSet.IterTriples() {
-			g.AddTriple(jterm2term(t.Subject), jterm2term(t.Predicate), jterm2term(t.Object))
+	} else {
+		p := newTurtleParser(g.uri, b.add, turtleOptions{warn: g.warn, limits: g.limits, ctx: ctx})
+		err := p.parse(string(data))
+		prefixes = p.prefixes
+		if err != nil {
+			return b.triples, prefixes, err
 		}
+	}
+	return g.checkParsed(b.triples, prefixes)
+}
 
-	} else if parserName == "turtle" {
-		parser, err := rdf.NewParser(g.uri).Parse(reader)
-		if err != nil {
+// checkParsed returns the triples and prefixes of a document, unless its
+// blank nodes are nested too deep, or it has no triples in ParseStrict mode
+func (g *Graph) checkParsed(triples []*Triple, prefixes map[string]string) ([]*Triple, map[string]string, error) {
+	err := g.limits.checkDepth(triples)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(triples) == 0 && g.parseMode == ParseStrict {
+		return nil, nil, ErrEmptyInput
+	}
+	return triples, prefixes, nil
+}
+
+// parseJSONLD converts a JSON-LD document, as decoded by encoding/json, to
+// triples, stopping with the error of ctx once it is done
+func (g *Graph) parseJSONLD(ctx context.Context, jsonData interface{}, b *tripleBuilder) error {
+	options := &jsonld.Options{}
+	options.Base = ""
+	options.ProduceGeneralizedRdf = false
+	dataSet, err := jsonld.ToRDF(jsonData, options)
+	if err != nil {
+		return &ParseError{Msg: err.Error()}
+	}
+	for t := range dataSet.IterTriples() {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-		for s := range parser.IterTriples() {
-			g.AddTriple(rdf2term(s.Subject), rdf2term(s.Predicate), rdf2term(s.Object))
-		}
-	} else {
-		return errors.New(parserName + " is not supported by the parser")
+		b.add(jterm2term(t.Subject), jterm2term(t.Predicate), jterm2term(t.Object))
+	}
+	return g.checkJSONLDTriples(b.triples)
+}
+
+// SafeParse behaves like Parse, but guarantees that failures are only ever
+// reported as errors. Data is parsed into a scratch graph first and merged
+// into g only if parsing succeeded, so g is left untouched on error.
+func (g *Graph) SafeParse(reader io.Reader, mime string) error {
+	tmp := NewGraph(g.uri)
+	tmp.limits = g.limits
+	tmp.warn = g.warn
+	tmp.parseMode = g.parseMode
+	err := tmp.Parse(reader, mime)
+	if err != nil {
+		return err
 	}
+	g.merge(tmp)
 	return nil
 }
 
 // LoadURI is used to load RDF data from a specific URI
 func (g *Graph) LoadURI(uri string) error {
+	return g.LoadURIContext(context.Background(), uri)
+}
+
+// LoadURIContext behaves like LoadURI, but uses ctx for the request, which
+// can then be cancelled, and as the parent of the spans created when tracing
+func (g *Graph) LoadURIContext(ctx context.Context, uri string) error {
+	if g.cache != nil {
+		return g.loadCached(ctx, uri)
+	}
+	_, err := g.load(ctx, uri)
+	return err
+}
+
+// load fetches and parses the document at uri, returning the response headers
+func (g *Graph) load(ctx context.Context, uri string) (header http.Header, err error) {
 	doc := defrag(uri)
-	q, err := http.NewRequest("GET", doc, nil)
+	ctx, span := startSpan(ctx, "rdf2go.LoadURI")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+	span.SetAttribute("uri", doc)
+	q, err := http.NewRequestWithContext(ctx, "GET", doc, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(g.uri) == 0 {
 		g.uri = doc
 	}
 	q.Header.Set("Accept", "text/turtle;q=1,application/ld+json;q=0.5")
+	started := time.Now()
 	r, err := g.httpClient.Do(q)
+	if m := currentMetrics(); m != nil {
+		status := 0
+		if r != nil {
+			status = r.StatusCode
+		}
+		m.ObserveFetch(doc, status, time.Since(started), err)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if r != nil {
 		defer r.Body.Close()
+		span.SetAttribute("status", r.StatusCode)
 		if r.StatusCode == 200 {
-			g.Parse(r.Body, r.Header.Get("Content-Type"))
+			err = g.parseAdd(ctx, r.Body, mediaType(r.Header.Get("Content-Type")))
+			if err != nil {
+				return r.Header, err
+			}
+			g.meta.setResponse(g.uri, r)
+			g.recordActivity("load", NewResource(doc), started)
 		} else {
-			return fmt.Errorf("Could not fetch graph from %s - HTTP %d", uri, r.StatusCode)
+			return nil, fmt.Errorf("Could not fetch graph from %s - HTTP %d", uri, r.StatusCode)
 		}
+		return r.Header, nil
 	}
-	return nil
+	return nil, nil
 }
 
 // String is used to serialize the graph object using NTriples
 func (g *Graph) String() string {
-	var toString string
+	return string(g.AppendNTriples(make([]byte, 0, g.Len()*nTripleSizeHint)))
+}
+
+// nTripleSizeHint is the average size of a triple in NTriples, used to
+// preallocate the output of String
+const nTripleSizeHint = 96
+
+// WriteNTriples writes the triples of the graph to w in NTriples, one per
+// line, without building the whole output in memory like String does
+func (g *Graph) WriteNTriples(w io.Writer) error {
+	if g.strictIRIs {
+		if err := g.checkIRIs(); err != nil {
+			return err
+		}
+	}
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	for triple := range g.IterTriples() {
+		buf = append(triple.AppendNTriples(buf[:0]), '\n')
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// AppendNTriples appends the triples of the graph in NTriples, one per line,
+// to buf and returns the extended buffer
+func (g *Graph) AppendNTriples(buf []byte) []byte {
 	for triple := range g.IterTriples() {
-		toString += triple.String() + "\n"
+		buf = append(triple.AppendNTriples(buf), '\n')
 	}
-	return toString
+	return buf
 }
 
 // Serialize is used to serialize a graph based on a given mime type
 func (g *Graph) Serialize(w io.Writer, mime string) error {
+	return g.SerializeContext(context.Background(), w, mime)
+}
+
+// SerializeString serializes the graph to a string, based on a given mime type
+func (g *Graph) SerializeString(mime string) (string, error) {
+	var b strings.Builder
+	err := g.Serialize(&b, mime)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// SerializeContext behaves like Serialize, using ctx as the parent of the
+// span created when tracing
+func (g *Graph) SerializeContext(ctx context.Context, w io.Writer, mime string) error {
+	return g.serializeContext(ctx, w, mime, SerializeOptions{SortKeys: true})
+}
+
+func (g *Graph) serializeContext(ctx context.Context, w io.Writer, mime string, opts SerializeOptions) error {
+	_, span := startSpan(ctx, "rdf2go.Serialize")
+	defer span.End()
+	span.SetAttribute("mime", mime)
+	span.SetAttribute("triples", g.Len())
+	started := time.Now()
+	cw := &countingWriter{w: w}
+	err := g.serialize(cw, mime, opts)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.SetAttribute("bytes", cw.n)
+	if m := currentMetrics(); m != nil {
+		m.ObserveSerialize(mime, cw.n, time.Since(started), err)
+	}
+	return err
+}
+
+func (g *Graph) serialize(w io.Writer, mime string, opts SerializeOptions) error {
 	serializerName := mimeSerializer[mime]
 	if serializerName == "jsonld" {
-		return g.serializeJSONLD(w)
+		if opts.Context == nil {
+			opts.Context = g.jsonldContext
+		}
+		return g.serializeJSONLD(w, opts)
 	}
 	// just return Turtle by default
-	return g.serializeTurtle(w)
+	return g.serializeTurtle(w, opts)
 }
 
 // @TODO improve streaming
-func (g *Graph) serializeTurtle(w io.Writer) error {
+func (g *Graph) serializeTurtle(w io.Writer, opts SerializeOptions) error {
 	var err error
+	if g.strictIRIs {
+		if err = g.checkIRIs(); err != nil {
+			return err
+		}
+	}
 
-	triplesBySubject := make(map[string][]*Triple)
+	prefixes := g.usedPrefixes()
+	indent := opts.indent()
+	term := func(t Term) string {
+		s := turtleTerm(t, prefixes)
+		if r, ok := t.(*Resource); ok && len(opts.BaseIRI) > 0 && s[0] == '<' {
+			if rel, ok := relativeIRI(r.URI, opts.BaseIRI); ok {
+				s = "<" + escapeIRI(rel) + ">"
+			}
+		}
+		return s
+	}
 
-	for triple := range g.IterTriples() {
-		s := encodeTerm(triple.Subject)
-		triplesBySubject[s] = append(triplesBySubject[s], triple)
+	if len(opts.BaseIRI) > 0 {
+		_, err = fmt.Fprintf(w, "@base <%s> .\n", escapeIRI(opts.BaseIRI))
+		if err != nil {
+			return err
+		}
+	}
+	for _, prefix := range sortedPrefixes(prefixes) {
+		_, err = fmt.Fprintf(w, "@prefix %s: <%s> .\n", prefix, escapeIRI(prefixes[prefix]))
+		if err != nil {
+			return err
+		}
+	}
+	if len(prefixes) > 0 || len(opts.BaseIRI) > 0 {
+		_, err = io.WriteString(w, "\n")
+		if err != nil {
+			return err
+		}
 	}
 
-	for subject, triples := range triplesBySubject {
-		_, err = fmt.Fprintf(w, "%s\n", subject)
+	// triples are sorted so that serializing an unchanged graph gives the
+	// same output, blank node labels included
+	groups := g.IterBySubject()
+	if !opts.SortKeys {
+		groups = groupBySubject(g.Triples(false))
+	}
+	first := true
+	for triples := range groups {
+		if opts.Pretty && !first {
+			_, err = io.WriteString(w, "\n\n")
+			if err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = fmt.Fprintf(w, "%s\n", term(triples[0].Subject))
 		if err != nil {
 			return err
 		}
 
 		for key, triple := range triples {
-			p := encodeTerm(triple.Predicate)
-			o := encodeTerm(triple.Object)
+			p := term(triple.Predicate)
+			o := term(triple.Object)
 
 			if key == len(triples)-1 {
-				_, err = fmt.Fprintf(w, "  %s %s .", p, o)
+				_, err = fmt.Fprintf(w, "%s%s %s .", indent, p, o)
 				if err != nil {
 					return err
 				}
 				break
 			}
-			_, err = fmt.Fprintf(w, "  %s %s ;\n", p, o)
+			_, err = fmt.Fprintf(w, "%s%s %s ;\n", indent, p, o)
 			if err != nil {
 				return err
 			}
 		}
 
 	}
+	if opts.Pretty && !first {
+		_, err = io.WriteString(w, "\n")
+	}
 
-	return nil
+	return err
 }
 
 // func (g *Graph) serializeJSONLD(w io.Writer) error {
@@ -323,9 +700,13 @@ func (g *Graph) serializeTurtle(w io.Writer) error {
 // 	return err
 // }
 
-func (g *Graph) serializeJSONLD(w io.Writer) error {
+func (g *Graph) serializeJSONLD(w io.Writer, opts SerializeOptions) error {
+	if opts.Context != nil {
+		return g.serializeCompactJSONLD(w, opts)
+	}
+	prefixes := g.jsonldPrefixes()
 	r := []map[string]interface{}{}
-	for elt := range g.IterTriples() {
+	for _, elt := range g.Triples(opts.SortKeys) {
 		var one map[string]interface{}
 		switch elt.Subject.(type) {
 		case *BlankNode:
@@ -334,35 +715,58 @@ func (g *Graph) serializeJSONLD(w io.Writer) error {
 			}
 		default:
 			one = map[string]interface{}{
-				"@id": elt.Subject.(*Resource).URI,
+				"@id": opts.relative(elt.Subject.(*Resource).URI),
 			}
 		}
-		switch t := elt.Object.(type) {
-		case *Resource:
-			one[elt.Predicate.(*Resource).URI] = []map[string]string{
-				{
-					"@id": t.URI,
-				},
-			}
-			break
-		case *Literal:
-			v := map[string]string{
-				"@value": t.Value,
+		if v := jsonldValue(elt.Object, prefixes); v != nil {
+			if _, ok := elt.Object.(*Resource); ok {
+				v["@id"] = opts.relative(v["@id"].(string))
 			}
-			if t.Datatype != nil && len(t.Datatype.String()) > 0 {
-				v["@type"] = debrack(t.Datatype.String())
-			}
-			if len(t.Language) > 0 {
-				v["@language"] = t.Language
-			}
-			one[elt.Predicate.(*Resource).URI] = []map[string]string{v}
+			one[compactIRI(elt.Predicate.(*Resource).URI, prefixes)] = []map[string]interface{}{v}
 		}
 		r = append(r, one)
 	}
-	bytes, err := json.Marshal(r)
-	if err != nil {
-		return err
+	var doc interface{} = r
+	if len(prefixes) > 0 || len(opts.BaseIRI) > 0 {
+		context := make(map[string]interface{}, len(prefixes)+1)
+		for prefix, ns := range prefixes {
+			context[prefix] = ns
+		}
+		if len(opts.BaseIRI) > 0 {
+			context["@base"] = opts.BaseIRI
+		}
+		doc = map[string]interface{}{
+			"@context": context,
+			"@graph":   r,
+		}
+	}
+	return opts.writeJSON(w, doc)
+}
+
+// jsonldValue returns the JSON-LD value object of a term, with datatypes
+// compacted with the given prefixes
+func jsonldValue(o Term, prefixes map[string]string) map[string]interface{} {
+	switch t := o.(type) {
+	case *Resource:
+		return map[string]interface{}{"@id": t.URI}
+	case *BlankNode:
+		return map[string]interface{}{"@id": t.String()}
+	case *Literal:
+		v := map[string]interface{}{
+			"@value": t.Value,
+		}
+		if datatype := t.datatype(); datatype != nil && len(datatype.String()) > 0 {
+			v["@type"] = compactIRI(debrack(datatype.String()), prefixes)
+			if c := LookupDatatype(datatype.RawValue()); c != nil && c.JSON != nil {
+				if native, err := c.Parse(t.Value); err == nil {
+					v["@value"] = c.JSON(native)
+				}
+			}
+		}
+		if len(t.Language) > 0 {
+			v["@language"] = t.Language
+		}
+		return v
 	}
-	fmt.Fprintf(w, string(bytes))
 	return nil
 }
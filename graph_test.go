@@ -2,8 +2,11 @@ package rdf2go
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -31,6 +34,51 @@ func MockServer() http.Handler {
 		w.Write([]byte(simpleTurtle))
 		return
 	}))
+	handler.Handle("/charset", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "text/turtle; charset=utf-8")
+		w.WriteHeader(200)
+		w.Write([]byte(simpleTurtle))
+	}))
+	handler.Handle("/invalid", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "text/turtle")
+		w.WriteHeader(200)
+		w.Write([]byte("this is not turtle"))
+	}))
+	handler.Handle("/empty", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "text/turtle")
+		w.WriteHeader(200)
+		w.Write([]byte("@prefix foaf: <http://xmlns.com/foaf/0.1/> ."))
+	}))
+	handler.Handle("/catalog", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "text/turtle")
+		w.WriteHeader(200)
+		w.Write([]byte("@prefix dcat: <http://www.w3.org/ns/dcat#> .\n" +
+			"<#catalog> dcat:dataset [ dcat:distribution [ dcat:downloadURL <http://" + req.Host + "/foo> ; dcat:mediaType \"text/turtle\" ] ] ."))
+	}))
+	handler.Handle("/page/", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "text/turtle")
+		switch req.URL.Path {
+		case "/page/1":
+			w.WriteHeader(200)
+			w.Write([]byte("<#m1> <#p> \"1\" .\n<> <http://www.w3.org/ns/hydra/core#next> <2> ."))
+		case "/page/2":
+			w.Header().Add("Link", "<http://www.w3.org/ns/ldp#Resource>; rel=\"type\", </page/3>; rel=\"next\"")
+			w.WriteHeader(200)
+			w.Write([]byte("<#m2> <#p> \"2\" ."))
+		case "/page/3":
+			w.WriteHeader(200)
+			w.Write([]byte("<#m3> <#p> \"3\" ."))
+		case "/page/endless":
+			n, _ := strconv.Atoi(req.URL.Query().Get("n"))
+			w.WriteHeader(200)
+			w.Write([]byte(fmt.Sprintf("<> <http://www.w3.org/ns/hydra/core#next> <?n=%d> .", n+1)))
+		case "/page/loop":
+			w.WriteHeader(200)
+			w.Write([]byte("<> <http://www.w3.org/ns/hydra/core#next> <loop> ."))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
 	return handler
 }
 
@@ -139,6 +187,22 @@ func TestGraphLoadURIFail(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGraphLoadURIParseError(t *testing.T) {
+	uri := testServer.URL + "/invalid"
+	g := NewGraph(uri)
+	err := g.LoadURI(uri)
+	assert.Error(t, err)
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestGraphLoadURIContentTypeParams(t *testing.T) {
+	uri := testServer.URL + "/charset"
+	g := NewGraph(uri)
+	err := g.LoadURI(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.Len())
+}
+
 func TestGraphLoadURINoSkip(t *testing.T) {
 	uri := testServer.URL + "/foo#me"
 	g := NewGraph(uri, false)
@@ -210,6 +274,66 @@ func TestSerializeJSONLD(t *testing.T) {
 	assert.Equal(t, 4, g2.Len())
 }
 
+func TestSerializeStable(t *testing.T) {
+	g := NewGraph(testUri)
+	for i := 0; i < 10; i++ {
+		b := NewBlankNode(fmt.Sprintf("n%d", i))
+		g.AddTriple(NewResource(testUri+"#me"), NewResource("http://xmlns.com/foaf/0.1/knows"), b)
+		g.AddTriple(b, NewResource("http://xmlns.com/foaf/0.1/name"), NewLiteral(fmt.Sprintf("Person %d", i)))
+	}
+	for _, mime := range []string{"text/turtle", "application/ld+json"} {
+		first, err := g.SerializeString(mime)
+		assert.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			out, err := g.SerializeString(mime)
+			assert.NoError(t, err)
+			assert.Equal(t, first, out, mime)
+		}
+	}
+}
+
+func TestIterBySubject(t *testing.T) {
+	g := NewGraph(testUri)
+	a, b := NewResource("http://example.org/a"), NewResource("http://example.org/b")
+	p, q := NewResource("http://example.org/p"), NewResource("http://example.org/q")
+	g.AddTriple(b, p, NewLiteral("1"))
+	g.AddTriple(a, q, NewLiteral("2"))
+	g.AddTriple(a, p, NewLiteral("3"))
+	g.AddTriple(NewBlankNode("x"), p, a)
+
+	var groups [][]*Triple
+	for triples := range g.IterBySubject() {
+		groups = append(groups, triples)
+	}
+	assert.Len(t, groups, 3)
+	assert.Len(t, groups[0], 2)
+	assert.True(t, groups[0][0].Subject.Equal(a))
+	assert.True(t, groups[0][0].Predicate.Equal(p))
+	assert.True(t, groups[0][1].Predicate.Equal(q))
+	assert.Len(t, groups[1], 1)
+	assert.True(t, groups[1][0].Subject.Equal(b))
+	assert.Equal(t, "_:x", groups[2][0].Subject.String())
+
+	for range NewGraph(testUri).IterBySubject() {
+		t.Fatal("empty graph has no subjects")
+	}
+}
+
+func TestParseSerializeString(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.ParseString(simpleTurtle, "text/turtle")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.Len())
+
+	out, err := g.SerializeString("text/turtle")
+	assert.NoError(t, err)
+	g2 := NewGraph(testUri)
+	assert.NoError(t, g2.ParseString(out, "text/turtle"))
+	assert.True(t, g.Equal(g2))
+
+	assert.Error(t, g.ParseString(simpleTurtle, "text/plain"))
+}
+
 func TestGraphMerge(t *testing.T) {
 	g := NewGraph(testUri)
 	g2 := NewGraph(testUri)
@@ -231,3 +355,134 @@ func TestGraphMerge(t *testing.T) {
 	assert.NotEqual(t,nil,g.One(NewResource("g"),NewResource("b2"),NewResource("e")))
 	assert.NotEqual(t,nil,g.One(NewResource("g"),NewResource("b2"),NewResource("c")))
 }
+
+func TestSafeParse(t *testing.T) {
+	g := NewGraph(testUri)
+	assert.NoError(t, g.SafeParse(strings.NewReader(simpleTurtle), "text/turtle"))
+	assert.Equal(t, 2, g.Len())
+
+	err := g.SafeParse(strings.NewReader("<a> <b> \"\\uZZZZ\" ."), "text/turtle")
+	assert.Error(t, err)
+	assert.Equal(t, 2, g.Len())
+}
+
+func FuzzParseTurtle(f *testing.F) {
+	f.Add([]byte(simpleTurtle))
+	f.Add([]byte("<a> <b> \"\\uZZZZ\" ."))
+	f.Add([]byte("@prefix : <#> .\n:a :b ( 1 2.0 3e1 true ) ."))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := NewGraph(testUri)
+		g.SafeParse(bytes.NewReader(data), "text/turtle")
+	})
+}
+
+func FuzzParseJSONLD(f *testing.F) {
+	f.Add([]byte("{ \"@id\": \"http://example.org/#me\", \"http://xmlns.com/foaf/0.1/name\": \"Test\" }"))
+	f.Add([]byte("[{\"@id\": \"_:b0\", \"@type\": [\"http://example.org/T\"]}]"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := NewGraph(testUri)
+		g.SafeParse(bytes.NewReader(data), "application/ld+json")
+	})
+}
+
+func TestGraphBulkAdd(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	g.BulkAdd([]*Triple{
+		NewTriple(NewResource("a"), NewResource("b"), NewResource("d")),
+		NewTriple(NewResource("a"), NewResource("b"), NewResource("e")),
+	})
+	assert.Equal(t, 3, g.Len())
+	assert.Equal(t, 3, len(g.All(NewResource("a"), nil, nil)))
+}
+
+func benchTriples(n int) []*Triple {
+	triples := make([]*Triple, n)
+	for i := range triples {
+		triples[i] = NewTriple(NewResource(testUri), NewResource("b"), NewLiteral(fmt.Sprint(i)))
+	}
+	return triples
+}
+
+func BenchmarkGraphAdd(b *testing.B) {
+	triples := benchTriples(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := NewGraph(testUri)
+		for _, t := range triples {
+			g.Add(t)
+		}
+	}
+}
+
+func BenchmarkGraphBulkAdd(b *testing.B) {
+	triples := benchTriples(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := NewGraph(testUri)
+		g.BulkAdd(triples)
+	}
+}
+
+func TestWriteNTriples(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource(testUri+"#a"), NewResource(testUri+"#b"), NewLiteral("line\n\"quoted\""))
+	g.AddTriple(NewResource(testUri+"#a"), NewResource(testUri+"#b"), NewLiteralWithLanguage("c", "en"))
+
+	var b bytes.Buffer
+	assert.NoError(t, g.WriteNTriples(&b))
+	assert.ElementsMatch(t, strings.Split(g.String(), "\n"), strings.Split(b.String(), "\n"))
+	assert.Contains(t, b.String(), "<"+testUri+"#a> <"+testUri+"#b> \"line\\n\\\"quoted\\\"\" .\n")
+	assert.Contains(t, b.String(), "<"+testUri+"#a> <"+testUri+"#b> \"c\"@en .\n")
+
+	g2 := NewGraph(testUri)
+	assert.NoError(t, g2.Parse(&b, "text/turtle"))
+	assert.True(t, g.Equal(g2))
+}
+
+func BenchmarkGraphString(b *testing.B) {
+	g := NewGraph(testUri)
+	g.BulkAdd(benchTriples(100000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.String()
+	}
+}
+
+func BenchmarkWriteNTriples(b *testing.B) {
+	g := NewGraph(testUri)
+	g.BulkAdd(benchTriples(100000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.WriteNTriples(io.Discard)
+	}
+}
+
+func BenchmarkAppendNTriples(b *testing.B) {
+	g := NewGraph(testUri)
+	g.BulkAdd(benchTriples(100000))
+	var buf []byte
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = g.AppendNTriples(buf[:0])
+	}
+}
+
+func TestGraphSnapshot(t *testing.T) {
+	g := NewGraph(testUri)
+	triple := NewTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	g.Add(triple)
+
+	snap := g.Snapshot()
+	assert.Equal(t, testUri, snap.URI())
+	g.AddTriple(NewResource("a"), NewResource("b"), NewResource("d"))
+	g.Remove(triple)
+	assert.Equal(t, 1, g.Len())
+	assert.Nil(t, g.One(nil, nil, NewResource("c")))
+	assert.Equal(t, 1, snap.Len())
+	assert.NotNil(t, snap.One(nil, nil, NewResource("c")))
+
+	snap.BulkAdd([]*Triple{NewTriple(NewResource("x"), NewResource("y"), NewResource("z"))})
+	assert.Equal(t, 2, snap.Len())
+	assert.Equal(t, 1, g.Len())
+}
@@ -0,0 +1,117 @@
+package rdf2go
+
+import (
+	"encoding/xml"
+	"io"
+	"path"
+	"strings"
+)
+
+// Distributions returns the URLs of the RDF dataset distributions described
+// in a DCAT catalog or VoID description, i.e. the download URLs (falling back
+// to access URLs) of dcat:Distribution resources and void:dataDump links.
+// Distributions with a media type that cannot be parsed are skipped.
+func Distributions(catalog *Graph) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(t Term) {
+		r, ok := t.(*Resource)
+		if ok && !seen[r.URI] {
+			seen[r.URI] = true
+			urls = append(urls, r.URI)
+		}
+	}
+
+	for _, triple := range catalog.All(nil, NewResource(nsDCAT+"distribution"), nil) {
+		dist := triple.Object
+		if !parseableDistribution(catalog, dist) {
+			continue
+		}
+		download := catalog.One(dist, NewResource(nsDCAT+"downloadURL"), nil)
+		if download != nil {
+			add(download.Object)
+			continue
+		}
+		access := catalog.One(dist, NewResource(nsDCAT+"accessURL"), nil)
+		if access != nil {
+			add(access.Object)
+		}
+	}
+	for _, triple := range catalog.All(nil, NewResource(nsVOID+"dataDump"), nil) {
+		add(triple.Object)
+	}
+	return urls
+}
+
+// parseableDistribution returns whether a distribution has a media type
+// (or file extension) supported by the parser
+func parseableDistribution(catalog *Graph, dist Term) bool {
+	for _, p := range []string{nsDCAT + "mediaType", nsDCT + "format"} {
+		t := catalog.One(dist, NewResource(p), nil)
+		if t == nil {
+			continue
+		}
+		mime := t.Object.RawValue()
+		// media types are often given as IANA IRIs
+		if i := strings.Index(mime, "/media-types/"); i >= 0 {
+			mime = mime[i+len("/media-types/"):]
+		}
+		_, ok := mimeParser[mime]
+		return ok && mimeParser[mime] != "internal"
+	}
+	for _, p := range []string{nsDCAT + "downloadURL", nsDCAT + "accessURL"} {
+		t := catalog.One(dist, NewResource(p), nil)
+		if t == nil {
+			continue
+		}
+		mime := mimeRdfExt[path.Ext(t.Object.RawValue())]
+		_, ok := mimeParser[mime]
+		return ok
+	}
+	return false
+}
+
+// ParseSitemap returns the dataset dump locations listed in a semantic
+// sitemap (sc:dataDumpLocation elements), or the locations of a regular
+// sitemap having an RDF file extension.
+func ParseSitemap(r io.Reader) ([]string, error) {
+	var sitemap struct {
+		Datasets []struct {
+			Dumps []string `xml:"dataDumpLocation"`
+		} `xml:"dataset"`
+		URLs []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+	}
+	err := xml.NewDecoder(r).Decode(&sitemap)
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	for _, dataset := range sitemap.Datasets {
+		for _, dump := range dataset.Dumps {
+			urls = append(urls, strings.TrimSpace(dump))
+		}
+	}
+	for _, u := range sitemap.URLs {
+		loc := strings.TrimSpace(u.Loc)
+		if _, ok := mimeRdfExt[path.Ext(loc)]; ok {
+			urls = append(urls, loc)
+		}
+	}
+	return urls, nil
+}
+
+// Harvest loads a DCAT catalog or VoID description from the given URI and
+// then loads all the RDF distributions it describes into the graph, using
+// at most concurrency simultaneous requests.
+func (g *Graph) Harvest(catalogURI string, concurrency int) error {
+	catalog := NewGraph(defrag(catalogURI))
+	catalog.httpClient = g.httpClient
+	catalog.cache = g.cache
+	err := catalog.LoadURI(catalogURI)
+	if err != nil {
+		return err
+	}
+	return g.LoadURIs(Distributions(catalog), concurrency)
+}
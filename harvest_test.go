@@ -0,0 +1,59 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributions(t *testing.T) {
+	data := `@prefix dcat: <http://www.w3.org/ns/dcat#> .
+@prefix dct: <http://purl.org/dc/terms/> .
+@prefix void: <http://rdfs.org/ns/void#> .
+<#ds> dcat:distribution <#d1>, <#d2>, <#d3>, <#d4> .
+<#d1> dcat:downloadURL <http://example.org/d1.ttl> ; dcat:accessURL <http://example.org/d1> ; dcat:mediaType "text/turtle" .
+<#d2> dcat:accessURL <http://example.org/d2> ; dcat:mediaType <http://www.iana.org/assignments/media-types/application/ld+json> .
+<#d3> dcat:downloadURL <http://example.org/d3.csv> ; dct:format "text/csv" .
+<#d4> dcat:downloadURL <http://example.org/d4.jsonld> .
+<#void> void:dataDump <http://example.org/dump.ttl> .`
+	g := NewGraph(testUri)
+	assert.NoError(t, g.Parse(strings.NewReader(data), "text/turtle"))
+	urls := Distributions(g)
+	assert.ElementsMatch(t, []string{
+		"http://example.org/d1.ttl",
+		"http://example.org/d2",
+		"http://example.org/d4.jsonld",
+		"http://example.org/dump.ttl",
+	}, urls)
+}
+
+func TestParseSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:sc="http://sw.deri.org/2007/07/sitemapextension/scschema.xsd">
+  <url><loc>http://example.org/page.html</loc></url>
+  <url><loc>http://example.org/data.ttl</loc></url>
+  <sc:dataset>
+    <sc:dataDumpLocation>http://example.org/dump1.jsonld</sc:dataDumpLocation>
+    <sc:dataDumpLocation> http://example.org/dump2.ttl </sc:dataDumpLocation>
+  </sc:dataset>
+</urlset>`
+	urls, err := ParseSitemap(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"http://example.org/dump1.jsonld",
+		"http://example.org/dump2.ttl",
+		"http://example.org/data.ttl",
+	}, urls)
+
+	_, err = ParseSitemap(strings.NewReader("not xml"))
+	assert.Error(t, err)
+}
+
+func TestHarvest(t *testing.T) {
+	g := NewGraph(testUri)
+	assert.NoError(t, g.Harvest(testServer.URL+"/catalog", 2))
+	assert.Equal(t, 2, g.Len())
+
+	assert.Error(t, g.Harvest(testServer.URL+"/fail", 2))
+}
@@ -0,0 +1,435 @@
+package rdf2go
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// The index file written by WriteIndex starts with a header holding the
+// magic string, the format version, the number of terms and triples, and the
+// URI of the graph. It is followed by the term dictionary (a table of offsets
+// and the terms sorted by their N-Triples form, so that a term ID is its rank)
+// and by the triples, as three arrays of term IDs sorted in the SPO, POS and
+// OSP orders. All integers are little endian.
+const (
+	indexMagic   = "RDF2GOIX"
+	indexVersion = 1
+)
+
+// ErrIndexFormat is returned when opening a file that is not a valid index
+var ErrIndexFormat = errors.New("invalid index file")
+
+// WriteIndex writes an index of the graph to w, which can then be opened with
+// OpenIndex
+func WriteIndex(w io.Writer, g *Graph) error {
+	ids := make(map[string]uint32)
+	var keys []string
+	terms := make(map[string]Term)
+	for triple := range g.IterTriples() {
+		for _, t := range []Term{triple.Subject, triple.Predicate, triple.Object} {
+			key := encodeTerm(t)
+			if _, ok := terms[key]; !ok {
+				terms[key] = t
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		ids[key] = uint32(i)
+	}
+
+	var spo [][3]uint32
+	seen := make(map[[3]uint32]bool)
+	for triple := range g.IterTriples() {
+		t := [3]uint32{ids[encodeTerm(triple.Subject)], ids[encodeTerm(triple.Predicate)], ids[encodeTerm(triple.Object)]}
+		if !seen[t] {
+			seen[t] = true
+			spo = append(spo, t)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(indexMagic)
+	writeUint32(bw, indexVersion)
+	writeUint32(bw, uint32(len(keys)))
+	writeUint32(bw, uint32(len(spo)))
+	writeUint32(bw, uint32(len(g.uri)))
+	bw.WriteString(g.uri)
+
+	entries := make([][]byte, len(keys))
+	offset := uint64(0)
+	for i, key := range keys {
+		entries[i] = appendIndexTerm(nil, key, terms[key])
+		writeUint64(bw, offset)
+		offset += uint64(len(entries[i]))
+	}
+	writeUint64(bw, offset)
+	for _, entry := range entries {
+		bw.Write(entry)
+	}
+
+	for _, order := range indexOrders {
+		sorted := make([][3]uint32, len(spo))
+		for i, t := range spo {
+			sorted[i] = [3]uint32{t[order[0]], t[order[1]], t[order[2]]}
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+			if a[1] != b[1] {
+				return a[1] < b[1]
+			}
+			return a[2] < b[2]
+		})
+		for _, t := range sorted {
+			writeUint32(bw, t[0])
+			writeUint32(bw, t[1])
+			writeUint32(bw, t[2])
+		}
+	}
+	return bw.Flush()
+}
+
+// indexOrders are the positions of the subject, predicate and object in the
+// SPO, POS and OSP arrays
+var indexOrders = [3][3]int{{0, 1, 2}, {1, 2, 0}, {2, 0, 1}}
+
+// appendIndexTerm appends the dictionary entry of a term: its N-Triples key
+// followed by the term itself
+func appendIndexTerm(b []byte, key string, t Term) []byte {
+	return appendTerm(appendIndexString(b, key), t)
+}
+
+// appendTerm appends the binary form of a term: its kind and its fields
+func appendTerm(b []byte, t Term) []byte {
+	switch term := t.(type) {
+	case *Resource:
+		b = append(b, 'r')
+		b = appendIndexString(b, term.URI)
+	case *BlankNode:
+		b = append(b, 'b')
+		b = appendIndexString(b, term.ID)
+	case *Literal:
+		b = append(b, 'l')
+		b = appendIndexString(b, term.Value)
+		b = appendIndexString(b, term.Language)
+		datatype := ""
+		if term.Datatype != nil {
+			datatype = term.Datatype.RawValue()
+		}
+		b = appendIndexString(b, datatype)
+	}
+	return b
+}
+
+func appendIndexString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func writeUint32(w *bufio.Writer, v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	w.Write(b[:])
+}
+
+func writeUint64(w *bufio.Writer, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	w.Write(b[:])
+}
+
+// IndexedGraph is a read-only graph backed by an index file written by
+// WriteIndex. The file is memory-mapped where the platform allows it, so
+// terms and triples are only decoded when a lookup returns them and the
+// dataset does not need to fit in the Go heap. Patterns with a bound subject,
+// predicate or object are answered with binary searches.
+type IndexedGraph struct {
+	data     []byte
+	unmap    func() error
+	uri      string
+	nTerms   int
+	nTriples int
+	offsets  []byte
+	terms    []byte
+	orders   [3][]byte
+}
+
+// OpenIndex opens an index file written by WriteIndex. The graph must be
+// closed with Close once it is no longer used.
+func OpenIndex(path string) (*IndexedGraph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, unmap, err := mapFile(f)
+	if err != nil {
+		return nil, err
+	}
+	ig, err := newIndexedGraph(data)
+	if err != nil {
+		unmap()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	ig.unmap = unmap
+	return ig, nil
+}
+
+func newIndexedGraph(data []byte) (*IndexedGraph, error) {
+	const header = len(indexMagic) + 16
+	if len(data) < header || string(data[:len(indexMagic)]) != indexMagic {
+		return nil, ErrIndexFormat
+	}
+	le := binary.LittleEndian
+	rest := data[len(indexMagic):]
+	if le.Uint32(rest) != indexVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrIndexFormat, le.Uint32(rest))
+	}
+	ig := &IndexedGraph{
+		data:     data,
+		nTerms:   int(le.Uint32(rest[4:])),
+		nTriples: int(le.Uint32(rest[8:])),
+	}
+	uriLen := int(le.Uint32(rest[12:]))
+	rest = data[header:]
+	offsetsLen := 8 * (ig.nTerms + 1)
+	if len(rest) < uriLen+offsetsLen {
+		return nil, ErrIndexFormat
+	}
+	ig.uri = string(rest[:uriLen])
+	rest = rest[uriLen:]
+	ig.offsets = rest[:offsetsLen]
+	rest = rest[offsetsLen:]
+	termsLen := le.Uint64(ig.offsets[offsetsLen-8:])
+	triplesLen := uint64(12 * ig.nTriples)
+	if uint64(len(rest)) != termsLen+3*triplesLen {
+		return nil, ErrIndexFormat
+	}
+	ig.terms = rest[:termsLen]
+	rest = rest[termsLen:]
+	for i := range ig.orders {
+		ig.orders[i] = rest[:triplesLen]
+		rest = rest[triplesLen:]
+	}
+	return ig, nil
+}
+
+// Close releases the index file
+func (ig *IndexedGraph) Close() error {
+	if ig.unmap == nil {
+		return nil
+	}
+	err := ig.unmap()
+	ig.unmap = nil
+	ig.data, ig.offsets, ig.terms, ig.orders = nil, nil, nil, [3][]byte{}
+	return err
+}
+
+// URI returns the graph URI
+func (ig *IndexedGraph) URI() string {
+	return ig.uri
+}
+
+// Len returns the number of triples in the graph
+func (ig *IndexedGraph) Len() int {
+	return ig.nTriples
+}
+
+// entry returns the dictionary entry of a term ID
+func (ig *IndexedGraph) entry(id uint32) []byte {
+	le := binary.LittleEndian
+	return ig.terms[le.Uint64(ig.offsets[8*id:]):le.Uint64(ig.offsets[8*id+8:])]
+}
+
+// key returns the N-Triples form of a term ID
+func (ig *IndexedGraph) key(id uint32) string {
+	key, _ := readIndexString(ig.entry(id))
+	return key
+}
+
+// term decodes the term of the given ID
+func (ig *IndexedGraph) term(id uint32) Term {
+	_, b := readIndexString(ig.entry(id))
+	t, _, _ := readTerm(b)
+	return t
+}
+
+// readTerm decodes a term written by appendTerm, returning the bytes
+// following it
+func readTerm(b []byte) (Term, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	kind, b := b[0], b[1:]
+	fields := 1
+	if kind == 'l' {
+		fields = 3
+	} else if kind != 'r' && kind != 'b' {
+		return nil, nil, fmt.Errorf("unknown term kind %q", kind)
+	}
+	var values [3]string
+	for i := 0; i < fields; i++ {
+		n, size := binary.Uvarint(b)
+		if size <= 0 || uint64(len(b)-size) < n {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		values[i], b = string(b[size:size+int(n)]), b[size+int(n):]
+	}
+	switch kind {
+	case 'r':
+		return NewResource(values[0]), b, nil
+	case 'b':
+		return NewBlankNode(values[0]), b, nil
+	}
+	l := &Literal{Value: values[0], Language: values[1]}
+	if len(values[2]) > 0 {
+		l.Datatype = NewResource(values[2])
+	}
+	return l, b, nil
+}
+
+func readIndexString(b []byte) (string, []byte) {
+	n, size := binary.Uvarint(b)
+	return string(b[size : size+int(n)]), b[size+int(n):]
+}
+
+// lookup returns the ID of a term, or false if the term is not in the graph
+func (ig *IndexedGraph) lookup(t Term) (uint32, bool) {
+	key := encodeTerm(t)
+	i := sort.Search(ig.nTerms, func(i int) bool {
+		return ig.key(uint32(i)) >= key
+	})
+	if i < ig.nTerms && ig.key(uint32(i)) == key {
+		return uint32(i), true
+	}
+	return 0, false
+}
+
+// row returns the i-th triple of an order, as IDs in the order of its array
+func row(order []byte, i int) [3]uint32 {
+	le := binary.LittleEndian
+	return [3]uint32{le.Uint32(order[12*i:]), le.Uint32(order[12*i+4:]), le.Uint32(order[12*i+8:])}
+}
+
+// match calls fn for the triples matching a pattern of S, P, O objects, until
+// it returns false
+func (ig *IndexedGraph) match(s Term, p Term, o Term, fn func(*Triple) bool) {
+	var ids [3]uint32
+	var bound [3]bool
+	for i, t := range []Term{s, p, o} {
+		if t == nil {
+			continue
+		}
+		id, ok := ig.lookup(t)
+		if !ok {
+			return
+		}
+		ids[i], bound[i] = id, true
+	}
+	// pick the order whose prefix covers the most bound positions
+	best, prefix := 0, -1
+	for i, order := range indexOrders {
+		n := 0
+		for n < 3 && bound[order[n]] {
+			n++
+		}
+		if n > prefix {
+			best, prefix = i, n
+		}
+	}
+	order := indexOrders[best]
+	var key [3]uint32
+	for i := 0; i < prefix; i++ {
+		key[i] = ids[order[i]]
+	}
+	// compare orders a row against the bound prefix of the pattern
+	compare := func(r [3]uint32) int {
+		for i := 0; i < prefix; i++ {
+			if r[i] != key[i] {
+				if r[i] < key[i] {
+					return -1
+				}
+				return 1
+			}
+		}
+		return 0
+	}
+	rows := ig.orders[best]
+	start := sort.Search(ig.nTriples, func(i int) bool { return compare(row(rows, i)) >= 0 })
+	for i := start; i < ig.nTriples; i++ {
+		r := row(rows, i)
+		if compare(r) != 0 {
+			return
+		}
+		var spo [3]uint32
+		for j, pos := range order {
+			spo[pos] = r[j]
+		}
+		if (bound[0] && spo[0] != ids[0]) || (bound[1] && spo[1] != ids[1]) || (bound[2] && spo[2] != ids[2]) {
+			continue
+		}
+		if !fn(NewTriple(ig.term(spo[0]), ig.term(spo[1]), ig.term(spo[2]))) {
+			return
+		}
+	}
+}
+
+// One returns one triple based on a triple pattern of S, P, O objects
+func (ig *IndexedGraph) One(s Term, p Term, o Term) *Triple {
+	var found *Triple
+	ig.match(s, p, o, func(t *Triple) bool {
+		found = t
+		return false
+	})
+	return found
+}
+
+// All is used to return all triples that match a given pattern of S, P, O objects
+func (ig *IndexedGraph) All(s Term, p Term, o Term) []*Triple {
+	var triples []*Triple
+	if s == nil && p == nil && o == nil {
+		return triples
+	}
+	ig.match(s, p, o, func(t *Triple) bool {
+		triples = append(triples, t)
+		return true
+	})
+	return triples
+}
+
+// IterTriples provides a channel containing all the triples in the graph,
+// sorted by subject, predicate and object. All the triples are decoded, so
+// One and All should be preferred on large graphs.
+// Note that the returned channel is already closed.
+func (ig *IndexedGraph) IterTriples() (ch chan *Triple) {
+	ch = make(chan *Triple, ig.nTriples)
+	ig.match(nil, nil, nil, func(t *Triple) bool {
+		ch <- t
+		return true
+	})
+	close(ch)
+	return ch
+}
+
+// Thaw returns a modifiable copy of the graph, decoding all its triples
+func (ig *IndexedGraph) Thaw() *Graph {
+	g := NewGraph(ig.uri)
+	for triple := range ig.IterTriples() {
+		g.Add(triple)
+	}
+	return g
+}
+
+// Serialize is used to serialize a graph based on a given mime type
+func (ig *IndexedGraph) Serialize(w io.Writer, mime string) error {
+	return NewGraphWithStore(ig.uri, readOnlyStore{ig}).Serialize(w, mime)
+}
@@ -0,0 +1,85 @@
+package rdf2go
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedGraph(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<#me> a foaf:Person ; foaf:name "Me"@en, "Moi"@fr ; foaf:age "42"^^xsd:integer ;
+  foaf:knows <#you>, [ foaf:name "Anon" ] .
+<#you> a foaf:Person ; foaf:name "You" ; foaf:knows <#me> .`, "text/turtle"))
+
+	path := filepath.Join(t.TempDir(), "graph.idx")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, WriteIndex(f, g))
+	require.NoError(t, f.Close())
+
+	ig, err := OpenIndex(path)
+	require.NoError(t, err)
+	defer ig.Close()
+	assert.Equal(t, testUri, ig.URI())
+	assert.Equal(t, g.Len(), ig.Len())
+	assert.True(t, g.Equal(ig.Thaw()))
+
+	me := NewResource(testUri + "#me")
+	you := NewResource(testUri + "#you")
+	name := NewResource("http://xmlns.com/foaf/0.1/name")
+	knows := NewResource("http://xmlns.com/foaf/0.1/knows")
+	person := NewResource("http://xmlns.com/foaf/0.1/Person")
+	patterns := [][3]Term{
+		{me, nil, nil},
+		{me, name, nil},
+		{me, name, NewLiteralWithLanguage("Moi", "fr")},
+		{nil, name, nil},
+		{nil, nil, person},
+		{me, nil, you},
+		{nil, knows, me},
+		{nil, nil, NewLiteralWithDatatype("42", NewResource("http://www.w3.org/2001/XMLSchema#integer"))},
+		{NewResource(testUri + "#nobody"), nil, nil},
+		{nil, nil, nil},
+	}
+	for _, p := range patterns {
+		var expected []*Triple
+		for triple := range g.IterTriples() {
+			if (p[0] != nil || p[1] != nil || p[2] != nil) && matchTriple(triple, p[0], p[1], p[2]) {
+				expected = append(expected, triple)
+			}
+		}
+		assert.ElementsMatch(t, expected, ig.All(p[0], p[1], p[2]), "%v", p)
+	}
+	assert.Equal(t, "\"You\"", ig.One(you, name, nil).Object.String())
+	assert.Nil(t, ig.One(you, name, NewLiteral("Me")))
+
+	var buf bytes.Buffer
+	require.NoError(t, ig.Serialize(&buf, "text/turtle"))
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.Parse(&buf, "text/turtle"))
+	assert.Equal(t, g.Len(), g2.Len())
+}
+
+func TestOpenIndexErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := OpenIndex(filepath.Join(dir, "missing.idx"))
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "bad.idx")
+	require.NoError(t, os.WriteFile(path, []byte(simpleTurtle), 0644))
+	_, err = OpenIndex(path)
+	assert.ErrorIs(t, err, ErrIndexFormat)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteIndex(&buf, NewGraph(testUri)))
+	require.NoError(t, os.WriteFile(path, buf.Bytes()[:buf.Len()-1], 0644))
+	_, err = OpenIndex(path)
+	assert.ErrorIs(t, err, ErrIndexFormat)
+}
@@ -0,0 +1,142 @@
+package rdf2go
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// multicodec and multihash codes used to build CIDs
+const (
+	codecRaw     = 0x55
+	codecDagCBOR = 0x71
+	hashSHA256   = 0x12
+)
+
+// maxBlockSize is the size above which canonical N-Triples are split into
+// several blocks, to stay within the block size accepted by IPFS nodes
+const maxBlockSize = 1 << 20
+
+var cidEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ipldBlock is a block of data along with its binary CID
+type ipldBlock struct {
+	cid  []byte
+	data []byte
+}
+
+func newBlock(codec uint64, data []byte) ipldBlock {
+	sum := sha256.Sum256(data)
+	cid := binary.AppendUvarint(nil, 1)
+	cid = binary.AppendUvarint(cid, codec)
+	cid = binary.AppendUvarint(cid, hashSHA256)
+	cid = binary.AppendUvarint(cid, uint64(len(sum)))
+	cid = append(cid, sum[:]...)
+	return ipldBlock{cid: cid, data: data}
+}
+
+// cidString returns the CIDv1 in its base32 multibase form
+func cidString(cid []byte) string {
+	return "b" + strings.ToLower(cidEncoding.EncodeToString(cid))
+}
+
+// ipldBlocks returns the blocks of the canonicalized graph, with the root block last.
+// Small graphs are stored as a single raw block of canonical N-Triples. Larger
+// graphs are split into raw blocks linked from a DAG-CBOR list.
+func (g *Graph) ipldBlocks() []ipldBlock {
+	data := g.CanonicalNTriples()
+	if len(data) <= maxBlockSize {
+		return []ipldBlock{newBlock(codecRaw, data)}
+	}
+	var blocks []ipldBlock
+	for len(data) > 0 {
+		n := maxBlockSize
+		if n > len(data) {
+			n = len(data)
+		} else if i := bytes.LastIndexByte(data[:n], '\n'); i > 0 {
+			// keep triples whole
+			n = i + 1
+		}
+		blocks = append(blocks, newBlock(codecRaw, data[:n]))
+		data = data[n:]
+	}
+	links := cborHeader(nil, 4, uint64(len(blocks)))
+	for _, b := range blocks {
+		links = cborLink(links, b.cid)
+	}
+	return append(blocks, newBlock(codecDagCBOR, links))
+}
+
+// CID returns the content identifier (CIDv1, sha2-256) of the canonicalized
+// graph, as it would be computed by IPFS for the blocks written by WriteCAR
+func (g *Graph) CID() string {
+	blocks := g.ipldBlocks()
+	return cidString(blocks[len(blocks)-1].cid)
+}
+
+// WriteCAR writes the canonicalized graph as a CAR (v1) archive of IPLD
+// blocks, which can be imported into IPFS, and returns the root CID
+func (g *Graph) WriteCAR(w io.Writer) (string, error) {
+	blocks := g.ipldBlocks()
+	root := blocks[len(blocks)-1].cid
+
+	// the header is the DAG-CBOR map {"roots": [root], "version": 1}
+	header := cborHeader(nil, 5, 2)
+	header = cborString(header, "roots")
+	header = cborHeader(header, 4, 1)
+	header = cborLink(header, root)
+	header = cborString(header, "version")
+	header = cborHeader(header, 0, 1)
+
+	buf := binary.AppendUvarint(nil, uint64(len(header)))
+	buf = append(buf, header...)
+	_, err := w.Write(buf)
+	if err != nil {
+		return "", err
+	}
+	for _, b := range blocks {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(b.cid)+len(b.data)))
+		buf = append(buf, b.cid...)
+		_, err = w.Write(buf)
+		if err != nil {
+			return "", err
+		}
+		_, err = w.Write(b.data)
+		if err != nil {
+			return "", err
+		}
+	}
+	return cidString(root), nil
+}
+
+// cborHeader appends a CBOR major type header with the given argument
+func cborHeader(buf []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= 0xff:
+		return append(buf, major|24, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+}
+
+func cborString(buf []byte, s string) []byte {
+	return append(cborHeader(buf, 3, uint64(len(s))), s...)
+}
+
+// cborLink appends a CID link, encoded as tag 42 over the CID bytes
+// prefixed with the identity multibase
+func cborLink(buf []byte, cid []byte) []byte {
+	buf = append(buf, 0xd8, 42)
+	buf = cborHeader(buf, 2, uint64(len(cid)+1))
+	buf = append(buf, 0)
+	return append(buf, cid...)
+}
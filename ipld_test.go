@@ -0,0 +1,64 @@
+package rdf2go
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCID(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("a"), NewResource("b"), NewBlankNode("x"))
+	cid := g.CID()
+	assert.Equal(t, "bafkrei", cid[:7])
+
+	g2 := NewGraph(testUri)
+	g2.AddTriple(NewResource("a"), NewResource("b"), NewBlankNode("y"))
+	assert.Equal(t, cid, g2.CID())
+
+	g2.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	assert.NotEqual(t, cid, g2.CID())
+}
+
+func TestWriteCAR(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+
+	b := new(bytes.Buffer)
+	cid, err := g.WriteCAR(b)
+	assert.NoError(t, err)
+	assert.Equal(t, g.CID(), cid)
+
+	r := bytes.NewReader(b.Bytes())
+	n, err := binary.ReadUvarint(r)
+	assert.NoError(t, err)
+	header := make([]byte, n)
+	r.Read(header)
+	assert.Equal(t, byte(0xa2), header[0])
+	assert.Contains(t, string(header), "roots")
+	assert.Contains(t, string(header), "version")
+
+	n, err = binary.ReadUvarint(r)
+	assert.NoError(t, err)
+	block := make([]byte, n)
+	r.Read(block)
+	assert.Equal(t, "<a> <b> <c> .\n", string(block[36:]))
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestCIDLargeGraph(t *testing.T) {
+	g := NewGraph(testUri)
+	value := string(bytes.Repeat([]byte("x"), 1000))
+	for i := 0; i < 1200; i++ {
+		g.AddTriple(NewResource(fmt.Sprint("s", i)), NewResource("p"), NewLiteral(value))
+	}
+	blocks := g.ipldBlocks()
+	assert.Equal(t, 3, len(blocks))
+	assert.Equal(t, "bafyrei", g.CID()[:7])
+	for _, b := range blocks[:2] {
+		assert.Equal(t, byte('\n'), b.data[len(b.data)-1])
+	}
+}
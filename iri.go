@@ -0,0 +1,85 @@
+package rdf2go
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// iriExcluded holds the characters that cannot appear in an IRI written
+// between angle brackets in Turtle or N-Triples, besides controls and space
+const iriExcluded = `<>"{}|^` + "`" + `\`
+
+// iriNeedsEscape returns whether an IRI holds characters that cannot be
+// written between angle brackets, or is not valid UTF-8
+func iriNeedsEscape(iri string) bool {
+	for i := 0; i < len(iri); i++ {
+		if c := iri[i]; c <= ' ' || c == 0x7f || strings.IndexByte(iriExcluded, c) >= 0 {
+			return true
+		}
+	}
+	return !utf8.ValidString(iri)
+}
+
+// escapeIRI percent-encodes the characters of an IRI which cannot be
+// written between angle brackets, e.g. spaces as %20, along with the bytes
+// of invalid UTF-8 sequences. Other characters, including non-ASCII ones,
+// are kept as they are.
+func escapeIRI(iri string) string {
+	if !iriNeedsEscape(iri) {
+		return iri
+	}
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	b.Grow(len(iri) + 8)
+	for i := 0; i < len(iri); {
+		c := iri[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(iri[i:])
+			if r != utf8.RuneError || size > 1 {
+				b.WriteString(iri[i : i+size])
+				i += size
+				continue
+			}
+		} else if c > ' ' && c != 0x7f && strings.IndexByte(iriExcluded, c) < 0 {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0xf])
+		i++
+	}
+	return b.String()
+}
+
+// SetStrictIRIs sets whether serializing the graph to Turtle or N-Triples
+// fails on IRIs holding characters that cannot be written between angle
+// brackets, such as spaces, instead of percent-encoding them. The error
+// wraps ErrInvalidIRI.
+func (g *Graph) SetStrictIRIs(strict bool) {
+	g.strictIRIs = strict
+}
+
+// checkIRIs returns an error for the first IRI of the graph, datatypes
+// included, that cannot be written as it is
+func (g *Graph) checkIRIs() error {
+	for triple := range g.IterTriples() {
+		for _, t := range []Term{triple.Subject, triple.Predicate, triple.Object} {
+			iri := ""
+			switch term := t.(type) {
+			case *Resource:
+				iri = term.URI
+			case *Literal:
+				if term.Datatype != nil {
+					iri = term.Datatype.RawValue()
+				}
+			}
+			if iriNeedsEscape(iri) {
+				return fmt.Errorf("%w: %q", ErrInvalidIRI, iri)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package rdf2go
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeIRI(t *testing.T) {
+	assert.Equal(t, "http://example.org/a", escapeIRI("http://example.org/a"))
+	assert.Equal(t, "http://example.org/café", escapeIRI("http://example.org/café"))
+	assert.Equal(t, "http://example.org/a%20b", escapeIRI("http://example.org/a b"))
+	assert.Equal(t, "http://example.org/%3Ca%3E%22%7B%7D%7C%5E%60%5C", escapeIRI("http://example.org/<a>\"{}|^`\\"))
+	assert.Equal(t, "http://example.org/%0A%FF", escapeIRI("http://example.org/\n\xff"))
+}
+
+func TestSerializeEscapedIRIs(t *testing.T) {
+	g := NewGraph(testUri)
+	s := NewResource("http://example.org/a b")
+	p := NewResource("http://example.org/p<1>")
+	g.AddTriple(s, p, NewResource("http://example.org/café"))
+	g.AddTriple(s, p, NewLiteralWithDatatype("x", NewResource("http://example.org/type{1}")))
+
+	out, err := g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.Contains(t, out, "<http://example.org/a%20b>")
+	assert.Contains(t, out, "<http://example.org/p%3C1%3E>")
+	assert.Contains(t, out, "<http://example.org/café>")
+	assert.Contains(t, out, `"x"^^<http://example.org/type%7B1%7D>`)
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.ParseString(out, "text/turtle"))
+	assert.Equal(t, 2, g2.Len())
+	assert.NotNil(t, g2.One(NewResource("http://example.org/a%20b"), nil, nil))
+
+	g2 = NewGraph(testUri)
+	require.NoError(t, g2.ParseString(g.String(), "text/turtle"))
+	assert.Equal(t, 2, g2.Len())
+
+	g.SetStrictIRIs(true)
+	_, err = g.SerializeString("text/turtle")
+	assert.True(t, errors.Is(err, ErrInvalidIRI))
+	assert.True(t, errors.Is(g.WriteNTriples(&bytes.Buffer{}), ErrInvalidIRI))
+	g.Remove(g.One(nil, nil, NewResource("http://example.org/café")))
+	g.Remove(g.One(nil, nil, nil))
+	g.AddTriple(NewResource("http://example.org/café"), NewResource("http://example.org/p"), NewLiteral("a b"))
+	_, err = g.SerializeString("text/turtle")
+	assert.NoError(t, err)
+}
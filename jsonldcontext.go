@@ -0,0 +1,195 @@
+package rdf2go
+
+import (
+	"io"
+	"strings"
+)
+
+// JSONLDContext describes the context used to compact a graph serialized to
+// JSON-LD, see SetJSONLDContext
+type JSONLDContext struct {
+	// Vocab is the @vocab of the context: predicates and types in this
+	// namespace are written as their local name, e.g. "name" for
+	// http://schema.org/name with Vocab set to http://schema.org/
+	Vocab string
+	// Terms maps the terms of the context to their definition
+	Terms map[string]JSONLDTerm
+	// Aliases maps keywords, such as @id and @type, to the term used instead,
+	// e.g. "id"
+	Aliases map[string]string
+}
+
+// JSONLDTerm is the definition of a term of a JSONLDContext
+type JSONLDTerm struct {
+	// ID is the IRI of the predicate the term stands for
+	ID string
+	// Container is "@language" to write the values of the term as a language
+	// map, e.g. {"en": "Paris", "fr": "Paris"}, or empty
+	Container string
+}
+
+// SetJSONLDContext sets the context used to compact the graph when it is
+// serialized to JSON-LD. The triples are then grouped by subject, the terms
+// of the context and the vocabulary are used as keys for their predicates,
+// rdf:type is written as @type, plain literals as strings, and properties
+// with a single value are not wrapped in a list. A nil context restores the
+// default output, with one node object per triple.
+func (g *Graph) SetJSONLDContext(c *JSONLDContext) {
+	g.jsonldContext = c
+}
+
+// serializeCompactJSONLD writes the graph as JSON-LD compacted with the
+// context of the options
+func (g *Graph) serializeCompactJSONLD(w io.Writer, opts SerializeOptions) error {
+	c := opts.Context
+	prefixes := g.jsonldPrefixes()
+	context := make(map[string]interface{})
+	k := &jsonldCompactor{
+		vocab:         c.Vocab,
+		prefixes:      prefixes,
+		terms:         make(map[string]string),
+		languageTerms: make(map[string]string),
+		reserved:      make(map[string]bool),
+	}
+	if len(c.Vocab) > 0 {
+		context["@vocab"] = c.Vocab
+	}
+	if len(opts.BaseIRI) > 0 {
+		context["@base"] = opts.BaseIRI
+	}
+	for name, t := range c.Terms {
+		// a term hides the prefix of the same name
+		delete(prefixes, name)
+		def := map[string]interface{}{"@id": t.ID}
+		if t.Container == "@language" {
+			def["@container"] = t.Container
+			k.languageTerms[t.ID] = name
+		} else {
+			k.terms[t.ID] = name
+		}
+		context[name] = def
+		k.reserved[name] = true
+	}
+	for keyword, alias := range c.Aliases {
+		delete(prefixes, alias)
+		context[alias] = keyword
+		k.reserved[alias] = true
+	}
+	for prefix, ns := range prefixes {
+		context[prefix] = ns
+		k.reserved[prefix] = true
+	}
+	id, typ := k.keyword(c, "@id"), k.keyword(c, "@type")
+
+	nodes := []map[string]interface{}{}
+	var node map[string]interface{}
+	var subject Term
+	rdfType := NewResource(nsRDF + "type")
+	for _, triple := range g.Triples(true) {
+		if subject == nil || !subject.Equal(triple.Subject) {
+			subject = triple.Subject
+			node = map[string]interface{}{id: jsonldValue(subject, prefixes)["@id"]}
+			if _, ok := subject.(*Resource); ok {
+				node[id] = opts.relative(subject.RawValue())
+			}
+			nodes = append(nodes, node)
+		}
+		iri := triple.Predicate.RawValue()
+		switch o := triple.Object.(type) {
+		case *Resource:
+			if triple.Predicate.Equal(rdfType) {
+				addJSONLDValue(node, typ, k.compact(o.URI))
+				continue
+			}
+		case *Literal:
+			if len(o.Language) > 0 && len(k.languageTerms[iri]) > 0 {
+				addLanguageValue(node, k.languageTerms[iri], o)
+				continue
+			}
+			if o.datatype() == nil && len(o.Language) == 0 {
+				addJSONLDValue(node, k.compact(iri), o.Value)
+				continue
+			}
+		}
+		v := jsonldValue(triple.Object, prefixes)
+		if _, ok := triple.Object.(*Resource); ok {
+			v["@id"] = opts.relative(v["@id"].(string))
+		}
+		for keyword, alias := range c.Aliases {
+			if value, ok := v[keyword]; ok {
+				delete(v, keyword)
+				v[alias] = value
+			}
+		}
+		addJSONLDValue(node, k.compact(iri), v)
+	}
+	return opts.writeJSON(w, map[string]interface{}{
+		"@context": context,
+		"@graph":   nodes,
+	})
+}
+
+// jsonldCompactor compacts IRIs with the terms of a context, its vocabulary
+// and prefixes
+type jsonldCompactor struct {
+	vocab         string
+	prefixes      map[string]string
+	terms         map[string]string
+	languageTerms map[string]string
+	// reserved holds the terms, aliases and prefixes of the context, which
+	// cannot be used for the local names of the vocabulary
+	reserved map[string]bool
+}
+
+// compact returns the shortest key or type name for an IRI
+func (k *jsonldCompactor) compact(iri string) string {
+	if term, ok := k.terms[iri]; ok {
+		return term
+	}
+	if len(k.vocab) > 0 && len(iri) > len(k.vocab) && iri[:len(k.vocab)] == k.vocab {
+		local := iri[len(k.vocab):]
+		if !k.reserved[local] && !strings.ContainsAny(local, ":/#?") && local[0] != '@' {
+			return local
+		}
+	}
+	return compactIRI(iri, k.prefixes)
+}
+
+// keyword returns the alias of a keyword, or the keyword itself
+func (k *jsonldCompactor) keyword(c *JSONLDContext, keyword string) string {
+	if alias, ok := c.Aliases[keyword]; ok {
+		return alias
+	}
+	return keyword
+}
+
+// addJSONLDValue adds a value to a property of a node, turning it into a
+// list when it has several values
+func addJSONLDValue(node map[string]interface{}, key string, v interface{}) {
+	switch existing := node[key].(type) {
+	case nil:
+		node[key] = v
+	case []interface{}:
+		node[key] = append(existing, v)
+	default:
+		node[key] = []interface{}{existing, v}
+	}
+}
+
+// addLanguageValue adds a literal to the language map of a node under key,
+// using a list when a language has several values
+func addLanguageValue(node map[string]interface{}, key string, l *Literal) {
+	langs, ok := node[key].(map[string]interface{})
+	if !ok {
+		langs = make(map[string]interface{})
+		node[key] = langs
+	}
+	switch v := langs[l.Language].(type) {
+	case nil:
+		langs[l.Language] = l.Value
+	case string:
+		langs[l.Language] = []string{v, l.Value}
+	case []string:
+		langs[l.Language] = append(v, l.Value)
+	}
+}
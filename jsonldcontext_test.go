@@ -0,0 +1,75 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLDLanguageMaps(t *testing.T) {
+	g := NewGraph(testUri)
+	paris := NewResource("http://example.org/paris")
+	label := NewResource(nsRDFS + "label")
+	g.AddTriple(paris, label, NewLiteralWithLanguage("Paris", "en"))
+	g.AddTriple(paris, label, NewLiteralWithLanguage("Paris", "fr"))
+	g.AddTriple(paris, label, NewLiteralWithLanguage("Lutèce", "fr"))
+	g.AddTriple(paris, label, NewLiteral("PAR"))
+	g.AddTriple(paris, NewResource(nsFOAF+"based_near"), NewBlankNode("b1"))
+	g.AddTriple(NewBlankNode("b1"), NewResource(nsFOAF+"name"), NewLiteral("France"))
+	g.SetJSONLDContext(&JSONLDContext{Terms: map[string]JSONLDTerm{
+		"label": {ID: nsRDFS + "label", Container: "@language"},
+	}})
+
+	out, err := g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"label":{"@container":"@language","@id":"`+nsRDFS+`label"}`)
+	assert.Contains(t, out, `"label":{"en":"Paris","fr":["Lutèce","Paris"]}`)
+	assert.Contains(t, out, `"rdfs:label":"PAR"`)
+	assert.Contains(t, out, `"foaf:based_near":{"@id":"_:b1"}`)
+
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.ParseString(out, "application/ld+json"))
+	assert.Equal(t, g.Len(), g2.Len())
+	assert.NotNil(t, g2.One(paris, label, NewLiteralWithLanguage("Lutèce", "fr")))
+
+	g.SetJSONLDContext(nil)
+	out, err = g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.NotContains(t, out, `"label"`)
+}
+
+func TestJSONLDVocabAndAliases(t *testing.T) {
+	g := NewGraph(testUri)
+	alice := NewResource("http://example.org/alice")
+	g.AddTriple(alice, NewResource(nsRDF+"type"), NewResource(nsSDO+"Person"))
+	g.AddTriple(alice, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Agent"))
+	g.AddTriple(alice, NewResource(nsSDO+"name"), NewLiteral("Alice"))
+	g.AddTriple(alice, NewResource(nsSDO+"birthDate"), NewLiteralWithDatatype("1990-01-01", NewResource(nsXSD+"date")))
+	g.AddTriple(alice, NewResource(nsSDO+"knows"), NewResource("http://example.org/bob"))
+	g.AddTriple(alice, NewResource(nsSDO+"knows"), NewResource("http://example.org/carol"))
+	g.AddTriple(alice, NewResource(nsFOAF+"mbox"), NewResource("mailto:alice@example.org"))
+	g.SetJSONLDContext(&JSONLDContext{
+		Vocab:   nsSDO,
+		Terms:   map[string]JSONLDTerm{"email": {ID: nsFOAF + "mbox"}},
+		Aliases: map[string]string{"@id": "id", "@type": "type"},
+	})
+
+	out, err := g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"@vocab":"`+nsSDO+`"`)
+	assert.Contains(t, out, `"id":"@id"`)
+	assert.Contains(t, out, `"id":"http://example.org/alice"`)
+	assert.Contains(t, out, `"type":["Person","foaf:Agent"]`)
+	assert.Contains(t, out, `"name":"Alice"`)
+	assert.Contains(t, out, `"birthDate":{"@value":"1990-01-01","type":"xsd:date"}`)
+	assert.Contains(t, out, `"knows":[{"id":"http://example.org/bob"},{"id":"http://example.org/carol"}]`)
+	assert.Contains(t, out, `"email":{"id":"mailto:alice@example.org"}`)
+
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.ParseString(out, "application/ld+json"))
+	assert.Equal(t, g.Len(), g2.Len())
+	assert.NotNil(t, g2.One(alice, NewResource(nsRDF+"type"), NewResource(nsSDO+"Person")))
+	assert.NotNil(t, g2.One(alice, NewResource(nsFOAF+"mbox"), nil))
+	assert.NotNil(t, g2.One(alice, NewResource(nsSDO+"birthDate"), NewLiteralWithDatatype("1990-01-01", NewResource(nsXSD+"date"))))
+}
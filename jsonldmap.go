@@ -0,0 +1,105 @@
+package rdf2go
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ParseJSONLDMap adds the triples of a JSON-LD document that is already
+// decoded, e.g. from the body of an API response, to the graph, without
+// encoding it to JSON again. Besides the types produced by encoding/json,
+// the document may hold Go integers, []string and map[string]string values.
+// The map is not modified. The document is checked like a parsed one: the
+// limits, warning handler, parse mode and SetKeepPartial of the graph apply.
+func (g *Graph) ParseJSONLDMap(m map[string]interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("could not parse JSON-LD data: %v", r)
+		}
+	}()
+	started := time.Now()
+	triples, err := g.parseJSONLDMap(m)
+	if mt := currentMetrics(); mt != nil {
+		mt.ObserveParse("application/ld+json", len(triples), time.Since(started), err)
+	}
+	return g.addParsed(triples, nil, err)
+}
+
+// parseJSONLDMap converts a decoded JSON-LD document to triples, checked
+// like those returned by parse
+func (g *Graph) parseJSONLDMap(m map[string]interface{}) ([]*Triple, error) {
+	doc, err := jsonValue(m)
+	if err != nil {
+		return nil, err
+	}
+	b := newTripleBuilder()
+	err = g.parseJSONLD(context.Background(), doc, b)
+	if err != nil {
+		return nil, err
+	}
+	triples, _, err := g.checkParsed(b.triples, nil)
+	return triples, err
+}
+
+// jsonValue returns a copy of a decoded JSON value, converting the Go types
+// allowed by ParseJSONLDMap to the ones produced by encoding/json
+func jsonValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, string, bool, float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint32:
+		return int64(v), nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			converted, err := jsonValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			m[k] = value
+		}
+		return m, nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, value := range v {
+			converted, err := jsonValue(value)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+		return list, nil
+	case []map[string]interface{}:
+		list := make([]interface{}, len(v))
+		for i, value := range v {
+			converted, err := jsonValue(value)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+		return list, nil
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, value := range v {
+			list[i] = value
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("unsupported JSON value of type %T", v)
+}
@@ -0,0 +1,55 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONLDMap(t *testing.T) {
+	doc := map[string]interface{}{
+		"@context":  map[string]string{"foaf": nsFOAF, "xsd": nsXSD},
+		"@id":       "http://example.org/alice",
+		"@type":     []string{"foaf:Person"},
+		"foaf:name": "Alice",
+		"foaf:age":  map[string]interface{}{"@value": 42},
+		"foaf:knows": []map[string]interface{}{
+			{"@id": "http://example.org/bob", "foaf:name": "Bob"},
+		},
+	}
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseJSONLDMap(doc))
+	assert.Equal(t, 5, g.Len())
+	alice := NewResource("http://example.org/alice")
+	assert.NotNil(t, g.One(alice, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person")))
+	assert.NotNil(t, g.One(alice, NewResource(nsFOAF+"name"), NewLiteral("Alice")))
+	assert.NotNil(t, g.One(NewResource("http://example.org/bob"), NewResource(nsFOAF+"name"), NewLiteral("Bob")))
+	age := g.One(alice, NewResource(nsFOAF+"age"), nil)
+	require.NotNil(t, age)
+	assert.Equal(t, "42", age.Object.RawValue())
+	// the document is left as it was
+	assert.Equal(t, 42, doc["foaf:age"].(map[string]interface{})["@value"])
+
+	err := NewGraph(testUri).ParseJSONLDMap(map[string]interface{}{"@id": "http://a", "http://b": struct{}{}})
+	assert.EqualError(t, err, "http://b: unsupported JSON value of type struct {}")
+}
+
+func TestParseJSONLDMapChecks(t *testing.T) {
+	g := NewGraph(testUri)
+	g.SetParseMode(ParseStrict)
+	assert.ErrorIs(t, g.ParseJSONLDMap(map[string]interface{}{}), ErrEmptyInput)
+
+	g = NewGraph(testUri)
+	g.SetLimits(Limits{MaxLiteralLength: 3})
+	err := g.ParseJSONLDMap(map[string]interface{}{"@id": "http://example.org/a", "http://example.org/b": "long"})
+	assert.EqualError(t, err, "literal length 4 exceeds the maximum of 3")
+	assert.Equal(t, 0, g.Len())
+
+	var warnings []Warning
+	g = NewGraph(testUri)
+	g.SetWarningHandler(func(w Warning) { warnings = append(warnings, w) })
+	require.NoError(t, g.ParseJSONLDMap(map[string]interface{}{"@id": "http://example.org/a", "foaf:name": "Alice"}))
+	require.Equal(t, 1, len(warnings))
+	assert.Equal(t, WarnUndefinedPrefix, warnings[0].Kind)
+}
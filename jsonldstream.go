@@ -0,0 +1,174 @@
+package rdf2go
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	jsonld "github.com/linkeddata/gojsonld"
+)
+
+// streamBNodePrefix is the prefix of the IRIs standing for the labelled
+// blank nodes of a streamed JSON-LD array, since gojsonld relabels blank
+// nodes each time it converts an element, which would keep the elements
+// referring to the same blank node apart
+const streamBNodePrefix = "urn:x-rdf2go-bnode:"
+
+// startsJSONArray returns whether the first character of the input, after
+// whitespace, opens a JSON array, without consuming it. Input starting with
+// a byte order mark is not streamed.
+func startsJSONArray(br *bufio.Reader) bool {
+	for n := 1; n <= br.Size(); n++ {
+		p, err := br.Peek(n)
+		if err != nil {
+			return false
+		}
+		switch p[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// parseJSONLDStream parses a JSON-LD document made of a top level array one
+// element at a time, so that neither the document nor its decoded form is
+// ever held in memory as a whole. Each element is expanded with its own
+// context, as JSON-LD processors do for the elements of a top level array.
+// each is called after every element, unless it is nil. On errors, the
+// triples of the elements parsed before are kept in b.
+func (g *Graph) parseJSONLDStream(ctx context.Context, r io.Reader, b *tripleBuilder, each func() error) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return &ParseError{Msg: err.Error()}
+	}
+	for i := 0; dec.More(); i++ {
+		var element interface{}
+		if err := dec.Decode(&element); err != nil {
+			return &ParseError{Msg: fmt.Sprintf("element %d: %v", i, err)}
+		}
+		err := g.addJSONLDElement(ctx, element, "e"+strconv.Itoa(i)+"_", b)
+		if err == nil && each != nil {
+			err = each()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return &ParseError{Msg: err.Error()}
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return &ParseError{Msg: "unexpected data after the top level array"}
+	}
+	return g.checkJSONLDTriples(b.triples)
+}
+
+// parseNDJSONLD parses line delimited JSON-LD, holding a JSON-LD document,
+// typically a node object, on each line. Lines are parsed as they are read,
+// and blank lines are skipped. The blank nodes labelled in the documents are
+// shared by all the lines, as if they were the elements of an array. each
+// is called after every line, unless it is nil. On errors, the triples of
+// the lines parsed before are kept in b.
+func (g *Graph) parseNDJSONLD(ctx context.Context, r io.Reader, b *tripleBuilder, each func() error) error {
+	br, err := streamReader(r)
+	if err != nil {
+		return err
+	}
+	for line := 1; ; line++ {
+		data, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			var doc interface{}
+			if jerr := json.Unmarshal(data, &doc); jerr != nil {
+				return &ParseError{Line: line, Msg: jerr.Error()}
+			}
+			jerr := g.addJSONLDElement(ctx, doc, "l"+strconv.Itoa(line)+"_", b)
+			if jerr == nil && each != nil {
+				jerr = each()
+			}
+			if jerr != nil {
+				return jerr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return g.checkJSONLDTriples(b.triples)
+}
+
+// checkJSONLDTriples reports the triples parsed from JSON-LD to the warning
+// handler, if any, and checks them against the limits of the graph
+func (g *Graph) checkJSONLDTriples(triples []*Triple) error {
+	if g.warn != nil {
+		warnTriples(triples, g.warn)
+	}
+	return g.limits.checkTerms(triples)
+}
+
+// addJSONLDElement converts an element of a streamed JSON-LD array to
+// triples. The blank nodes labelled by gojsonld are scoped to the element
+// with scope, while those labelled in the document keep their label.
+func (g *Graph) addJSONLDElement(ctx context.Context, element interface{}, scope string, b *tripleBuilder) error {
+	options := &jsonld.Options{}
+	expanded, err := jsonld.Expand(element, options)
+	if err != nil {
+		return &ParseError{Msg: err.Error()}
+	}
+	dataSet, err := jsonld.ToRDF(labelBlankNodes(expanded), options)
+	if err != nil {
+		return &ParseError{Msg: err.Error()}
+	}
+	term := func(t jsonld.Term) Term {
+		switch t := jterm2term(t).(type) {
+		case *BlankNode:
+			return NewBlankNode(scope + t.ID)
+		case *Resource:
+			if strings.HasPrefix(t.URI, streamBNodePrefix) {
+				return NewBlankNode(t.URI[len(streamBNodePrefix):])
+			}
+		}
+		return jterm2term(t)
+	}
+	for t := range dataSet.IterTriples() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b.add(term(t.Subject), term(t.Predicate), term(t.Object))
+	}
+	return nil
+}
+
+// labelBlankNodes replaces the blank node identifiers of an expanded JSON-LD
+// document with IRIs starting with streamBNodePrefix, so that gojsonld keeps
+// them apart from its own labels
+func labelBlankNodes(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = labelBlankNodes(item)
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			if key != "@value" {
+				v[key] = labelBlankNodes(value)
+			}
+		}
+	case string:
+		if strings.HasPrefix(v, "_:") {
+			return streamBNodePrefix + v[2:]
+		}
+	}
+	return v
+}
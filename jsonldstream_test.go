@@ -0,0 +1,121 @@
+package rdf2go
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonldArray returns a JSON-LD array of n elements
+func jsonldArray(n int) io.Reader {
+	var b strings.Builder
+	b.WriteString(" [")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, `{"@id": "http://example.org/%d", "http://example.org/p": {"@value": %d}}`, i, i)
+	}
+	b.WriteString("]")
+	return strings.NewReader(b.String())
+}
+
+func TestParseJSONLDStream(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.Parse(jsonldArray(5000), "application/ld+json"))
+	assert.Equal(t, 5000, g.Len())
+	assert.NotNil(t, g.One(NewResource("http://example.org/4999"), NewResource("http://example.org/p"), nil))
+}
+
+func TestParseJSONLDStreamBlankNodes(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader(`[
+		{"@context": {"knows": {"@id": "http://xmlns.com/foaf/0.1/knows", "@type": "@id"}},
+		 "@id": "_:x", "knows": "_:y", "http://example.org/p": {"http://example.org/q": "a"}},
+		{"@id": "_:y", "http://example.org/p": {"http://example.org/q": "b"}}
+	]`), "application/ld+json")
+	require.NoError(t, err)
+	assert.Equal(t, 5, g.Len())
+
+	knows := g.One(nil, NewResource("http://xmlns.com/foaf/0.1/knows"), nil)
+	require.NotNil(t, knows)
+	assert.IsType(t, &BlankNode{}, knows.Subject)
+	assert.NotNil(t, g.One(knows.Object, NewResource("http://example.org/p"), nil))
+
+	// the unlabelled blank nodes of the elements are distinct
+	values := g.All(nil, NewResource("http://example.org/q"), nil)
+	require.Len(t, values, 2)
+	assert.False(t, values[0].Subject.Equal(values[1].Subject))
+}
+
+func TestParseJSONLDStreamErrors(t *testing.T) {
+	doc := `[{"@id": "http://a", "http://b": "c"}, {"@id": "http://d", "http://b": ]`
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader(doc), "application/ld+json")
+	var perr *ParseError
+	assert.ErrorAs(t, err, &perr)
+	assert.Equal(t, 0, g.Len())
+
+	g.SetKeepPartial(true)
+	err = g.Parse(strings.NewReader(doc), "application/ld+json")
+	var partial *PartialParseError
+	assert.ErrorAs(t, err, &partial)
+	assert.Equal(t, 1, g.Len())
+
+	err = NewGraph(testUri).Parse(strings.NewReader(`[{"@id": "http://a", "http://b": "c"}] {}`), "application/ld+json")
+	assert.ErrorAs(t, err, &perr)
+}
+
+func TestParseJSONLDStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g := NewGraph(testUri)
+	err := g.ParseContext(ctx, jsonldArray(10), "application/ld+json")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestParseNDJSONLD(t *testing.T) {
+	doc := "\xef\xbb\xbf" + `{"@context": {"@vocab": "http://schema.org/"}, "@id": "http://example.org/1", "name": "one", "knows": {"@id": "_:x"}}
+
+{"@id": "_:x", "http://schema.org/name": "two", "http://schema.org/address": {"http://schema.org/city": "Paris"}}
+{"@id": "_:y", "http://schema.org/address": {"http://schema.org/city": "Rome"}}
+`
+	g := NewGraph(testUri)
+	require.NoError(t, g.Parse(strings.NewReader(doc), "application/x-ndjson"))
+	assert.Equal(t, 7, g.Len())
+
+	knows := g.One(NewResource("http://example.org/1"), NewResource("http://schema.org/knows"), nil)
+	require.NotNil(t, knows)
+	assert.NotNil(t, g.One(knows.Object, NewResource("http://schema.org/name"), NewLiteral("two")))
+
+	cities := g.All(nil, NewResource("http://schema.org/city"), nil)
+	require.Len(t, cities, 2)
+	assert.False(t, cities[0].Subject.Equal(cities[1].Subject))
+}
+
+func TestParseNDJSONLDErrors(t *testing.T) {
+	doc := `{"@id": "http://a", "http://b": "c"}
+{"@id": "http://d", "http://b": }
+{"@id": "http://e", "http://b": "f"}`
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader(doc), "application/x-ndjson")
+	var perr *ParseError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, 2, perr.Line)
+	assert.Equal(t, 0, g.Len())
+
+	g.SetKeepPartial(true)
+	err = g.Parse(strings.NewReader(doc), "application/x-ndjson")
+	assert.Error(t, err)
+	assert.Equal(t, 1, g.Len())
+
+	g = NewGraph(testUri)
+	g.SetParseMode(ParseStrict)
+	assert.ErrorIs(t, g.Parse(strings.NewReader("\n\n"), "application/x-ndjson"), ErrEmptyInput)
+}
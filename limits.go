@@ -0,0 +1,166 @@
+package rdf2go
+
+import (
+	"fmt"
+	"io"
+)
+
+// Limits holds guardrails applied when parsing untrusted data. A zero value
+// for any of the fields means that no limit is enforced.
+type Limits struct {
+	// MaxInputSize is the maximum number of bytes read from the input
+	MaxInputSize int64
+	// MaxIRILength is the maximum length of an IRI (including datatype IRIs)
+	MaxIRILength int
+	// MaxLiteralLength is the maximum length of a literal value
+	MaxLiteralLength int
+	// MaxBlankNodeDepth is the maximum length of a chain of nested blank nodes
+	MaxBlankNodeDepth int
+}
+
+// SetLimits sets the limits used when parsing data into the graph
+func (g *Graph) SetLimits(limits Limits) {
+	g.limits = limits
+}
+
+// Limits returns the limits used when parsing data into the graph
+func (g *Graph) Limits() Limits {
+	return g.limits
+}
+
+// reader wraps r so that reading fails once more than MaxInputSize bytes
+// have been consumed
+func (l Limits) reader(r io.Reader) io.Reader {
+	if l.MaxInputSize <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, n: l.MaxInputSize}
+}
+
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.n < 0 {
+		return 0, fmt.Errorf("input exceeds the maximum size")
+	}
+	// read one byte past the limit to tell if the input is too large
+	if int64(len(p)) > lr.n+1 {
+		p = p[:lr.n+1]
+	}
+	n, err := lr.r.Read(p)
+	lr.n -= int64(n)
+	if lr.n < 0 {
+		return n, fmt.Errorf("input exceeds the maximum size")
+	}
+	return n, err
+}
+
+// checkTerms validates the length of the terms of parsed triples, for
+// parsers which do not enforce the limits themselves
+func (l Limits) checkTerms(triples []*Triple) error {
+	if l.MaxIRILength <= 0 && l.MaxLiteralLength <= 0 {
+		return nil
+	}
+	for _, triple := range triples {
+		for _, term := range []Term{triple.Subject, triple.Predicate, triple.Object} {
+			err := l.checkTerm(term)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkDepth validates the length of the chains of blank nodes of parsed
+// triples, which include the nodes of collections
+func (l Limits) checkDepth(triples []*Triple) error {
+	if l.MaxBlankNodeDepth <= 0 {
+		return nil
+	}
+	depth := bnodeDepth(triples)
+	if depth > l.MaxBlankNodeDepth {
+		return fmt.Errorf("blank node depth %d exceeds the maximum of %d", depth, l.MaxBlankNodeDepth)
+	}
+	return nil
+}
+
+func (l Limits) checkTerm(term Term) error {
+	switch term := term.(type) {
+	case *Resource:
+		if l.MaxIRILength > 0 && len(term.URI) > l.MaxIRILength {
+			return fmt.Errorf("IRI length %d exceeds the maximum of %d", len(term.URI), l.MaxIRILength)
+		}
+	case *Literal:
+		if l.MaxLiteralLength > 0 && len(term.Value) > l.MaxLiteralLength {
+			return fmt.Errorf("literal length %d exceeds the maximum of %d", len(term.Value), l.MaxLiteralLength)
+		}
+		if term.Datatype != nil {
+			return l.checkTerm(term.Datatype)
+		}
+	}
+	return nil
+}
+
+// bnodeDepth returns the length of the longest chain of blank nodes linked
+// together as subject and object
+func bnodeDepth(triples []*Triple) int {
+	edges := make(map[string][]string)
+	for _, triple := range triples {
+		s, ok := triple.Subject.(*BlankNode)
+		if !ok {
+			continue
+		}
+		o, ok := triple.Object.(*BlankNode)
+		if !ok {
+			continue
+		}
+		edges[s.ID] = append(edges[s.ID], o.ID)
+	}
+
+	// depth first walk, with an explicit stack since chains can be as long
+	// as the input allows
+	type frame struct {
+		id   string
+		next int
+	}
+	depths := make(map[string]int)
+	visiting := make(map[string]bool)
+	max := 0
+	for root := range edges {
+		if _, ok := depths[root]; ok {
+			continue
+		}
+		visiting[root] = true
+		stack := []frame{{id: root}}
+		for len(stack) > 0 {
+			f := &stack[len(stack)-1]
+			if f.next < len(edges[f.id]) {
+				child := edges[f.id][f.next]
+				f.next++
+				// cycles do not make a chain any deeper
+				if _, ok := depths[child]; !ok && !visiting[child] {
+					visiting[child] = true
+					stack = append(stack, frame{id: child})
+				}
+				continue
+			}
+			d := 0
+			for _, child := range edges[f.id] {
+				if depths[child] > d {
+					d = depths[child]
+				}
+			}
+			depths[f.id] = d + 1
+			visiting[f.id] = false
+			if d+1 > max {
+				max = d + 1
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return max
+}
@@ -0,0 +1,72 @@
+package rdf2go
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsInputSize(t *testing.T) {
+	g := NewGraph(testUri)
+	g.SetLimits(Limits{MaxInputSize: 10})
+	err := g.Parse(strings.NewReader(simpleTurtle), "text/turtle")
+	assert.Error(t, err)
+	assert.Equal(t, 0, g.Len())
+
+	g.SetLimits(Limits{MaxInputSize: int64(len(simpleTurtle))})
+	err = g.Parse(strings.NewReader(simpleTurtle), "text/turtle")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.Len())
+}
+
+func TestLimitsTermLength(t *testing.T) {
+	g := NewGraph(testUri)
+	g.SetLimits(Limits{MaxLiteralLength: 3})
+	err := g.Parse(strings.NewReader(simpleTurtle), "text/turtle")
+	assert.Error(t, err)
+
+	g.SetLimits(Limits{MaxIRILength: 20})
+	err = g.Parse(strings.NewReader(simpleTurtle), "text/turtle")
+	assert.Error(t, err)
+	assert.Equal(t, 0, g.Len())
+	assert.Equal(t, 20, g.Limits().MaxIRILength)
+}
+
+func TestLimitsTermLengthWhileParsing(t *testing.T) {
+	g := NewGraph(testUri)
+	g.SetLimits(Limits{MaxLiteralLength: 3})
+	err := g.Parse(strings.NewReader("<http://a> <http://b> \"abcdef\" ."), "text/turtle")
+	assert.EqualError(t, err, "line 1, column 28: literal length exceeds the maximum of 3")
+
+	g.SetLimits(Limits{MaxIRILength: 5})
+	err = g.Parse(strings.NewReader("<http://a> <http://b> <c> ."), "text/turtle")
+	assert.EqualError(t, err, "line 1, column 8: IRI length exceeds the maximum of 5")
+
+	err = g.Parse(strings.NewReader(`{"@id": "http://example.org/a", "http://b": "c"}`), "application/ld+json")
+	assert.EqualError(t, err, "IRI length 20 exceeds the maximum of 5")
+}
+
+func TestBlankNodeDepthLongChain(t *testing.T) {
+	n := 200000
+	triples := make([]*Triple, 0, n)
+	for i := 0; i < n; i++ {
+		triples = append(triples, NewTriple(NewBlankNode(fmt.Sprint(i)), NewResource("p"), NewBlankNode(fmt.Sprint(i+1))))
+	}
+	// a cycle does not make the chain any longer
+	triples = append(triples, NewTriple(NewBlankNode(fmt.Sprint(n)), NewResource("p"), NewBlankNode("0")))
+	assert.Equal(t, n+1, bnodeDepth(triples))
+	assert.Error(t, Limits{MaxBlankNodeDepth: 10}.checkDepth(triples))
+}
+
+func TestLimitsBlankNodeDepth(t *testing.T) {
+	data := "<#a> <#p> [ <#p> [ <#p> [ <#p> \"deep\" ] ] ] ."
+	g := NewGraph(testUri)
+	g.SetLimits(Limits{MaxBlankNodeDepth: 2})
+	assert.Error(t, g.Parse(strings.NewReader(data), "text/turtle"))
+
+	g.SetLimits(Limits{MaxBlankNodeDepth: 3})
+	assert.NoError(t, g.Parse(strings.NewReader(data), "text/turtle"))
+	assert.Equal(t, 4, g.Len())
+}
@@ -0,0 +1,81 @@
+package rdf2go
+
+// SetOrdered makes the items the only value of p for s, as an rdf:List
+// keeping their order, which repeated triples do not. The previous values
+// of p for s are removed, along with the rdf:first and rdf:rest triples of
+// the lists they are the head of. An empty list of items is written as
+// rdf:nil.
+func (g *Graph) SetOrdered(s Term, p Term, items []Term) {
+	for _, t := range g.All(s, p, nil) {
+		g.Remove(t)
+		g.removeList(t.Object)
+	}
+	head := NewResource(nsRDF + "nil")
+	for i := len(items) - 1; i >= 0; i-- {
+		node := NewAnonNode()
+		g.AddTriple(node, NewResource(nsRDF+"first"), items[i])
+		g.AddTriple(node, NewResource(nsRDF+"rest"), head)
+		head = node
+	}
+	g.AddTriple(s, p, head)
+}
+
+// GetOrdered returns the items of the rdf:List that is the value of p for
+// s, in order. It returns nil when s has no value for p, when the value is
+// not a well-formed list, or when it has several values.
+func (g *Graph) GetOrdered(s Term, p Term) []Term {
+	values := g.All(s, p, nil)
+	if len(values) != 1 {
+		return nil
+	}
+	items, ok := g.listItems(values[0].Object)
+	if !ok {
+		return nil
+	}
+	return items
+}
+
+// listItems returns the items of the list starting at head, and whether it
+// is a well-formed list: each node has exactly one rdf:first and one
+// rdf:rest, and the list ends with rdf:nil without going through a node
+// twice.
+func (g *Graph) listItems(head Term) ([]Term, bool) {
+	items := []Term{}
+	seen := map[string]bool{}
+	for node := head; !node.Equal(NewResource(nsRDF + "nil")); {
+		key := encodeTerm(node)
+		if seen[key] {
+			return nil, false
+		}
+		seen[key] = true
+		first := g.All(node, NewResource(nsRDF+"first"), nil)
+		rest := g.All(node, NewResource(nsRDF+"rest"), nil)
+		if len(first) != 1 || len(rest) != 1 {
+			return nil, false
+		}
+		items = append(items, first[0].Object)
+		node = rest[0].Object
+	}
+	return items, true
+}
+
+// removeList removes the rdf:first and rdf:rest triples of the blank nodes
+// of the list starting at head
+func (g *Graph) removeList(head Term) {
+	seen := map[string]bool{}
+	for node := head; node != nil; {
+		if _, ok := node.(*BlankNode); !ok || seen[encodeTerm(node)] {
+			return
+		}
+		seen[encodeTerm(node)] = true
+		var next Term
+		for _, t := range g.All(node, NewResource(nsRDF+"first"), nil) {
+			g.Remove(t)
+		}
+		for _, t := range g.All(node, NewResource(nsRDF+"rest"), nil) {
+			g.Remove(t)
+			next = t.Object
+		}
+		node = next
+	}
+}
@@ -0,0 +1,45 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedValues(t *testing.T) {
+	g := NewGraph(testUri)
+	s, authors := NewResource("http://example.org/paper"), NewResource("http://example.org/authors")
+	items := []Term{NewResource("http://example.org/c"), NewResource("http://example.org/a"), NewLiteral("b")}
+
+	g.SetOrdered(s, authors, items)
+	assert.Equal(t, items, g.GetOrdered(s, authors))
+	assert.Equal(t, 7, g.Len())
+
+	// replacing the list removes the previous one
+	g.SetOrdered(s, authors, items[:1])
+	assert.Equal(t, items[:1], g.GetOrdered(s, authors))
+	assert.Equal(t, 3, g.Len())
+
+	g.SetOrdered(s, authors, nil)
+	assert.Equal(t, []Term{}, g.GetOrdered(s, authors))
+	assert.Equal(t, 1, g.Len())
+	assert.True(t, g.Contains(s, authors, NewResource(nsRDF+"nil")))
+
+	assert.Nil(t, g.GetOrdered(s, NewResource("http://example.org/missing")))
+}
+
+func TestOrderedValuesParsed(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString(`@prefix ex: <http://example.org/> .
+ex:s ex:list (ex:a ex:b ex:c) ;
+    ex:plain ex:a ;
+    ex:broken _:n .
+_:n <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> ex:a ;
+    <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:n .`, "text/turtle"))
+	ex := func(name string) Term { return NewResource("http://example.org/" + name) }
+
+	assert.Equal(t, []Term{ex("a"), ex("b"), ex("c")}, g.GetOrdered(ex("s"), ex("list")))
+	assert.Nil(t, g.GetOrdered(ex("s"), ex("plain")))
+	assert.Nil(t, g.GetOrdered(ex("s"), ex("broken")))
+}
@@ -0,0 +1,126 @@
+package rdf2go
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// documentScopes numbers the documents merged by mergeDocument
+var documentScopes atomic.Uint64
+
+// mergeDocument merges a document loaded along with others into the graph,
+// like Merge, but prefixes its blank node labels with d<N>_, N being unique
+// to the document. Parsers label blank nodes per document (a0, a1, ...), so
+// blank nodes from different documents would otherwise be merged.
+func (g *Graph) mergeDocument(doc *Graph) {
+	scope := "d" + strconv.FormatUint(documentScopes.Add(1), 10) + "_"
+	scoped := func(t Term) Term {
+		if b, ok := t.(*BlankNode); ok {
+			return NewBlankNode(scope + b.ID)
+		}
+		return t
+	}
+	for triple := range doc.IterTriples() {
+		g.Add(NewTriple(scoped(triple.Subject), scoped(triple.Predicate), scoped(triple.Object)))
+	}
+	g.addPrefixes(doc.prefixes)
+}
+
+// LoadURIs fetches and parses several documents in parallel, using at most
+// concurrency simultaneous requests, and merges them into the graph. Requests
+// to the same host are never made in parallel, and the optional hostInterval
+// sets a minimum delay between two requests to the same host. A URI that
+// fails to load is left out of the graph and reported, prefixed with the
+// URI, in the joined error returned once every URI has been tried. The
+// blank nodes of each document are kept apart, see mergeDocument.
+// The limits, parse mode and warning handler of the graph apply to every
+// document; the handler may be called from several goroutines at once.
+func (g *Graph) LoadURIs(uris []string, concurrency int, hostInterval ...time.Duration) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	interval := time.Duration(0)
+	if len(hostInterval) > 0 {
+		interval = hostInterval[0]
+	}
+
+	var (
+		mu    sync.Mutex
+		errs  []error
+		wg    sync.WaitGroup
+		hosts = make(map[string]*hostLimiter)
+		jobs  = make(chan string)
+	)
+	limiter := func(uri string) *hostLimiter {
+		host := uri
+		if u, err := url.Parse(uri); err == nil {
+			host = u.Host
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if hosts[host] == nil {
+			hosts[host] = &hostLimiter{interval: interval}
+		}
+		return hosts[host]
+	}
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for uri := range jobs {
+				l := limiter(uri)
+				l.acquire()
+				doc := NewGraph(defrag(uri))
+				doc.httpClient = g.httpClient
+				doc.limits = g.limits
+				doc.warn = g.warn
+				doc.parseMode = g.parseMode
+				doc.cache = g.cache
+				err := doc.LoadURI(uri)
+				l.release()
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", uri, err))
+				} else {
+					started := time.Now()
+					g.mergeDocument(doc)
+					g.recordActivity("merge", doc.Term(), started)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, uri := range uris {
+		jobs <- uri
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// hostLimiter serializes the requests made to a host, spacing them by interval
+type hostLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func (h *hostLimiter) acquire() {
+	h.mu.Lock()
+	if wait := h.interval - time.Since(h.last); !h.last.IsZero() && wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (h *hostLimiter) release() {
+	h.last = time.Now()
+	h.mu.Unlock()
+}
@@ -0,0 +1,56 @@
+package rdf2go
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadURIs(t *testing.T) {
+	g := NewGraph(testUri)
+	uris := []string{
+		testServer.URL + "/foo",
+		testServer.URL + "/foo#me",
+		testServer.URL + "/fail",
+		testServer.URL + "/invalid",
+	}
+	err := g.LoadURIs(uris, 2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/fail")
+	assert.Contains(t, err.Error(), "/invalid")
+	// both URIs are the same document, whose triples are only added once
+	assert.Equal(t, 2, g.Len())
+}
+
+func TestLoadURIsSettings(t *testing.T) {
+	g := NewGraph(testUri)
+	g.SetParseMode(ParseStrict)
+	var mu sync.Mutex
+	var warnings []Warning
+	g.SetWarningHandler(func(w Warning) {
+		mu.Lock()
+		defer mu.Unlock()
+		warnings = append(warnings, w)
+	})
+	err := g.LoadURIs([]string{testServer.URL + "/foo", testServer.URL + "/empty"}, 2)
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	assert.Contains(t, err.Error(), "/empty")
+	assert.Equal(t, 2, g.Len())
+	assert.NotEmpty(t, warnings)
+}
+
+func TestLoadURIsHostInterval(t *testing.T) {
+	g := NewGraph(testUri)
+	uris := []string{
+		testServer.URL + "/foo",
+		testServer.URL + "/foo",
+	}
+	start := time.Now()
+	err := g.LoadURIs(uris, 0, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, 2, g.Len())
+}
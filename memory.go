@@ -0,0 +1,42 @@
+package rdf2go
+
+import (
+	"unsafe"
+)
+
+// approximate per-entry overhead of a Go map bucket slot
+const mapEntryOverhead = 48
+
+// EstimateMemory returns an approximation of the heap memory (in bytes) held
+// by the graph's triples, terms and index. Terms shared by several triples
+// are only counted once.
+func (g *Graph) EstimateMemory() int64 {
+	var size int64
+	seen := make(map[Term]bool)
+	for triple := range g.IterTriples() {
+		size += mapEntryOverhead + int64(unsafe.Sizeof(*triple))
+		// the key of the triple in the default store
+		size += int64(len(tripleKey(triple.Subject, triple.Predicate, triple.Object)))
+		size += termMemory(triple.Subject, seen)
+		size += termMemory(triple.Predicate, seen)
+		size += termMemory(triple.Object, seen)
+	}
+	return size
+}
+
+func termMemory(term Term, seen map[Term]bool) int64 {
+	if term == nil || seen[term] {
+		return 0
+	}
+	seen[term] = true
+	switch term := term.(type) {
+	case *Resource:
+		return int64(unsafe.Sizeof(*term)) + int64(len(term.URI))
+	case *Literal:
+		return int64(unsafe.Sizeof(*term)) + int64(len(term.Value)) + int64(len(term.Language)) +
+			termMemory(term.Datatype, seen)
+	case *BlankNode:
+		return int64(unsafe.Sizeof(*term)) + int64(len(term.ID))
+	}
+	return int64(len(term.RawValue()))
+}
@@ -0,0 +1,23 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateMemory(t *testing.T) {
+	g := NewGraph(testUri)
+	assert.Equal(t, int64(0), g.EstimateMemory())
+
+	s := NewResource("a")
+	g.AddTriple(s, NewResource("b"), NewLiteralWithLanguage("c", "en"))
+	one := g.EstimateMemory()
+	assert.True(t, one > 0)
+
+	// the shared subject is only counted once
+	g.AddTriple(s, NewResource("b"), NewBlankNode("n1"))
+	two := g.EstimateMemory()
+	assert.True(t, two > one)
+	assert.True(t, two < 2*one)
+}
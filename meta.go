@@ -0,0 +1,65 @@
+package rdf2go
+
+import (
+	"net/http"
+	"time"
+)
+
+// Metadata describes the document a graph was loaded from. LoadURI fills it
+// from the HTTP response, so that callers can implement caching and
+// conditional requests.
+type Metadata struct {
+	// BaseURI is the URI relative IRIs were resolved against
+	BaseURI string
+	// SourceURL is the URL the document was fetched from, after redirects
+	SourceURL string
+	// ContentType is the media type of the document, without parameters
+	ContentType string
+	// ETag is the entity tag of the document, if the server sent one
+	ETag string
+	// LastModified is the modification time of the document, if the server
+	// sent one
+	LastModified time.Time
+	// Values holds the application defined metadata set with SetMeta
+	Values map[string]string
+}
+
+// Metadata returns the metadata of the graph, which can be modified in place
+func (g *Graph) Metadata() *Metadata {
+	return &g.meta
+}
+
+// SetMeta sets an application defined metadata value
+func (g *Graph) SetMeta(key string, value string) {
+	if g.meta.Values == nil {
+		g.meta.Values = make(map[string]string)
+	}
+	g.meta.Values[key] = value
+}
+
+// Meta returns an application defined metadata value, or an empty string if
+// it is not set
+func (g *Graph) Meta(key string) string {
+	return g.meta.Values[key]
+}
+
+// copy returns a copy of the metadata not sharing its values
+func (m Metadata) copy() Metadata {
+	if m.Values != nil {
+		values := make(map[string]string, len(m.Values))
+		for k, v := range m.Values {
+			values[k] = v
+		}
+		m.Values = values
+	}
+	return m
+}
+
+// setResponse fills the metadata from the response a document was read from
+func (m *Metadata) setResponse(base string, r *http.Response) {
+	m.BaseURI = base
+	m.SourceURL = r.Request.URL.String()
+	m.ContentType = mediaType(r.Header.Get("Content-Type"))
+	m.ETag = r.Header.Get("ETag")
+	m.LastModified, _ = http.ParseTime(r.Header.Get("Last-Modified"))
+}
@@ -0,0 +1,53 @@
+package rdf2go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphMetadata(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/old" {
+			http.Redirect(w, req, "/doc", http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("Content-Type", "text/turtle; charset=utf-8")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+		w.Write([]byte(simpleTurtle))
+	}))
+	defer ts.Close()
+
+	g := NewGraph("")
+	require.NoError(t, g.LoadURI(ts.URL+"/old#me"))
+	meta := g.Metadata()
+	assert.Equal(t, ts.URL+"/old", meta.BaseURI)
+	assert.Equal(t, ts.URL+"/doc", meta.SourceURL)
+	assert.Equal(t, "text/turtle", meta.ContentType)
+	assert.Equal(t, `"v1"`, meta.ETag)
+	assert.True(t, modified.Equal(meta.LastModified))
+
+	g.SetMeta("owner", "alice")
+	assert.Equal(t, "alice", g.Meta("owner"))
+	assert.Equal(t, "", g.Meta("missing"))
+	snapshot := g.Snapshot()
+	g.SetMeta("owner", "bob")
+	assert.Equal(t, "alice", snapshot.Meta("owner"))
+	assert.Equal(t, `"v1"`, snapshot.Metadata().ETag)
+
+	// metadata is kept for documents served from a cache
+	cache := &GraphCache{}
+	cached := NewGraph("")
+	cached.SetCache(cache)
+	require.NoError(t, cached.LoadURI(ts.URL+"/doc"))
+	cached = NewGraph("")
+	cached.SetCache(cache)
+	require.NoError(t, cached.LoadURI(ts.URL+"/doc"))
+	assert.Equal(t, `"v1"`, cached.Metadata().ETag)
+}
@@ -0,0 +1,141 @@
+package rdf2go
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Metrics is used to monitor the work done by graphs. Implementations must be
+// safe for concurrent use, and can for instance feed Prometheus counters and
+// histograms.
+type Metrics interface {
+	// ObserveParse is called after parsing data of the given mime type
+	ObserveParse(mime string, triples int, duration time.Duration, err error)
+	// ObserveFetch is called after fetching a document over HTTP. The status
+	// is 0 if the request failed.
+	ObserveFetch(uri string, status int, duration time.Duration, err error)
+	// ObserveSerialize is called after serializing a graph
+	ObserveSerialize(mime string, bytes int64, duration time.Duration, err error)
+}
+
+var (
+	metricsMu sync.RWMutex
+	metrics   Metrics
+)
+
+// SetMetrics sets the Metrics notified by all graphs. Passing nil disables
+// metrics collection, which is the default.
+func SetMetrics(m Metrics) {
+	metricsMu.Lock()
+	metrics = m
+	metricsMu.Unlock()
+}
+
+func currentMetrics() Metrics {
+	metricsMu.RLock()
+	defer metricsMu.RUnlock()
+	return metrics
+}
+
+// ExpvarMetrics is a Metrics implementation publishing counters with expvar,
+// so they are served as JSON on /debug/vars. Durations are also counted in
+// the cumulative buckets of the parse_seconds, http_fetch_seconds and
+// serialize_seconds histograms, keyed by their upper bound in seconds.
+type ExpvarMetrics struct {
+	vars *expvar.Map
+}
+
+// durationBuckets are the upper bounds, in seconds, of the histogram buckets
+var durationBuckets = []float64{0.001, 0.01, 0.1, 1, 10}
+
+var expvarMu sync.Mutex
+
+// NewExpvarMetrics creates an ExpvarMetrics publishing its counters under the
+// given expvar name (e.g. "rdf2go"). The counters are shared with any
+// ExpvarMetrics created earlier with the same name. It returns an error if
+// the name is already used by another kind of expvar variable.
+func NewExpvarMetrics(name string) (*ExpvarMetrics, error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	switch v := expvar.Get(name).(type) {
+	case nil:
+		return &ExpvarMetrics{vars: expvar.NewMap(name)}, nil
+	case *expvar.Map:
+		return &ExpvarMetrics{vars: v}, nil
+	default:
+		return nil, fmt.Errorf("expvar %q is already published as a %T", name, v)
+	}
+}
+
+// Get returns the current value of a counter
+func (m *ExpvarMetrics) Get(key string) expvar.Var {
+	return m.vars.Get(key)
+}
+
+// ObserveParse implements Metrics
+func (m *ExpvarMetrics) ObserveParse(mime string, triples int, duration time.Duration, err error) {
+	m.vars.Add("parse_total", 1)
+	if err != nil {
+		m.vars.Add("parse_errors_total", 1)
+	}
+	m.vars.Add("triples_parsed_total", int64(triples))
+	m.vars.AddFloat("parse_seconds_total", duration.Seconds())
+	m.observe("parse_seconds", duration)
+}
+
+// ObserveFetch implements Metrics
+func (m *ExpvarMetrics) ObserveFetch(uri string, status int, duration time.Duration, err error) {
+	m.vars.Add("http_fetches_total", 1)
+	if err != nil || status != 200 {
+		m.vars.Add("http_fetch_errors_total", 1)
+	}
+	m.vars.AddFloat("http_fetch_seconds_total", duration.Seconds())
+	m.observe("http_fetch_seconds", duration)
+}
+
+// ObserveSerialize implements Metrics
+func (m *ExpvarMetrics) ObserveSerialize(mime string, bytes int64, duration time.Duration, err error) {
+	m.vars.Add("serialize_total", 1)
+	if err != nil {
+		m.vars.Add("serialize_errors_total", 1)
+	}
+	m.vars.Add("serialized_bytes_total", bytes)
+	m.vars.AddFloat("serialize_seconds_total", duration.Seconds())
+	m.observe("serialize_seconds", duration)
+}
+
+// observe counts duration in the buckets of the named histogram
+func (m *ExpvarMetrics) observe(name string, duration time.Duration) {
+	h, ok := m.vars.Get(name).(*expvar.Map)
+	if !ok {
+		expvarMu.Lock()
+		if h, ok = m.vars.Get(name).(*expvar.Map); !ok {
+			h = new(expvar.Map)
+			m.vars.Set(name, h)
+		}
+		expvarMu.Unlock()
+	}
+	seconds := duration.Seconds()
+	for _, le := range durationBuckets {
+		if seconds <= le {
+			h.Add(strconv.FormatFloat(le, 'g', -1, 64), 1)
+		}
+	}
+	h.Add("+Inf", 1)
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
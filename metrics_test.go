@@ -0,0 +1,48 @@
+package rdf2go
+
+import (
+	"bytes"
+	"expvar"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpvarMetrics(t *testing.T) {
+	m, err := NewExpvarMetrics("rdf2go_test")
+	assert.NoError(t, err)
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	g := NewGraph(testUri)
+	assert.NoError(t, g.Parse(strings.NewReader(simpleTurtle), "text/turtle"))
+	assert.Error(t, g.Parse(strings.NewReader("<a> <b>"), "text/turtle"))
+	assert.NoError(t, g.LoadURI(testServer.URL+"/foo"))
+	assert.Error(t, g.LoadURI(testServer.URL+"/missing"))
+	var buf bytes.Buffer
+	assert.NoError(t, g.Serialize(&buf, "text/turtle"))
+
+	assert.Equal(t, "3", m.Get("parse_total").String())
+	assert.Equal(t, "1", m.Get("parse_errors_total").String())
+	assert.Equal(t, "4", m.Get("triples_parsed_total").String())
+	assert.Equal(t, "2", m.Get("http_fetches_total").String())
+	assert.Equal(t, "1", m.Get("http_fetch_errors_total").String())
+	assert.Equal(t, "1", m.Get("serialize_total").String())
+	assert.Equal(t, strconv.Itoa(buf.Len()), m.Get("serialized_bytes_total").String())
+
+	parse := m.Get("parse_seconds").(*expvar.Map)
+	assert.Equal(t, "3", parse.Get("+Inf").String())
+	assert.Equal(t, "3", parse.Get("10").String())
+	assert.Equal(t, "1", m.Get("serialize_seconds").(*expvar.Map).Get("+Inf").String())
+
+	// the counters are shared by metrics with the same name
+	again, err := NewExpvarMetrics("rdf2go_test")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", again.Get("parse_total").String())
+
+	expvar.NewInt("rdf2go_test_int")
+	_, err = NewExpvarMetrics("rdf2go_test_int")
+	assert.Error(t, err)
+}
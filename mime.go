@@ -1,12 +1,15 @@
 package rdf2go
 
 import (
+	"mime"
 	"regexp"
+	"strings"
 )
 
 var mimeParser = map[string]string{
 	"text/turtle":               "turtle",
 	"application/ld+json":       "jsonld",
+	"application/x-ndjson":      "ndjsonld",
 	"application/sparql-update": "internal",
 }
 
@@ -20,6 +23,7 @@ var mimeRdfExt = map[string]string{
 	".n3":     "text/n3",
 	".rdf":    "application/rdf+xml",
 	".jsonld": "application/ld+json",
+	".ndjson": "application/x-ndjson",
 }
 
 var rdfExtensions = []string{
@@ -33,3 +37,13 @@ var (
 	serializerMimes = []string{}
 	validMimeType   = regexp.MustCompile(`^\w+/\w+$`)
 )
+
+// mediaType returns the media type of a Content-Type header value, without
+// parameters such as charset
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	}
+	return mt
+}
@@ -0,0 +1,83 @@
+package rdf2go
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MintIRI generates a new fragment IRI under base (or under the graph URI if
+// base is empty), made of a slug of hint, e.g. <doc#alice-smith>. If the IRI
+// is already used in the graph, or was minted before, a counter is appended
+// (doc#alice-smith-2, doc#alice-smith-3, ...).
+func (g *Graph) MintIRI(base string, hint string) Term {
+	if len(base) == 0 {
+		base = g.uri
+	}
+	prefix := defrag(base) + "#" + slugify(hint)
+	g.mintMu.Lock()
+	defer g.mintMu.Unlock()
+	if g.minted[prefix] || g.used(NewResource(prefix)) {
+		return NewResource(g.mintCounter(prefix+"-", 2))
+	}
+	g.markMinted(prefix)
+	return NewResource(prefix)
+}
+
+// mintCounter returns the first IRI made of prefix and a counter, from first
+// on, which was not minted before and is not used in the graph, and marks it
+// as minted. The next counter is kept for each prefix, so that minting many
+// IRIs with the same prefix does not probe the same candidates again. The
+// caller must hold g.mintMu.
+func (g *Graph) mintCounter(prefix string, first int) string {
+	i := max(first, g.mintNext[prefix])
+	uri := prefix + strconv.Itoa(i)
+	for g.minted[uri] || g.used(NewResource(uri)) {
+		i++
+		uri = prefix + strconv.Itoa(i)
+	}
+	g.markMinted(uri)
+	g.mintNext[prefix] = i + 1
+	return uri
+}
+
+// markMinted records a minted IRI. The caller must hold g.mintMu.
+func (g *Graph) markMinted(uri string) {
+	if g.minted == nil {
+		g.minted = make(map[string]bool)
+		g.mintNext = make(map[string]int)
+	}
+	g.minted[uri] = true
+}
+
+// used returns true if term appears anywhere in the graph
+func (g *Graph) used(term Term) bool {
+	return g.One(term, nil, nil) != nil || g.One(nil, term, nil) != nil || g.One(nil, nil, term) != nil
+}
+
+// slugify turns a string into a lowercase identifier made of ASCII letters,
+// digits and dashes
+func slugify(hint string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFKD.String(hint) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// drop the accents left over by the decomposition
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "id"
+	}
+	return b.String()
+}
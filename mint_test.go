@@ -0,0 +1,46 @@
+package rdf2go
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMintIRI(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource(testUri+"#alice-smith"), NewResource("p"), NewLiteral("x"))
+
+	assert.Equal(t, NewResource(testUri+"#alice-smith-2"), g.MintIRI("", "Alice Smith"))
+	assert.Equal(t, NewResource(testUri+"#alice-smith-3"), g.MintIRI("", "  alice_smith! "))
+	assert.Equal(t, NewResource("http://example.org/doc#creme-brulee"), g.MintIRI("http://example.org/doc#x", "Crème Brûlée"))
+	assert.Equal(t, NewResource("http://example.org/doc#id"), g.MintIRI("http://example.org/doc", "???"))
+}
+
+func TestMintIRIConcurrent(t *testing.T) {
+	g := NewGraph(testUri)
+	iris := make([]Term, 50)
+	var wg sync.WaitGroup
+	for i := range iris {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			iris[i] = g.MintIRI("", "item")
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for _, iri := range iris {
+		assert.False(t, seen[iri.RawValue()], iri.String())
+		seen[iri.RawValue()] = true
+	}
+	assert.True(t, seen[testUri+"#item"])
+	assert.True(t, seen[testUri+"#item-50"])
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "hello-world-2", slugify("Hello, World 2"))
+	assert.Equal(t, "a-b", slugify("--a--b--"))
+	assert.Equal(t, "id", slugify(""))
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package rdf2go
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the content of f, on platforms without mmap support
+func mapFile(f *os.File) ([]byte, func() error, error) {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
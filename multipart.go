@@ -0,0 +1,97 @@
+package rdf2go
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+// ParseMultipart parses each part of a multipart body (e.g. an HTML form
+// upload) as an RDF document into a graph of the returned dataset, named
+// after the form field name of the part, or its file name if it has none.
+// The format of a part is taken from its Content-Type, or from the extension
+// of its file name. Each graph has the name resolved against base as URI.
+// Parts that cannot be parsed are skipped, and each of them adds an entry,
+// prefixed with the part name, to the returned error.
+func ParseMultipart(r io.Reader, boundary string, base string) (*Dataset, error) {
+	d := NewDataset()
+	mr := multipart.NewReader(r, boundary)
+	var errs []error
+	for i := 1; ; i++ {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		name := part.FormName()
+		if len(name) == 0 {
+			name = part.FileName()
+		}
+		if len(name) == 0 {
+			name = "part" + strconv.Itoa(i)
+		}
+		err = d.parsePart(part, name, base)
+		part.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return d, errors.Join(errs...)
+}
+
+// ParseMultipartRequest parses the multipart body of an HTTP request with
+// ParseMultipart, using the request URL as base
+func ParseMultipartRequest(req *http.Request) (*Dataset, error) {
+	mt, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	if mt != "multipart/form-data" && mt != "multipart/mixed" || len(params["boundary"]) == 0 {
+		return nil, fmt.Errorf("expected a multipart body, got %s", mt)
+	}
+	base := ""
+	if req.URL != nil {
+		base = req.URL.String()
+		if req.Host != "" && !req.URL.IsAbs() {
+			scheme := "http"
+			if req.TLS != nil {
+				scheme = "https"
+			}
+			base = scheme + "://" + req.Host + req.URL.RequestURI()
+		}
+	}
+	return ParseMultipart(req.Body, params["boundary"], base)
+}
+
+// parsePart parses a part of a multipart body into a new graph of the dataset
+func (d *Dataset) parsePart(part *multipart.Part, name string, base string) error {
+	mime := mediaType(part.Header.Get("Content-Type"))
+	if _, ok := mimeParser[mime]; !ok {
+		mime = mimeRdfExt[path.Ext(part.FileName())]
+	}
+	if _, ok := mimeParser[mime]; !ok {
+		return fmt.Errorf("unsupported format")
+	}
+	uri := name
+	if b, err := url.Parse(base); err == nil && len(base) > 0 {
+		if ref, err := url.Parse(name); err == nil {
+			uri = b.ResolveReference(ref).String()
+		}
+	}
+	g := NewGraph(uri)
+	err := g.Parse(part, mime)
+	if err != nil {
+		return err
+	}
+	d.SetGraph(name, g)
+	return nil
+}
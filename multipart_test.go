@@ -0,0 +1,48 @@
+package rdf2go
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMultipartRequest(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("profile", "card.ttl")
+	fw.Write([]byte(simpleTurtle))
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="data"`)
+	h.Set("Content-Type", "application/ld+json")
+	fw, _ = mw.CreatePart(h)
+	fw.Write([]byte(`{"@id": "http://example.org/a", "http://example.org/p": "x"}`))
+	fw, _ = mw.CreateFormField("comment")
+	fw.Write([]byte("not RDF"))
+	fw, _ = mw.CreateFormFile("broken", "broken.ttl")
+	fw.Write([]byte("<a> <b>"))
+	mw.Close()
+
+	req := httptest.NewRequest("POST", "https://example.org/upload/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	d, err := ParseMultipartRequest(req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "comment: unsupported format")
+	assert.Contains(t, err.Error(), "broken: ")
+	assert.Equal(t, []string{"data", "profile"}, d.Names())
+	assert.Equal(t, 3, d.Len())
+	profile := d.Graph("profile")
+	require.NotNil(t, profile)
+	assert.Equal(t, "https://example.org/upload/profile", profile.URI())
+	assert.NotNil(t, profile.One(NewResource("https://example.org/upload/profile#me"), nil, nil))
+	assert.Nil(t, d.Graph("comment"))
+
+	req = httptest.NewRequest("POST", "https://example.org/upload/", &body)
+	req.Header.Set("Content-Type", "text/turtle")
+	_, err = ParseMultipartRequest(req)
+	assert.Error(t, err)
+}
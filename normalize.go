@@ -0,0 +1,165 @@
+package rdf2go
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions selects the normalizations applied by NormalizeLiterals
+type NormalizeOptions struct {
+	// TrimSpace removes leading and trailing whitespace from literal values
+	TrimSpace bool
+	// NFC applies Unicode Normalization Form C to literal values
+	NFC bool
+	// CanonicalNumbers rewrites xsd numeric and boolean literals to their canonical lexical form
+	CanonicalNumbers bool
+	// CanonicalDates rewrites xsd:dateTime literals having a time zone to UTC
+	CanonicalDates bool
+	// LowercaseLanguage lowercases language tags
+	LowercaseLanguage bool
+}
+
+// NormalizeLiterals rewrites the literals in the graph according to the given
+// options, and returns the number of triples that changed
+func (g *Graph) NormalizeLiterals(opts NormalizeOptions) int {
+	return g.rewrite(func(t *Triple) *Triple {
+		lit, ok := t.Object.(*Literal)
+		if !ok {
+			return t
+		}
+		n := normalizeLiteral(lit, opts)
+		if n.Value == lit.Value && n.Language == lit.Language {
+			return t
+		}
+		return NewTriple(t.Subject, t.Predicate, n)
+	})
+}
+
+func normalizeLiteral(lit *Literal, opts NormalizeOptions) *Literal {
+	value := lit.Value
+	if opts.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if opts.NFC {
+		value = norm.NFC.String(value)
+	}
+	datatype := ""
+	if lit.Datatype != nil {
+		datatype = lit.Datatype.RawValue()
+	}
+	if opts.CanonicalNumbers {
+		value = canonicalNumber(value, datatype)
+	}
+	if opts.CanonicalDates && datatype == nsXSD+"dateTime" {
+		if d, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			value = d.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	language := lit.Language
+	if opts.LowercaseLanguage {
+		language = strings.ToLower(language)
+	}
+	return &Literal{Value: value, Language: language, Datatype: lit.Datatype}
+}
+
+// canonicalNumber returns the canonical lexical form of numeric and boolean
+// values, or the value itself if it is not valid for the datatype
+func canonicalNumber(value string, datatype string) string {
+	switch strings.TrimPrefix(datatype, nsXSD) {
+	case "integer", "int", "long", "short", "byte", "nonNegativeInteger", "positiveInteger",
+		"nonPositiveInteger", "negativeInteger", "unsignedLong", "unsignedInt", "unsignedShort", "unsignedByte":
+		if i, ok := new(big.Int).SetString(value, 10); ok {
+			return i.String()
+		}
+	case "decimal":
+		if r, ok := new(big.Rat).SetString(value); ok && !strings.ContainsAny(value, "eE/") {
+			return canonicalDecimal(r)
+		}
+	case "double", "float":
+		switch value {
+		case "INF", "+INF", "-INF", "NaN":
+		default:
+			// ParseFloat also accepts forms which are not valid xsd:double,
+			// such as "Inf", "nan" or hexadecimal numbers
+			if strings.ContainsAny(value, "xXnN") {
+				return value
+			}
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return canonicalDouble(f)
+		}
+	case "boolean":
+		switch value {
+		case "1":
+			return "true"
+		case "0":
+			return "false"
+		}
+	}
+	return value
+}
+
+// canonicalDecimal formats a rational with a finite decimal representation
+func canonicalDecimal(r *big.Rat) string {
+	digits, _ := fractionDigits(r.Denom())
+	s := r.FloatString(digits)
+	if digits == 0 {
+		s += ".0"
+	}
+	return s
+}
+
+// fractionDigits returns the number of fractional digits of the decimals
+// having the given denominator, in lowest terms, which is the larger of
+// the exponents of 2 and 5 in the denominator. It returns false when the
+// denominator has other prime factors, as the decimal is then infinite.
+func fractionDigits(denom *big.Int) (int, bool) {
+	twos := int(denom.TrailingZeroBits())
+	d := new(big.Int).Rsh(denom, uint(twos))
+	// divide by 5, 25, 625, ... from the largest power not above d, so
+	// that large exponents only take a few divisions
+	powers := []*big.Int{big.NewInt(5)}
+	for {
+		next := new(big.Int).Mul(powers[len(powers)-1], powers[len(powers)-1])
+		if next.Cmp(d) > 0 {
+			break
+		}
+		powers = append(powers, next)
+	}
+	fives := 0
+	q, m := new(big.Int), new(big.Int)
+	for i := len(powers) - 1; i >= 0; i-- {
+		for {
+			q.QuoRem(d, powers[i], m)
+			if m.Sign() != 0 {
+				break
+			}
+			d, q = q, d
+			fives += 1 << i
+		}
+	}
+	return max(twos, fives), d.Cmp(big.NewInt(1)) == 0
+}
+
+func canonicalDouble(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	s := strconv.FormatFloat(f, 'E', -1, 64)
+	mantissa, exp, _ := strings.Cut(s, "E")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	e, _ := strconv.Atoi(exp)
+	return mantissa + "E" + strconv.Itoa(e)
+}
@@ -0,0 +1,81 @@
+package rdf2go
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLiterals(t *testing.T) {
+	g := NewGraph(testUri)
+	s, p := NewResource("a"), NewResource("b")
+	g.AddTriple(s, p, NewLiteralWithLanguage("  cafe\u0301 ", "EN-gb"))
+	g.AddTriple(s, p, NewLiteralWithDatatype("+007", NewResource(nsXSD+"integer")))
+	g.AddTriple(s, p, NewLiteralWithDatatype("01.500", NewResource(nsXSD+"decimal")))
+	g.AddTriple(s, p, NewLiteralWithDatatype("100", NewResource(nsXSD+"double")))
+	g.AddTriple(s, p, NewLiteralWithDatatype("1", NewResource(nsXSD+"boolean")))
+	g.AddTriple(s, p, NewLiteralWithDatatype("2020-01-01T10:00:00+02:00", NewResource(nsXSD+"dateTime")))
+	g.AddTriple(s, p, NewLiteralWithDatatype("abc", NewResource(nsXSD+"integer")))
+	g.AddTriple(s, p, NewResource("c"))
+
+	n := g.NormalizeLiterals(NormalizeOptions{
+		TrimSpace:         true,
+		NFC:               true,
+		CanonicalNumbers:  true,
+		CanonicalDates:    true,
+		LowercaseLanguage: true,
+	})
+	assert.Equal(t, 6, n)
+	assert.Equal(t, 8, g.Len())
+	assert.NotNil(t, g.One(s, p, NewLiteralWithLanguage("café", "en-gb")))
+	assert.NotNil(t, g.One(s, p, NewLiteralWithDatatype("7", NewResource(nsXSD+"integer"))))
+	assert.NotNil(t, g.One(s, p, NewLiteralWithDatatype("1.5", NewResource(nsXSD+"decimal"))))
+	assert.NotNil(t, g.One(s, p, NewLiteralWithDatatype("1.0E2", NewResource(nsXSD+"double"))))
+	assert.NotNil(t, g.One(s, p, NewLiteralWithDatatype("true", NewResource(nsXSD+"boolean"))))
+	assert.NotNil(t, g.One(s, p, NewLiteralWithDatatype("2020-01-01T08:00:00Z", NewResource(nsXSD+"dateTime"))))
+	assert.NotNil(t, g.One(s, p, NewLiteralWithDatatype("abc", NewResource(nsXSD+"integer"))))
+}
+
+func TestCanonicalDecimal(t *testing.T) {
+	for in, out := range map[string]string{
+		"10":        "10.0",
+		"-2.50":     "-2.5",
+		"0.0625":    "0.0625",
+		"1200.0008": "1200.0008",
+		"5e-1":      "0.5",
+	} {
+		r, _ := new(big.Rat).SetString(in)
+		assert.Equal(t, out, canonicalDecimal(r), in)
+	}
+
+	// the digits are counted in a few divisions, even for long values
+	long := "0." + strings.Repeat("7", 8000)
+	started := time.Now()
+	assert.Equal(t, long, canonicalNumber(long, nsXSD+"decimal"))
+	assert.Less(t, time.Since(started), time.Second)
+
+	_, ok := fractionDigits(big.NewInt(3 * 5 * 5))
+	assert.False(t, ok)
+	digits, ok := fractionDigits(new(big.Int).Mul(big.NewInt(8), new(big.Int).Exp(big.NewInt(5), big.NewInt(70), nil)))
+	assert.True(t, ok)
+	assert.Equal(t, 70, digits)
+}
+
+func TestCanonicalNumber(t *testing.T) {
+	assert.Equal(t, "-3", canonicalNumber("-003", nsXSD+"int"))
+	assert.Equal(t, "0.0", canonicalNumber("0", nsXSD+"decimal"))
+	assert.Equal(t, "-0.125", canonicalNumber("-.125", nsXSD+"decimal"))
+	assert.Equal(t, "1.0E-3", canonicalNumber("0.001", nsXSD+"double"))
+	assert.Equal(t, "false", canonicalNumber("0", nsXSD+"boolean"))
+	assert.Equal(t, "x", canonicalNumber("x", nsXSD+"double"))
+	assert.Equal(t, "INF", canonicalNumber("INF", nsXSD+"double"))
+	assert.Equal(t, "INF", canonicalNumber("+INF", nsXSD+"float"))
+	assert.Equal(t, "-INF", canonicalNumber("-INF", nsXSD+"double"))
+	assert.Equal(t, "NaN", canonicalNumber("NaN", nsXSD+"double"))
+	assert.Equal(t, "Infinity", canonicalNumber("Infinity", nsXSD+"double"))
+	assert.Equal(t, "0x1p-2", canonicalNumber("0x1p-2", nsXSD+"double"))
+	assert.Equal(t, "01", canonicalNumber("01", ""))
+}
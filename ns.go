@@ -0,0 +1,62 @@
+package rdf2go
+
+// Namespaces of the vocabularies used by the helpers in this package
+const (
+	nsRDF   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsXSD   = "http://www.w3.org/2001/XMLSchema#"
+	nsDCAT  = "http://www.w3.org/ns/dcat#"
+	nsDCT   = "http://purl.org/dc/terms/"
+	nsVOID  = "http://rdfs.org/ns/void#"
+	nsPROV  = "http://www.w3.org/ns/prov#"
+	nsRDFS  = "http://www.w3.org/2000/01/rdf-schema#"
+	nsOWL   = "http://www.w3.org/2002/07/owl#"
+	nsFOAF  = "http://xmlns.com/foaf/0.1/"
+	nsSDO   = "http://schema.org/"
+	nsHydra = "http://www.w3.org/ns/hydra/core#"
+	nsLDP   = "http://www.w3.org/ns/ldp#"
+	nsVCard = "http://www.w3.org/2006/vcard/ns#"
+	nsPIM   = "http://www.w3.org/ns/pim/space#"
+	nsSolid = "http://www.w3.org/ns/solid/terms#"
+	nsACL   = "http://www.w3.org/ns/auth/acl#"
+	nsACP   = "http://www.w3.org/ns/solid/acp#"
+)
+
+// commonPrefixes maps the prefixes used when shortening IRIs for display to
+// their namespaces
+var commonPrefixes = map[string]string{
+	"rdf":    nsRDF,
+	"rdfs":   nsRDFS,
+	"xsd":    nsXSD,
+	"owl":    nsOWL,
+	"dcat":   nsDCAT,
+	"dct":    nsDCT,
+	"void":   nsVOID,
+	"prov":   nsPROV,
+	"foaf":   nsFOAF,
+	"schema": nsSDO,
+	"hydra":  nsHydra,
+	"ldp":    nsLDP,
+	"vcard":  nsVCard,
+	"pim":    nsPIM,
+	"solid":  nsSolid,
+	"acl":    nsACL,
+	"acp":    nsACP,
+}
+
+// shortenIRI returns the prefixed name of an IRI using the longest matching
+// namespace, or the IRI itself if none matches
+func shortenIRI(iri string, prefixes map[string]string) string {
+	best, found := "", false
+	for prefix, ns := range prefixes {
+		if len(ns) > len(iri) || iri[:len(ns)] != ns {
+			continue
+		}
+		if !found || len(ns) > len(prefixes[best]) || len(ns) == len(prefixes[best]) && prefix < best {
+			best, found = prefix, true
+		}
+	}
+	if !found {
+		return iri
+	}
+	return best + ":" + iri[len(prefixes[best]):]
+}
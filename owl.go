@@ -0,0 +1,196 @@
+package rdf2go
+
+import "sort"
+
+// OWLOntology describes the classes and properties defined in a graph
+type OWLOntology struct {
+	URI        string
+	Classes    []*OWLClass
+	Properties []*OWLProperty
+}
+
+// OWLClass describes an owl:Class (or rdfs:Class)
+type OWLClass struct {
+	URI        string
+	Label      string
+	Comment    string
+	SubClassOf []string
+	Deprecated bool
+}
+
+// OWLPropertyKind is the kind of an OWL property
+type OWLPropertyKind int
+
+const (
+	// OWLRDFProperty is an rdf:Property that is not declared as one of the OWL
+	// kinds
+	OWLRDFProperty OWLPropertyKind = iota
+	// OWLObjectProperty is an owl:ObjectProperty, linking two resources
+	OWLObjectProperty
+	// OWLDatatypeProperty is an owl:DatatypeProperty, linking a resource to a
+	// literal
+	OWLDatatypeProperty
+	// OWLAnnotationProperty is an owl:AnnotationProperty
+	OWLAnnotationProperty
+)
+
+// OWLProperty describes a property and its characteristics
+type OWLProperty struct {
+	URI               string
+	Kind              OWLPropertyKind
+	Label             string
+	Comment           string
+	Domain            []string
+	Range             []string
+	SubPropertyOf     []string
+	InverseOf         string
+	Functional        bool
+	InverseFunctional bool
+	Transitive        bool
+	Symmetric         bool
+	Deprecated        bool
+}
+
+var owlPropertyKinds = map[string]OWLPropertyKind{
+	nsRDF + "Property":           OWLRDFProperty,
+	nsOWL + "ObjectProperty":     OWLObjectProperty,
+	nsOWL + "DatatypeProperty":   OWLDatatypeProperty,
+	nsOWL + "AnnotationProperty": OWLAnnotationProperty,
+}
+
+// OWLOntology reads the classes and properties defined in the graph, sorted
+// by URI. Anonymous classes, such as restrictions, are left out.
+func (g *Graph) OWLOntology() *OWLOntology {
+	o := &OWLOntology{}
+	if t := g.One(nil, NewResource(nsRDF+"type"), NewResource(nsOWL+"Ontology")); t != nil {
+		o.URI = t.Subject.RawValue()
+	}
+	rdfType := NewResource(nsRDF + "type")
+	classes := make(map[string]bool)
+	for _, class := range []string{nsOWL + "Class", nsRDFS + "Class"} {
+		for _, t := range g.All(nil, rdfType, NewResource(class)) {
+			if r, ok := t.Subject.(*Resource); ok && !classes[r.URI] {
+				classes[r.URI] = true
+				o.Classes = append(o.Classes, g.owlClass(r))
+			}
+		}
+	}
+	properties := make(map[string]bool)
+	for kind := range owlPropertyKinds {
+		for _, t := range g.All(nil, rdfType, NewResource(kind)) {
+			if r, ok := t.Subject.(*Resource); ok && !properties[r.URI] {
+				properties[r.URI] = true
+				o.Properties = append(o.Properties, g.owlProperty(r))
+			}
+		}
+	}
+	sort.Slice(o.Classes, func(i, j int) bool { return o.Classes[i].URI < o.Classes[j].URI })
+	sort.Slice(o.Properties, func(i, j int) bool { return o.Properties[i].URI < o.Properties[j].URI })
+	return o
+}
+
+func (g *Graph) owlClass(s Term) *OWLClass {
+	return &OWLClass{
+		URI:        s.RawValue(),
+		Label:      g.value(s, nsRDFS+"label"),
+		Comment:    g.value(s, nsRDFS+"comment"),
+		SubClassOf: g.resourceValues(s, nsRDFS+"subClassOf"),
+		Deprecated: g.value(s, nsOWL+"deprecated") == "true",
+	}
+}
+
+func (g *Graph) owlProperty(s Term) *OWLProperty {
+	p := &OWLProperty{
+		URI:           s.RawValue(),
+		Label:         g.value(s, nsRDFS+"label"),
+		Comment:       g.value(s, nsRDFS+"comment"),
+		Domain:        g.resourceValues(s, nsRDFS+"domain"),
+		Range:         g.resourceValues(s, nsRDFS+"range"),
+		SubPropertyOf: g.resourceValues(s, nsRDFS+"subPropertyOf"),
+		InverseOf:     g.value(s, nsOWL+"inverseOf"),
+		Deprecated:    g.value(s, nsOWL+"deprecated") == "true",
+	}
+	for _, t := range g.All(s, NewResource(nsRDF+"type"), nil) {
+		switch t.Object.RawValue() {
+		case nsOWL + "FunctionalProperty":
+			p.Functional = true
+		case nsOWL + "InverseFunctionalProperty":
+			p.InverseFunctional = true
+		case nsOWL + "TransitiveProperty":
+			p.Transitive = true
+		case nsOWL + "SymmetricProperty":
+			p.Symmetric = true
+		default:
+			if kind, ok := owlPropertyKinds[t.Object.RawValue()]; ok && kind > p.Kind {
+				p.Kind = kind
+			}
+		}
+	}
+	return p
+}
+
+// resourceValues returns the IRIs of the objects of s and p, sorted. Blank
+// nodes are left out.
+func (g *Graph) resourceValues(s Term, p string) []string {
+	var values []string
+	for _, t := range g.All(s, NewResource(p), nil) {
+		if r, ok := t.Object.(*Resource); ok {
+			values = append(values, r.URI)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Class returns the class with the given URI, or nil if it is not defined
+func (o *OWLOntology) Class(uri string) *OWLClass {
+	for _, c := range o.Classes {
+		if c.URI == uri {
+			return c
+		}
+	}
+	return nil
+}
+
+// Property returns the property with the given URI, or nil if it is not
+// defined
+func (o *OWLOntology) Property(uri string) *OWLProperty {
+	for _, p := range o.Properties {
+		if p.URI == uri {
+			return p
+		}
+	}
+	return nil
+}
+
+// SubClasses returns the URIs of the classes directly declared as sub
+// classes of the given class
+func (o *OWLOntology) SubClasses(uri string) []string {
+	var subs []string
+	for _, c := range o.Classes {
+		for _, super := range c.SubClassOf {
+			if super == uri {
+				subs = append(subs, c.URI)
+			}
+		}
+	}
+	return subs
+}
+
+// RootClasses returns the URIs of the classes that are not a sub class of
+// another class of the ontology, i.e. the roots of the class hierarchy
+func (o *OWLOntology) RootClasses() []string {
+	var roots []string
+	for _, c := range o.Classes {
+		root := true
+		for _, super := range c.SubClassOf {
+			if o.Class(super) != nil && super != c.URI {
+				root = false
+			}
+		}
+		if root {
+			roots = append(roots, c.URI)
+		}
+	}
+	return roots
+}
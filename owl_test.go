@@ -0,0 +1,50 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOWLOntology(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`<http://example.org/ns> a <http://www.w3.org/2002/07/owl#Ontology> .
+`+testOntology+`
+ex:Person rdfs:label "Person" ; rdfs:subClassOf [ a owl:Restriction ] .
+ex:knows a owl:SymmetricProperty ; owl:inverseOf ex:knows .
+ex:id a owl:DatatypeProperty, owl:FunctionalProperty .
+ex:parentOf a owl:ObjectProperty, owl:InverseFunctionalProperty, owl:TransitiveProperty .
+ex:note a owl:AnnotationProperty ; rdfs:comment "A note" .
+ex:old a <http://www.w3.org/1999/02/22-rdf-syntax-ns#Property> .`, "text/turtle"))
+
+	o := g.OWLOntology()
+	assert.Equal(t, "http://example.org/ns", o.URI)
+	assert.Len(t, o.Classes, 3)
+	person := o.Class("http://example.org/ns#Person")
+	require.NotNil(t, person)
+	assert.Equal(t, "Person", person.Label)
+	assert.Equal(t, []string{"http://example.org/ns#Agent"}, person.SubClassOf)
+	assert.Equal(t, []string{"http://example.org/ns#Agent", "http://example.org/ns#Document"}, o.RootClasses())
+	assert.Equal(t, []string{"http://example.org/ns#Person"}, o.SubClasses("http://example.org/ns#Agent"))
+
+	names := []string{}
+	for _, p := range o.Properties {
+		names = append(names, p.URI[len("http://example.org/ns#"):])
+	}
+	assert.Equal(t, []string{"age", "id", "knows", "name", "nick", "note", "old", "parentOf"}, names)
+	knows := o.Property("http://example.org/ns#knows")
+	assert.Equal(t, OWLObjectProperty, knows.Kind)
+	assert.True(t, knows.Symmetric)
+	assert.Equal(t, "http://example.org/ns#knows", knows.InverseOf)
+	assert.Equal(t, []string{"http://example.org/ns#Agent"}, knows.Domain)
+	assert.True(t, o.Property("http://example.org/ns#id").Functional)
+	parent := o.Property("http://example.org/ns#parentOf")
+	assert.True(t, parent.InverseFunctional && parent.Transitive)
+	assert.Equal(t, OWLAnnotationProperty, o.Property("http://example.org/ns#note").Kind)
+	assert.Equal(t, "A note", o.Property("http://example.org/ns#note").Comment)
+	assert.Equal(t, OWLRDFProperty, o.Property("http://example.org/ns#old").Kind)
+	assert.Equal(t, OWLDatatypeProperty, o.Property("http://example.org/ns#age").Kind)
+	assert.True(t, o.Property("http://example.org/ns#nick").Deprecated)
+	assert.Nil(t, o.Property("http://example.org/ns#missing"))
+}
@@ -0,0 +1,109 @@
+package rdf2go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultMaxPages is the number of pages LoadPaged loads at most when no
+// limit is given
+var DefaultMaxPages = 1000
+
+// LoadPaged loads a paged collection starting at uri, following the links to
+// the next page until there are none left, and merges all the pages into the
+// graph. Next pages are found through hydra:next (or hydra:nextPage) triples,
+// or through a Link header with rel="next" as used by LDP Paging. At most
+// maxPages pages are loaded when maxPages is greater than zero. Otherwise
+// loading stops with an error after DefaultMaxPages pages, and when a page
+// links back to one that was already loaded. It returns the number of pages
+// that were loaded. The blank nodes of each page are kept apart.
+func (g *Graph) LoadPaged(uri string, maxPages int) (int, error) {
+	limit := maxPages
+	if limit <= 0 {
+		limit = DefaultMaxPages
+	}
+	visited := make(map[string]bool)
+	pages := 0
+	next := defrag(uri)
+	for len(next) > 0 {
+		if pages >= limit {
+			if maxPages > 0 {
+				break
+			}
+			return pages, fmt.Errorf("paging stopped after %d pages at %s", pages, next)
+		}
+		if visited[next] {
+			return pages, fmt.Errorf("paging loop detected at %s", next)
+		}
+		visited[next] = true
+
+		page := NewGraph(next)
+		page.httpClient = g.httpClient
+		page.limits = g.limits
+		header, err := page.load(context.Background(), next)
+		if err != nil {
+			return pages, err
+		}
+		pages++
+		started := time.Now()
+		g.mergeDocument(page)
+		g.recordActivity("merge", page.Term(), started)
+		next = nextPage(page, header)
+	}
+	return pages, nil
+}
+
+// nextPage returns the URI of the page following the given one, if any
+func nextPage(page *Graph, header http.Header) string {
+	for _, p := range []string{nsHydra + "next", nsHydra + "nextPage"} {
+		t := page.One(nil, NewResource(p), nil)
+		if t != nil {
+			if r, ok := t.Object.(*Resource); ok {
+				return defrag(r.URI)
+			}
+		}
+	}
+	link := linkRel(header, "next")
+	if len(link) == 0 {
+		return ""
+	}
+	base, err := url.Parse(page.URI())
+	if err != nil {
+		return link
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return defrag(base.ResolveReference(ref).String())
+}
+
+// linkRel returns the target of the first link with the given relation found
+// in the Link headers
+func linkRel(header http.Header, rel string) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || strings.ToLower(strings.TrimSpace(k)) != "rel" {
+					continue
+				}
+				for _, r := range strings.Fields(strings.Trim(v, "\"")) {
+					if r == rel {
+						return debrack(target)
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
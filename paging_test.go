@@ -0,0 +1,57 @@
+package rdf2go
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPaged(t *testing.T) {
+	g := NewGraph(testUri)
+	pages, err := g.LoadPaged(testServer.URL+"/page/1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, 4, g.Len())
+	assert.NotNil(t, g.One(NewResource(testServer.URL+"/page/3#m3"), nil, nil))
+}
+
+func TestLoadPagedLimit(t *testing.T) {
+	g := NewGraph(testUri)
+	pages, err := g.LoadPaged(testServer.URL+"/page/1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, 3, g.Len())
+}
+
+func TestLoadPagedErrors(t *testing.T) {
+	g := NewGraph(testUri)
+	pages, err := g.LoadPaged(testServer.URL+"/page/loop", 0)
+	assert.Error(t, err)
+	assert.Equal(t, 1, pages)
+
+	pages, err = g.LoadPaged(testServer.URL+"/page/missing", 0)
+	assert.Error(t, err)
+	assert.Equal(t, 0, pages)
+}
+
+func TestLoadPagedDefaultLimit(t *testing.T) {
+	defer func(n int) { DefaultMaxPages = n }(DefaultMaxPages)
+	DefaultMaxPages = 5
+	g := NewGraph(testUri)
+	pages, err := g.LoadPaged(testServer.URL+"/page/endless", 0)
+	assert.Error(t, err)
+	assert.Equal(t, 5, pages)
+
+	pages, err = g.LoadPaged(testServer.URL+"/page/endless", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, pages)
+}
+
+func TestLinkRel(t *testing.T) {
+	h := http.Header{}
+	h.Add("Link", "<a>; rel=\"first\", <b>; rel=\"prev next\"")
+	assert.Equal(t, "b", linkRel(h, "next"))
+	assert.Equal(t, "a", linkRel(h, "first"))
+	assert.Equal(t, "", linkRel(h, "last"))
+}
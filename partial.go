@@ -0,0 +1,35 @@
+package rdf2go
+
+// PartialParseError is returned by Parse, LoadURI and the other methods
+// parsing data into the graph when SetKeepPartial is enabled and the data
+// could only be parsed up to an error, e.g. a Turtle document truncated by
+// a network error. The triples parsed before the error were added to the
+// graph.
+type PartialParseError struct {
+	// Parsed is the number of triples parsed before the error
+	Parsed int
+	// Added is the number of those triples added to the graph, which is
+	// lower than Parsed when some of them were already in it
+	Added int
+	// Err is the error that stopped the parser, usually a *ParseError
+	Err error
+}
+
+func (e *PartialParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PartialParseError) Unwrap() error {
+	return e.Err
+}
+
+// SetKeepPartial sets what happens to the triples parsed before an error.
+// By default they are dropped: the graph is left as it was before the call,
+// and the error of the parser is returned as is. When keep is true, they
+// are added to the graph, and the error is a *PartialParseError reporting
+// how many were added, unless no triple was parsed before the error.
+// Documents loaded through a GraphCache are always dropped on error, so that
+// incomplete documents are not cached.
+func (g *Graph) SetKeepPartial(keep bool) {
+	g.keepPartial = keep
+}
@@ -0,0 +1,63 @@
+package rdf2go
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const truncatedTurtle = "<http://a> <http://b> <http://c> .\n<http://a> <http://b> <http://d> .\n<http://a> <http://b> "
+
+func TestPartialParseDropped(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("http://x"), NewResource("http://y"), NewResource("http://z"))
+	err := g.Parse(strings.NewReader(truncatedTurtle), "text/turtle")
+	var perr *ParseError
+	assert.True(t, errors.As(err, &perr))
+	var partial *PartialParseError
+	assert.False(t, errors.As(err, &partial))
+	assert.Equal(t, 1, g.Len())
+}
+
+func TestPartialParseKept(t *testing.T) {
+	g := NewGraph(testUri)
+	g.SetKeepPartial(true)
+	g.AddTriple(NewResource("http://a"), NewResource("http://b"), NewResource("http://c"))
+	err := g.Parse(strings.NewReader(truncatedTurtle), "text/turtle")
+	var partial *PartialParseError
+	require.True(t, errors.As(err, &partial))
+	assert.Equal(t, 2, partial.Parsed)
+	assert.Equal(t, 1, partial.Added)
+	var perr *ParseError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 3, perr.Line)
+	assert.Equal(t, 2, g.Len())
+
+	// errors before the first triple are returned as is
+	err = g.Parse(strings.NewReader("<http://a> <http://b> "), "text/turtle")
+	assert.False(t, errors.As(err, &partial))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/turtle")
+		w.Write([]byte(truncatedTurtle))
+	}))
+	defer ts.Close()
+	g = NewGraph(ts.URL)
+	g.SetKeepPartial(true)
+	err = g.LoadURI(ts.URL)
+	require.True(t, errors.As(err, &partial))
+	assert.Equal(t, 2, partial.Added)
+	assert.Equal(t, 2, g.Len())
+
+	g = NewGraph(testUri)
+	g.SetKeepPartial(true)
+	mime, err := g.ParseSeeker(strings.NewReader(truncatedTurtle))
+	assert.Equal(t, "text/turtle", mime)
+	assert.True(t, errors.As(err, &partial))
+	assert.Equal(t, 2, g.Len())
+}
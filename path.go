@@ -0,0 +1,68 @@
+package rdf2go
+
+// ShortestPath returns the triples of one of the shortest paths from one
+// node to another, found with a breadth-first search following the given
+// predicates (or all predicates if none are given). Triples are followed
+// from subject to object, and also from object to subject if undirected is
+// set. It returns nil if there is no path, and an empty path if from and to
+// are the same node.
+func (g *Graph) ShortestPath(from Term, to Term, predicates []Term, undirected ...bool) []*Triple {
+	if from.Equal(to) {
+		return []*Triple{}
+	}
+	both := len(undirected) > 0 && undirected[0]
+	if len(predicates) == 0 {
+		predicates = []Term{nil}
+	}
+	// via maps each reached node to the triple it was reached through
+	via := map[string]*Triple{encodeTerm(from): nil}
+	queue := []Term{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, p := range predicates {
+			for _, t := range g.All(node, p, nil) {
+				if visit(via, &queue, t, t.Object, to) {
+					return pathTo(via, from, to)
+				}
+			}
+			if !both {
+				continue
+			}
+			for _, t := range g.All(nil, p, node) {
+				if visit(via, &queue, t, t.Subject, to) {
+					return pathTo(via, from, to)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// visit records that next was reached through t, unless it was already
+// reached, and returns whether next is the node searched for
+func visit(via map[string]*Triple, queue *[]Term, t *Triple, next Term, to Term) bool {
+	key := encodeTerm(next)
+	if _, ok := via[key]; ok {
+		return false
+	}
+	via[key] = t
+	*queue = append(*queue, next)
+	return next.Equal(to)
+}
+
+// pathTo walks back from to along the triples recorded in via
+func pathTo(via map[string]*Triple, from Term, to Term) []*Triple {
+	var path []*Triple
+	node := to
+	for !node.Equal(from) {
+		t := via[encodeTerm(node)]
+		path = append([]*Triple{t}, path...)
+		if t.Object.Equal(node) {
+			node = t.Subject
+		} else {
+			node = t.Object
+		}
+	}
+	return path
+}
@@ -0,0 +1,37 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortestPath(t *testing.T) {
+	g := NewGraph("")
+	require.NoError(t, g.ParseString(`@prefix ex: <http://example.org/> .
+ex:alice ex:knows ex:bob .
+ex:bob ex:knows ex:carol .
+ex:carol ex:knows ex:dave .
+ex:alice ex:worksFor ex:acme .
+ex:dave ex:worksFor ex:acme .
+ex:erin ex:knows ex:alice .`, "text/turtle"))
+	ex := func(name string) Term { return NewResource("http://example.org/" + name) }
+	knows := ex("knows")
+
+	path := g.ShortestPath(ex("alice"), ex("dave"), []Term{knows})
+	require.Len(t, path, 3)
+	assert.Equal(t, ex("alice"), path[0].Subject)
+	assert.Equal(t, ex("dave"), path[2].Object)
+
+	// through the employer, against the direction of worksFor
+	path = g.ShortestPath(ex("alice"), ex("dave"), nil, true)
+	require.Len(t, path, 2)
+	assert.Equal(t, ex("acme"), path[0].Object)
+	assert.Equal(t, ex("dave"), path[1].Subject)
+
+	assert.Nil(t, g.ShortestPath(ex("dave"), ex("alice"), []Term{knows}))
+	assert.Len(t, g.ShortestPath(ex("dave"), ex("erin"), []Term{knows}, true), 4)
+	assert.Empty(t, g.ShortestPath(ex("bob"), ex("bob"), nil))
+	assert.NotNil(t, g.ShortestPath(ex("bob"), ex("bob"), nil))
+}
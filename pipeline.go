@@ -0,0 +1,80 @@
+package rdf2go
+
+// TripleSource is implemented by anything providing a stream of triples,
+// such as a Graph, a FrozenGraph or a Store
+type TripleSource interface {
+	IterTriples() chan *Triple
+}
+
+// Pipeline applies a sequence of filtering and transformation stages to a
+// stream of triples. Triples go through all the stages one at a time, so no
+// intermediate graph is built. Stages never modify the source triples.
+type Pipeline struct {
+	src    TripleSource
+	stages []func(*Triple) *Triple
+}
+
+// NewPipeline creates a pipeline reading triples from src
+func NewPipeline(src TripleSource) *Pipeline {
+	return &Pipeline{src: src}
+}
+
+// Filter drops the triples for which keep returns false
+func (p *Pipeline) Filter(keep func(*Triple) bool) *Pipeline {
+	p.stages = append(p.stages, func(t *Triple) *Triple {
+		if keep(t) {
+			return t
+		}
+		return nil
+	})
+	return p
+}
+
+// Map replaces each triple by the one returned by fn. Returning nil drops the triple.
+func (p *Pipeline) Map(fn func(*Triple) *Triple) *Pipeline {
+	p.stages = append(p.stages, fn)
+	return p
+}
+
+// MapTerms replaces the subject, predicate and object of each triple by the
+// terms returned by fn. Returning nil for any of them drops the triple.
+func (p *Pipeline) MapTerms(fn func(Term) Term) *Pipeline {
+	return p.Map(func(t *Triple) *Triple {
+		s, pred, o := fn(t.Subject), fn(t.Predicate), fn(t.Object)
+		if s == nil || pred == nil || o == nil {
+			return nil
+		}
+		if s == t.Subject && pred == t.Predicate && o == t.Object {
+			return t
+		}
+		return NewTriple(s, pred, o)
+	})
+}
+
+// Each runs the pipeline, calling fn for every triple coming out of it
+func (p *Pipeline) Each(fn func(*Triple)) {
+	for t := range p.src.IterTriples() {
+		for _, stage := range p.stages {
+			t = stage(t)
+			if t == nil {
+				break
+			}
+		}
+		if t != nil {
+			fn(t)
+		}
+	}
+}
+
+// Sink runs the pipeline, adding the resulting triples to dst, and returns
+// the number of triples added. Triples already in dst, including those
+// added earlier by the pipeline itself, are not counted.
+func (p *Pipeline) Sink(dst *Graph) int {
+	n := 0
+	p.Each(func(t *Triple) {
+		if _, added := dst.AddTripleIfAbsent(t.Subject, t.Predicate, t.Object); added {
+			n++
+		}
+	})
+	return n
+}
@@ -0,0 +1,75 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	src := NewGraph(testUri)
+	src.AddTriple(NewResource("a"), NewResource("http://schema.org/name"), NewLiteralWithLanguage("A", "en"))
+	src.AddTriple(NewResource("a"), NewResource("http://schema.org/name"), NewLiteralWithLanguage("A", "fr"))
+	src.AddTriple(NewResource("a"), NewResource("http://schema.org/knows"), NewResource("b"))
+
+	dst := NewGraph(testUri)
+	n := NewPipeline(src).
+		Filter(func(t *Triple) bool {
+			l, ok := t.Object.(*Literal)
+			return !ok || l.Language != "fr"
+		}).
+		MapTerms(func(term Term) Term {
+			if r, ok := term.(*Resource); ok && r.URI == "http://schema.org/name" {
+				return NewResource("https://schema.org/name")
+			}
+			return term
+		}).
+		Sink(dst)
+
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 2, dst.Len())
+	assert.NotNil(t, dst.One(nil, NewResource("https://schema.org/name"), NewLiteralWithLanguage("A", "en")))
+	assert.NotNil(t, dst.One(nil, NewResource("http://schema.org/knows"), nil))
+	// the source is left untouched
+	assert.Equal(t, 3, src.Len())
+	assert.Equal(t, 2, len(src.All(nil, NewResource("http://schema.org/name"), nil)))
+}
+
+func TestPipelineMapDrop(t *testing.T) {
+	src := NewGraph(testUri)
+	src.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	src.AddTriple(NewResource("a"), NewResource("b"), NewBlankNode("n1"))
+
+	count := 0
+	NewPipeline(src.Freeze()).
+		MapTerms(func(term Term) Term {
+			if _, ok := term.(*BlankNode); ok {
+				return nil
+			}
+			return term
+		}).
+		Map(func(t *Triple) *Triple { return t }).
+		Each(func(t *Triple) { count++ })
+	assert.Equal(t, 1, count)
+}
+
+func TestPipelineSinkDuplicates(t *testing.T) {
+	src := NewGraph(testUri)
+	src.AddTriple(NewResource("a"), NewResource("b"), NewLiteral("1"))
+	src.AddTriple(NewResource("a"), NewResource("b"), NewLiteral("2"))
+	src.AddTriple(NewResource("a"), NewResource("c"), NewLiteral("3"))
+	dst := NewGraph(testUri)
+	dst.AddTriple(NewResource("a"), NewResource("c"), NewLiteral("x"))
+
+	// both b triples become the same one, and the c one is already in dst
+	n := NewPipeline(src).
+		MapTerms(func(term Term) Term {
+			if _, ok := term.(*Literal); ok {
+				return NewLiteral("x")
+			}
+			return term
+		}).
+		Sink(dst)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 2, dst.Len())
+}
@@ -0,0 +1,92 @@
+package rdf2go
+
+import (
+	"bytes"
+	"sync"
+)
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// maxPooledBuffer is the capacity above which buffers are not returned to
+// the pool, so that parsing one large document does not keep its buffer
+// alive indefinitely
+const maxPooledBuffer = 64 << 10
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// size of the chunks of triples allocated by a tripleArena
+const arenaChunkSize = 1024
+
+// tripleArena allocates triples in contiguous chunks, so that loading many
+// triples results in a few large allocations instead of one per triple. Note
+// that a whole chunk stays in memory as long as any of its triples is
+// referenced, e.g. after most of the triples were removed from a graph.
+type tripleArena struct {
+	chunk []Triple
+}
+
+func (a *tripleArena) newTriple(s Term, p Term, o Term) *Triple {
+	if len(a.chunk) == cap(a.chunk) {
+		a.chunk = make([]Triple, 0, arenaChunkSize)
+	}
+	a.chunk = append(a.chunk, Triple{Subject: s, Predicate: p, Object: o})
+	return &a.chunk[len(a.chunk)-1]
+}
+
+// tripleBuilder collects parsed triples, sharing identical resources
+// between triples and allocating triples from an arena
+type tripleBuilder struct {
+	triples   []*Triple
+	arena     tripleArena
+	resources map[string]Term
+}
+
+func newTripleBuilder() *tripleBuilder {
+	return &tripleBuilder{
+		resources: make(map[string]Term),
+	}
+}
+
+// add adds a triple made of parsed terms, skipping triples containing terms
+// that could not be converted
+func (b *tripleBuilder) add(s Term, p Term, o Term) {
+	if s == nil || p == nil || o == nil {
+		return
+	}
+	b.triples = append(b.triples, b.arena.newTriple(b.intern(s), b.intern(p), b.intern(o)))
+}
+
+// reset returns the triples collected so far and forgets them, along with
+// the terms they share, so that a builder streaming triples does not grow
+func (b *tripleBuilder) reset() []*Triple {
+	triples := b.triples
+	b.triples = nil
+	clear(b.resources)
+	return triples
+}
+
+func (b *tripleBuilder) intern(t Term) Term {
+	r, ok := t.(*Resource)
+	if !ok {
+		return t
+	}
+	if shared, ok := b.resources[r.URI]; ok {
+		return shared
+	}
+	b.resources[r.URI] = t
+	return t
+}
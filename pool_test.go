@@ -0,0 +1,76 @@
+package rdf2go
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferPool(t *testing.T) {
+	buf := getBuffer()
+	buf.Grow(2 * maxPooledBuffer)
+	putBuffer(buf)
+	for i := 0; i < 10; i++ {
+		assert.True(t, getBuffer().Cap() <= maxPooledBuffer)
+	}
+}
+
+func TestTripleBuilder(t *testing.T) {
+	b := newTripleBuilder()
+	for i := 0; i < arenaChunkSize+1; i++ {
+		b.add(NewResource("a"), NewResource("b"), NewLiteral(fmt.Sprint(i)))
+	}
+	b.add(NewResource("a"), nil, NewLiteral("skipped"))
+	assert.Equal(t, arenaChunkSize+1, len(b.triples))
+	// identical resources are shared
+	assert.True(t, b.triples[0].Predicate == b.triples[arenaChunkSize].Predicate)
+	assert.Equal(t, "\"0\"", b.triples[0].Object.String())
+}
+
+func TestParseBlankNodeLabels(t *testing.T) {
+	g := NewGraph(testUri)
+	assert.NoError(t, g.ParseString("<a> <p> _:x .", "text/turtle"))
+	assert.NoError(t, g.ParseString("<a> <p> _:x .", "text/turtle"))
+	assert.Equal(t, 1, g.Len())
+	assert.NotNil(t, g.One(nil, nil, NewBlankNode("a0")))
+}
+
+func largeTurtle(n int) string {
+	var sb strings.Builder
+	sb.WriteString("@prefix foaf: <http://xmlns.com/foaf/0.1/> .\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "<#p%d> a foaf:Person ;\n  foaf:name \"Person %d\" .\n", i, i)
+	}
+	return sb.String()
+}
+
+func BenchmarkParseTurtle(b *testing.B) {
+	data := largeTurtle(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := NewGraph(testUri)
+		g.Parse(strings.NewReader(data), "text/turtle")
+	}
+}
+
+var tripleSink *Triple
+
+func BenchmarkNewTriple(b *testing.B) {
+	s, p, o := NewResource("a"), NewResource("b"), NewResource("c")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tripleSink = NewTriple(s, p, o)
+	}
+}
+
+func BenchmarkTripleArena(b *testing.B) {
+	s, p, o := NewResource("a"), NewResource("b"), NewResource("c")
+	var a tripleArena
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tripleSink = a.newTriple(s, p, o)
+	}
+}
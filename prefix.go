@@ -0,0 +1,208 @@
+package rdf2go
+
+import (
+	"sort"
+	"strings"
+)
+
+// autoPrefixes are the well-known vocabularies given a prefix when
+// serializing, unless disabled with SetAutoPrefixes
+var autoPrefixes = map[string]string{
+	"rdf":    nsRDF,
+	"rdfs":   nsRDFS,
+	"xsd":    nsXSD,
+	"owl":    nsOWL,
+	"foaf":   nsFOAF,
+	"schema": nsSDO,
+	"dct":    nsDCT,
+}
+
+// SetAutoPrefixes sets whether IRIs of well-known vocabularies (rdf, rdfs,
+// xsd, owl, foaf, schema.org and DC terms) are shortened with prefixes when
+// serializing the graph, which is the default
+func (g *Graph) SetAutoPrefixes(enabled bool) {
+	g.noAutoPrefixes = !enabled
+}
+
+// Prefixes returns the prefixes declared in the Turtle documents parsed into
+// the graph, or set with SetPrefix, mapped to their namespace. They are used
+// when serializing the graph, so that converting a document keeps its
+// prefixes.
+func (g *Graph) Prefixes() map[string]string {
+	prefixes := make(map[string]string, len(g.prefixes))
+	for prefix, ns := range g.prefixes {
+		prefixes[prefix] = ns
+	}
+	return prefixes
+}
+
+// SetPrefix sets the namespace of a prefix used when serializing the graph,
+// replacing the one declared by a parsed document, if any. An empty
+// namespace removes the prefix.
+func (g *Graph) SetPrefix(prefix string, ns string) {
+	if len(ns) == 0 {
+		delete(g.prefixes, prefix)
+		return
+	}
+	g.addPrefixes(map[string]string{prefix: ns})
+}
+
+// addPrefixes adds prefixes to the graph, replacing the ones of the same name
+func (g *Graph) addPrefixes(prefixes map[string]string) {
+	if len(prefixes) == 0 {
+		return
+	}
+	if g.prefixes == nil {
+		g.prefixes = make(map[string]string, len(prefixes))
+	}
+	for prefix, ns := range prefixes {
+		g.prefixes[prefix] = ns
+	}
+}
+
+// candidatePrefixes returns the prefixes of the graph, along with the
+// well-known ones unless they are disabled. Well-known prefixes are left out
+// when the graph uses their name or namespace with another prefix.
+func (g *Graph) candidatePrefixes() map[string]string {
+	candidates := make(map[string]string)
+	if !g.noAutoPrefixes {
+		namespaces := make(map[string]bool, len(g.prefixes))
+		for _, ns := range g.prefixes {
+			namespaces[ns] = true
+		}
+		for prefix, ns := range autoPrefixes {
+			if !namespaces[ns] {
+				candidates[prefix] = ns
+			}
+		}
+	}
+	for prefix, ns := range g.prefixes {
+		candidates[prefix] = ns
+	}
+	return candidates
+}
+
+// usedPrefixes returns the prefixes of the graph and the well-known ones
+// whose namespace is used by IRIs of the graph. Prefixes that would be
+// mistaken for the scheme of an IRI of the graph are left out.
+func (g *Graph) usedPrefixes() map[string]string {
+	used := make(map[string]string)
+	candidates := g.candidatePrefixes()
+	if len(candidates) == 0 {
+		return used
+	}
+	schemes := make(map[string]bool)
+	for triple := range g.IterTriples() {
+		for _, t := range []Term{triple.Subject, triple.Predicate, triple.Object} {
+			iri := ""
+			switch term := t.(type) {
+			case *Resource:
+				iri = term.URI
+			case *Literal:
+				if datatype := term.datatype(); datatype != nil {
+					iri = datatype.RawValue()
+				}
+			}
+			if scheme, _, ok := strings.Cut(iri, ":"); ok {
+				schemes[scheme] = true
+			}
+			if prefix, _, ok := prefixedName(iri, candidates); ok {
+				used[prefix] = candidates[prefix]
+			}
+		}
+	}
+	for prefix := range used {
+		if schemes[prefix] {
+			delete(used, prefix)
+		}
+	}
+	return used
+}
+
+// Namespaces returns the namespaces of the IRIs used by the graph, as
+// subject, predicate, object or datatype, along with the number of times
+// they are used. The namespace of an IRI is the part up to its last # or /.
+func (g *Graph) Namespaces() map[string]int {
+	counts := make(map[string]int)
+	for triple := range g.IterTriples() {
+		for _, t := range []Term{triple.Subject, triple.Predicate, triple.Object} {
+			iri := ""
+			switch term := t.(type) {
+			case *Resource:
+				iri = term.URI
+			case *Literal:
+				if datatype := term.datatype(); datatype != nil {
+					iri = datatype.RawValue()
+				}
+			}
+			if ns, _ := splitPrefix(iri); len(ns) > 0 {
+				counts[ns]++
+			}
+		}
+	}
+	return counts
+}
+
+// prefixedName splits an IRI into a prefix and a local name that can be
+// written as a Turtle prefixed name
+func prefixedName(iri string, prefixes map[string]string) (prefix string, local string, ok bool) {
+	short := shortenIRI(iri, prefixes)
+	if short == iri {
+		return "", "", false
+	}
+	prefix, local, _ = strings.Cut(short, ":")
+	for i, r := range local {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case (r >= '0' && r <= '9' || r == '-') && i > 0:
+		default:
+			return "", "", false
+		}
+	}
+	return prefix, local, true
+}
+
+// sortedPrefixes returns the prefixes of the map in alphabetical order
+func sortedPrefixes(prefixes map[string]string) []string {
+	names := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		names = append(names, prefix)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// turtleTerm encodes a term like encodeTerm, but using prefixed names for
+// IRIs found in the given namespaces
+func turtleTerm(term Term, prefixes map[string]string) string {
+	switch term := term.(type) {
+	case *Resource:
+		if prefix, local, ok := prefixedName(term.URI, prefixes); ok {
+			return prefix + ":" + local
+		}
+		return "<" + escapeIRI(term.URI) + ">"
+	case *Literal:
+		if datatype := term.datatype(); datatype != nil {
+			lit := *term
+			lit.Datatype = nil
+			return lit.String() + "^^" + turtleTerm(datatype, prefixes)
+		}
+	}
+	return encodeTerm(term)
+}
+
+// jsonldPrefixes returns the prefixes used in the @context of the graph
+// serialized to JSON-LD, where the empty prefix is not allowed
+func (g *Graph) jsonldPrefixes() map[string]string {
+	prefixes := g.usedPrefixes()
+	delete(prefixes, "")
+	return prefixes
+}
+
+// compactIRI returns the compact form of an IRI used in JSON-LD documents
+func compactIRI(iri string, prefixes map[string]string) string {
+	if prefix, local, ok := prefixedName(iri, prefixes); ok {
+		return prefix + ":" + local
+	}
+	return iri
+}
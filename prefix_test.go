@@ -0,0 +1,113 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoPrefixesTurtle(t *testing.T) {
+	g := NewGraph(testUri)
+	me := NewResource("http://example.org/#me")
+	g.AddTriple(me, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person"))
+	g.AddTriple(me, NewResource(nsFOAF+"age"), NewLiteralWithDatatype("42", NewResource(nsXSD+"integer")))
+	g.AddTriple(me, NewResource(nsFOAF+"weird.name"), NewLiteral("x"))
+
+	out, err := g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "@prefix foaf: <"+nsFOAF+"> .\n@prefix rdf: <"+nsRDF+"> .\n@prefix xsd: <"+nsXSD+"> .\n\n"), out)
+	assert.Contains(t, out, "rdf:type foaf:Person")
+	assert.Contains(t, out, "foaf:age \"42\"^^xsd:integer")
+	assert.Contains(t, out, "<"+nsFOAF+"weird.name>")
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.ParseString(out, "text/turtle"))
+	assert.True(t, g.Equal(g2))
+
+	g.SetAutoPrefixes(false)
+	out, err = g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "@prefix")
+	assert.Contains(t, out, "<"+nsFOAF+"Person>")
+}
+
+func TestAutoPrefixesJSONLD(t *testing.T) {
+	g := NewGraph(testUri)
+	me := NewResource("http://example.org/#me")
+	g.AddTriple(me, NewResource(nsFOAF+"name"), NewLiteralWithLanguage("Me", "en"))
+	g.AddTriple(me, NewResource(nsFOAF+"knows"), NewResource(nsFOAF+"x"))
+	g.AddTriple(me, NewResource(nsRDFS+"label"), NewLiteralWithDatatype("Me", NewResource(nsXSD+"string")))
+
+	out, err := g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"@context":{"foaf":"`+nsFOAF+`","rdfs":"`+nsRDFS+`"}`)
+	assert.Contains(t, out, `"foaf:name"`)
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.ParseString(out, "application/ld+json"))
+	assert.True(t, g.Equal(g2))
+}
+
+func TestAutoPrefixesSchemeClash(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("foaf:me"), NewResource(nsFOAF+"name"), NewLiteral("Me"))
+	assert.Empty(t, g.usedPrefixes())
+}
+
+func TestParsedPrefixes(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString(`@prefix : <http://example.org/ns#> .
+@prefix sdo: <http://schema.org/> .
+@prefix unused: <http://example.org/unused#> .
+:alice a sdo:Person ; sdo:name "Alice" ; :likes :bob .`, "text/turtle"))
+	assert.Equal(t, map[string]string{
+		"":       "http://example.org/ns#",
+		"sdo":    nsSDO,
+		"unused": "http://example.org/unused#",
+	}, g.Prefixes())
+
+	out, err := g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "@prefix : <http://example.org/ns#> .\n@prefix rdf: <"+nsRDF+"> .\n@prefix sdo: <"+nsSDO+"> .\n\n"), out)
+	assert.Contains(t, out, ":alice\n")
+	assert.Contains(t, out, ":likes :bob")
+	g2 := NewGraph(testUri)
+	require.NoError(t, g2.ParseString(out, "text/turtle"))
+	assert.True(t, g.Equal(g2))
+
+	out, err = g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"sdo:name"`)
+	assert.NotContains(t, out, `"":`)
+
+	g.SetPrefix("sdo", "")
+	g.SetPrefix("ex", "http://example.org/ns#")
+	g.SetPrefix("", "")
+	g.SetAutoPrefixes(false)
+	out, err = g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "@prefix ex: <http://example.org/ns#> .\n\n"), out)
+
+	// prefixes are kept when merging graphs
+	merged := NewGraph(testUri)
+	merged.Merge(g)
+	assert.Equal(t, g.Prefixes(), merged.Prefixes())
+}
+
+func TestNamespaces(t *testing.T) {
+	g := NewGraph(testUri)
+	alice := NewResource("http://example.org/people/alice")
+	g.AddTriple(alice, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person"))
+	g.AddTriple(alice, NewResource(nsFOAF+"knows"), NewResource("http://example.org/people/bob"))
+	g.AddTriple(alice, NewResource(nsFOAF+"age"), NewLiteralWithDatatype("42", NewResource(nsXSD+"integer")))
+	g.AddTriple(alice, NewResource(nsFOAF+"name"), NewLiteral("Alice"))
+	g.AddTriple(NewBlankNode("x"), NewResource("urn:isbn"), NewLiteralWithLanguage("a", "en"))
+
+	assert.Equal(t, map[string]int{
+		"http://example.org/people/": 5,
+		nsRDF:                        1,
+		nsFOAF:                       4,
+		nsXSD:                        1,
+	}, g.Namespaces())
+	assert.Empty(t, NewGraph(testUri).Namespaces())
+}
@@ -0,0 +1,51 @@
+package rdf2go
+
+import (
+	"time"
+)
+
+// provenance holds the settings used to record PROV-O activities
+type provenance struct {
+	graph *Graph
+	agent Term
+}
+
+// SetProvenance enables recording of the operations changing the graph
+// (Merge and LoadURI) as PROV-O activities in the prov graph. Each activity
+// records the source it used, the graph it updated, when it happened and,
+// if agent is not nil, who it is associated with. Passing a nil prov graph
+// disables recording.
+func (g *Graph) SetProvenance(prov *Graph, agent Term) {
+	if prov == nil {
+		g.prov = nil
+		return
+	}
+	g.prov = &provenance{graph: prov, agent: agent}
+}
+
+// recordActivity writes a PROV-O activity describing an operation that used
+// source to update the graph
+func (g *Graph) recordActivity(label string, source Term, started time.Time) Term {
+	if g.prov == nil {
+		return nil
+	}
+	p := g.prov.graph
+	activity := NewAnonNode()
+	p.AddTriple(activity, NewResource(nsRDF+"type"), NewResource(nsPROV+"Activity"))
+	p.AddTriple(activity, NewResource(nsRDFS+"label"), NewLiteral(label))
+	p.AddTriple(activity, NewResource(nsPROV+"startedAtTime"), dateTimeLiteral(started))
+	p.AddTriple(activity, NewResource(nsPROV+"endedAtTime"), dateTimeLiteral(time.Now()))
+	if g.prov.agent != nil {
+		p.AddTriple(activity, NewResource(nsPROV+"wasAssociatedWith"), g.prov.agent)
+	}
+	if source != nil {
+		p.AddTriple(activity, NewResource(nsPROV+"used"), source)
+		p.AddTriple(g.term, NewResource(nsPROV+"wasDerivedFrom"), source)
+	}
+	p.AddTriple(g.term, NewResource(nsPROV+"wasGeneratedBy"), activity)
+	return activity
+}
+
+func dateTimeLiteral(t time.Time) Term {
+	return NewLiteralWithDatatype(t.UTC().Format(time.RFC3339Nano), NewResource(nsXSD+"dateTime"))
+}
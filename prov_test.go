@@ -0,0 +1,44 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenanceMerge(t *testing.T) {
+	prov := NewGraph(testUri + "/prov")
+	agent := NewResource("http://example.org/agent")
+
+	g := NewGraph(testUri)
+	g.SetProvenance(prov, agent)
+	source := NewGraph("http://example.org/source")
+	source.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	g.Merge(source)
+	assert.Equal(t, 1, g.Len())
+
+	activity := prov.One(nil, NewResource(nsRDF+"type"), NewResource(nsPROV+"Activity"))
+	assert.NotNil(t, activity)
+	act := activity.Subject
+	assert.NotNil(t, prov.One(act, NewResource(nsPROV+"used"), source.Term()))
+	assert.NotNil(t, prov.One(act, NewResource(nsPROV+"wasAssociatedWith"), agent))
+	assert.NotNil(t, prov.One(act, NewResource(nsPROV+"startedAtTime"), nil))
+	assert.NotNil(t, prov.One(act, NewResource(nsPROV+"endedAtTime"), nil))
+	assert.NotNil(t, prov.One(g.Term(), NewResource(nsPROV+"wasDerivedFrom"), source.Term()))
+	assert.NotNil(t, prov.One(g.Term(), NewResource(nsPROV+"wasGeneratedBy"), act))
+
+	g.SetProvenance(nil, nil)
+	n := prov.Len()
+	g.Merge(source)
+	assert.Equal(t, n, prov.Len())
+}
+
+func TestProvenanceLoadURI(t *testing.T) {
+	prov := NewGraph(testUri + "/prov")
+	uri := testServer.URL + "/foo#me"
+	g := NewGraph(uri)
+	g.SetProvenance(prov, nil)
+	assert.NoError(t, g.LoadURI(uri))
+	assert.NotNil(t, prov.One(nil, NewResource(nsPROV+"used"), NewResource(testServer.URL+"/foo")))
+	assert.Nil(t, prov.One(nil, NewResource(nsPROV+"wasAssociatedWith"), nil))
+}
@@ -0,0 +1,74 @@
+package rdf2go
+
+import (
+	"strings"
+)
+
+// RewritePredicates replaces the predicates of all triples according to the
+// given mapping of old to new predicate URIs, and returns the number of
+// triples that were rewritten
+func (g *Graph) RewritePredicates(mapping map[string]string) int {
+	return g.rewrite(func(t *Triple) *Triple {
+		p, ok := t.Predicate.(*Resource)
+		if !ok {
+			return t
+		}
+		uri, ok := mapping[p.URI]
+		if !ok {
+			return t
+		}
+		return NewTriple(t.Subject, NewResource(uri), t.Object)
+	})
+}
+
+// RewriteNamespace replaces oldNS with newNS at the start of all the URIs
+// found in the graph (subjects, predicates, objects and literal datatypes),
+// e.g. when migrating from http://schema.org/ to https://schema.org/. It
+// returns the number of triples that were rewritten.
+func (g *Graph) RewriteNamespace(oldNS string, newNS string) int {
+	return g.rewrite(func(t *Triple) *Triple {
+		s := rewriteTermNamespace(t.Subject, oldNS, newNS)
+		p := rewriteTermNamespace(t.Predicate, oldNS, newNS)
+		o := rewriteTermNamespace(t.Object, oldNS, newNS)
+		if s == t.Subject && p == t.Predicate && o == t.Object {
+			return t
+		}
+		return NewTriple(s, p, o)
+	})
+}
+
+func rewriteTermNamespace(term Term, oldNS string, newNS string) Term {
+	switch t := term.(type) {
+	case *Resource:
+		if strings.HasPrefix(t.URI, oldNS) {
+			return NewResource(newNS + t.URI[len(oldNS):])
+		}
+	case *Literal:
+		if t.Datatype == nil {
+			return term
+		}
+		dt := rewriteTermNamespace(t.Datatype, oldNS, newNS)
+		if dt != t.Datatype {
+			return &Literal{Value: t.Value, Language: t.Language, Datatype: dt}
+		}
+	}
+	return term
+}
+
+// rewrite replaces every triple by the one returned by fn, and returns the
+// number of triples that changed
+func (g *Graph) rewrite(fn func(*Triple) *Triple) int {
+	var removed, added []*Triple
+	for triple := range g.IterTriples() {
+		t := fn(triple)
+		if t != triple {
+			removed = append(removed, triple)
+			added = append(added, t)
+		}
+	}
+	for _, t := range removed {
+		g.Remove(t)
+	}
+	g.BulkAdd(added)
+	return len(added)
+}
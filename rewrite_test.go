@@ -0,0 +1,35 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewritePredicates(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("a"), NewResource("http://example.org/old"), NewLiteral("x"))
+	g.AddTriple(NewResource("a"), NewResource("http://example.org/other"), NewLiteral("y"))
+
+	n := g.RewritePredicates(map[string]string{"http://example.org/old": "http://example.org/new"})
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 2, g.Len())
+	assert.Nil(t, g.One(nil, NewResource("http://example.org/old"), nil))
+	assert.NotNil(t, g.One(NewResource("a"), NewResource("http://example.org/new"), NewLiteral("x")))
+}
+
+func TestRewriteNamespace(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("http://schema.org/a"), NewResource("http://schema.org/knows"), NewResource("http://example.org/b"))
+	g.AddTriple(NewResource("http://example.org/b"), NewResource("http://example.org/p"),
+		NewLiteralWithDatatype("1", NewResource("http://schema.org/Number")))
+	g.AddTriple(NewResource("http://example.org/b"), NewResource("http://example.org/p"), NewLiteral("http://schema.org/"))
+
+	n := g.RewriteNamespace("http://schema.org/", "https://schema.org/")
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 3, g.Len())
+	assert.NotNil(t, g.One(NewResource("https://schema.org/a"), NewResource("https://schema.org/knows"), NewResource("http://example.org/b")))
+	assert.NotNil(t, g.One(nil, nil, NewLiteralWithDatatype("1", NewResource("https://schema.org/Number"))))
+	// literal values are not rewritten
+	assert.NotNil(t, g.One(nil, nil, NewLiteral("http://schema.org/")))
+}
@@ -0,0 +1,110 @@
+package rdf2go
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphDiff holds the triples found in only one of two graphs
+type GraphDiff struct {
+	// Missing holds the triples of the first graph not found in the second
+	Missing []*Triple
+	// Added holds the triples of the second graph not found in the first
+	Added []*Triple
+}
+
+// Empty returns true if the two graphs were found to be equal
+func (d *GraphDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Added) == 0
+}
+
+// String returns the differences in a diff-like format, with a line for each
+// missing (-) and added (+) triple
+func (d *GraphDiff) String() string {
+	var b strings.Builder
+	for _, t := range d.Missing {
+		b.WriteString("- " + t.String() + "\n")
+	}
+	for _, t := range d.Added {
+		b.WriteString("+ " + t.String() + "\n")
+	}
+	return b.String()
+}
+
+// Diff compares two graphs. Blank nodes are compared by their canonical
+// labels (see CanonicalNTriples), so graphs that only differ by blank node
+// labels are equal.
+func Diff(a *Graph, b *Graph) *GraphDiff {
+	linesA := canonicalLines(a)
+	linesB := canonicalLines(b)
+	d := &GraphDiff{}
+	for line, t := range linesA {
+		if _, ok := linesB[line]; !ok {
+			d.Missing = append(d.Missing, t)
+		}
+	}
+	for line, t := range linesB {
+		if _, ok := linesA[line]; !ok {
+			d.Added = append(d.Added, t)
+		}
+	}
+	sort.Slice(d.Missing, func(i, j int) bool { return lessTriple(d.Missing[i], d.Missing[j]) })
+	sort.Slice(d.Added, func(i, j int) bool { return lessTriple(d.Added[i], d.Added[j]) })
+	return d
+}
+
+// canonicalLines maps the canonical N-Triples line of each triple to the triple
+func canonicalLines(g *Graph) map[string]*Triple {
+	labels := canonicalLabels(g)
+	lines := make(map[string]*Triple, g.Len())
+	for triple := range g.IterTriples() {
+		lines[canonicalTerm(triple.Subject, labels)+" "+
+			canonicalTerm(triple.Predicate, labels)+" "+
+			canonicalTerm(triple.Object, labels)] = triple
+	}
+	return lines
+}
+
+// Equal returns true if both graphs hold the same triples, up to blank node
+// labels
+func (g *Graph) Equal(other *Graph) bool {
+	return Diff(g, other).Empty()
+}
+
+// RoundTripError is returned by RoundTrip when the data changed on the way
+type RoundTripError struct {
+	Diff *GraphDiff
+}
+
+func (e *RoundTripError) Error() string {
+	return fmt.Sprintf("round trip changed the graph (%d triples missing, %d added):\n%s",
+		len(e.Diff.Missing), len(e.Diff.Added), e.Diff)
+}
+
+// RoundTrip converts data from one format to another, then parses the result
+// back and compares it with the original graph. The converted data is
+// returned along with a *RoundTripError if any triple was lost or changed.
+func RoundTrip(input []byte, fromMime string, toMime string) ([]byte, error) {
+	g := NewGraph("")
+	err := g.Parse(bytes.NewReader(input), fromMime)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = g.Serialize(&buf, toMime)
+	if err != nil {
+		return nil, err
+	}
+	out := buf.Bytes()
+	back := NewGraph("")
+	err = back.Parse(bytes.NewReader(out), toMime)
+	if err != nil {
+		return out, err
+	}
+	if d := Diff(g, back); !d.Empty() {
+		return out, &RoundTripError{Diff: d}
+	}
+	return out, nil
+}
@@ -0,0 +1,58 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a := NewGraph(testUri)
+	b := NewGraph(testUri)
+	a.AddTriple(NewResource("s"), NewResource("p"), NewBlankNode("x"))
+	a.AddTriple(NewBlankNode("x"), NewResource("p"), NewLiteral("1"))
+	b.AddTriple(NewResource("s"), NewResource("p"), NewBlankNode("y"))
+	b.AddTriple(NewBlankNode("y"), NewResource("p"), NewLiteral("1"))
+	assert.True(t, a.Equal(b))
+
+	a.AddTriple(NewResource("s"), NewResource("q"), NewLiteral("a"))
+	b.AddTriple(NewResource("s"), NewResource("q"), NewLiteral("b"))
+	d := Diff(a, b)
+	assert.False(t, d.Empty())
+	assert.Equal(t, "- <s> <q> \"a\" .\n+ <s> <q> \"b\" .\n", d.String())
+}
+
+func TestRoundTrip(t *testing.T) {
+	input := "@prefix foaf: <http://xmlns.com/foaf/0.1/> .\n<http://example.org/#me> a foaf:Person ;\nfoaf:name \"Test\" ."
+	out, err := RoundTrip([]byte(input), "text/turtle", "text/turtle")
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "Test")
+
+	// plain literals come back from JSON-LD as xsd:string, which is the same
+	_, err = RoundTrip([]byte(input), "text/turtle", "application/ld+json")
+	assert.NoError(t, err)
+	DistinctStringLiterals = true
+	_, err = RoundTrip([]byte(input), "text/turtle", "application/ld+json")
+	DistinctStringLiterals = false
+	assert.Contains(t, err.Error(), "1 triples missing, 1 added")
+
+	// relative IRIs are dropped by the JSON-LD parser
+	out, err = RoundTrip([]byte(simpleTurtle), "text/turtle", "application/ld+json")
+	assert.NotEmpty(t, out)
+	rtErr, ok := err.(*RoundTripError)
+	assert.True(t, ok)
+	assert.Len(t, rtErr.Diff.Missing, 2)
+	assert.Empty(t, rtErr.Diff.Added)
+
+	_, err = RoundTrip([]byte("<a> <b> [ <c> \"d\"@en ] ."), "text/turtle", "text/turtle")
+	assert.NoError(t, err)
+
+	_, err = RoundTrip([]byte("<a> <b"), "text/turtle", "text/turtle")
+	assert.Error(t, err)
+}
+
+func TestRoundTripError(t *testing.T) {
+	d := &GraphDiff{Missing: []*Triple{NewTriple(NewResource("a"), NewResource("b"), NewLiteral("c"))}}
+	err := &RoundTripError{Diff: d}
+	assert.Equal(t, "round trip changed the graph (1 triples missing, 0 added):\n- <a> <b> \"c\" .\n", err.Error())
+}
@@ -0,0 +1,56 @@
+package rdf2go
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Sample returns n triples picked at random from the graph (or all of them
+// if it has fewer), in the order of Dump. The same seed always gives the
+// same sample of the same graph, so samples can be used as test fixtures.
+func (g *Graph) Sample(n int, seed int64) []*Triple {
+	triples := g.Triples(true)
+	var result []*Triple
+	for _, i := range sampleIndexes(len(triples), n, seed) {
+		result = append(result, triples[i])
+	}
+	return result
+}
+
+// SampleSubjects picks n subjects at random from the graph (or all of them
+// if it has fewer) and returns all their triples, in the order of Dump, so
+// that the sampled nodes are fully described. The same seed always gives the
+// same sample of the same graph.
+func (g *Graph) SampleSubjects(n int, seed int64) []*Triple {
+	triples := g.Triples(true)
+	// triples are sorted by subject, so each subject is a range of triples
+	var starts []int
+	for i, t := range triples {
+		if i == 0 || !t.Subject.Equal(triples[i-1].Subject) {
+			starts = append(starts, i)
+		}
+	}
+	var result []*Triple
+	for _, i := range sampleIndexes(len(starts), n, seed) {
+		start := starts[i]
+		end := start + 1
+		for end < len(triples) && triples[end].Subject.Equal(triples[start].Subject) {
+			end++
+		}
+		result = append(result, triples[start:end]...)
+	}
+	return result
+}
+
+// sampleIndexes picks n indexes below count at random, in increasing order
+func sampleIndexes(count int, n int, seed int64) []int {
+	if n > count {
+		n = count
+	}
+	if n <= 0 {
+		return nil
+	}
+	picked := rand.New(rand.NewSource(seed)).Perm(count)[:n]
+	sort.Ints(picked)
+	return picked
+}
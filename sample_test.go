@@ -0,0 +1,32 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSample(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.Parse(strings.NewReader(largeTurtle(50)), "text/turtle"))
+
+	s := g.Sample(10, 42)
+	assert.Len(t, s, 10)
+	assert.Equal(t, s, g.Sample(10, 42))
+	assert.NotEqual(t, s, g.Sample(10, 7))
+	for i := 1; i < len(s); i++ {
+		assert.True(t, lessTriple(s[i-1], s[i]))
+	}
+	assert.Len(t, g.Sample(1000, 1), 100)
+	assert.Empty(t, g.Sample(0, 1))
+
+	subjects := g.SampleSubjects(5, 42)
+	assert.Len(t, subjects, 10)
+	assert.Equal(t, subjects, g.SampleSubjects(5, 42))
+	for _, triple := range subjects {
+		assert.Len(t, g.All(triple.Subject, nil, nil), 2)
+	}
+	assert.Len(t, g.SampleSubjects(100, 1), 100)
+}
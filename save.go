@@ -0,0 +1,73 @@
+package rdf2go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ConflictError is returned by Save when the document was modified on the
+// server since the graph was loaded (HTTP 412 Precondition Failed)
+type ConflictError struct {
+	URI  string
+	ETag string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s was modified since version %s was loaded", e.URI, e.ETag)
+}
+
+// Save writes the graph back to the document it was loaded from with a PUT
+// request, see SaveContext
+func (g *Graph) Save() error {
+	return g.SaveContext(context.Background())
+}
+
+// SaveContext writes the graph back to the document it was loaded from with
+// a PUT request, serialized with the content type of the document (Turtle if
+// it cannot be serialized in that format). If the server sent an ETag when
+// the graph was loaded, the request is made with an If-Match header, and a
+// *ConflictError is returned if the document changed in the meantime. The
+// metadata of the graph is updated with the new ETag on success.
+func (g *Graph) SaveContext(ctx context.Context) error {
+	uri := g.meta.SourceURL
+	if len(uri) == 0 {
+		uri = defrag(g.uri)
+	}
+	mime := g.meta.ContentType
+	if _, ok := mimeSerializer[mime]; !ok || mime == "text/html" {
+		mime = "text/turtle"
+	}
+	var body bytes.Buffer
+	err := g.SerializeContext(ctx, &body, mime)
+	if err != nil {
+		return err
+	}
+	q, err := http.NewRequestWithContext(ctx, "PUT", uri, &body)
+	if err != nil {
+		return err
+	}
+	q.Header.Set("Content-Type", mime)
+	if len(g.meta.ETag) > 0 {
+		q.Header.Set("If-Match", g.meta.ETag)
+	}
+	r, err := g.httpClient.Do(q)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	switch {
+	case r.StatusCode == http.StatusPreconditionFailed:
+		return &ConflictError{URI: uri, ETag: g.meta.ETag}
+	case r.StatusCode < 200 || r.StatusCode > 299:
+		return fmt.Errorf("Could not save graph to %s - HTTP %d", uri, r.StatusCode)
+	}
+	g.meta.SourceURL = uri
+	g.meta.ContentType = mime
+	g.meta.ETag = r.Header.Get("ETag")
+	if modified, err := http.ParseTime(r.Header.Get("Last-Modified")); err == nil {
+		g.meta.LastModified = modified
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package rdf2go
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedServer serves a single Turtle document, rejecting writes made
+// with an outdated If-Match header
+type versionedServer struct {
+	mu      sync.Mutex
+	version int
+	body    []byte
+}
+
+func (s *versionedServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	etag := fmt.Sprintf(`"v%d"`, s.version)
+	switch req.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "text/turtle")
+		w.Header().Set("ETag", etag)
+		w.Write(s.body)
+	case "PUT":
+		if match := req.Header.Get("If-Match"); len(match) > 0 && match != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		s.body, _ = io.ReadAll(req.Body)
+		s.version++
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, s.version))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestGraphSave(t *testing.T) {
+	server := &versionedServer{body: []byte(simpleTurtle)}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	g := NewGraph(ts.URL + "/doc")
+	require.NoError(t, g.LoadURI(ts.URL+"/doc"))
+	other := NewGraph(ts.URL + "/doc")
+	require.NoError(t, other.LoadURI(ts.URL+"/doc"))
+
+	g.AddTriple(NewResource(ts.URL+"/doc#me"), NewResource(nsFOAF+"nick"), NewLiteral("t"))
+	require.NoError(t, g.Save())
+	assert.Equal(t, `"v1"`, g.Metadata().ETag)
+
+	// other was loaded before the change
+	other.AddTriple(NewResource(ts.URL+"/doc#me"), NewResource(nsFOAF+"nick"), NewLiteral("o"))
+	err := other.Save()
+	var conflict *ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, `"v0"`, conflict.ETag)
+
+	reloaded := NewGraph(ts.URL + "/doc")
+	require.NoError(t, reloaded.LoadURI(ts.URL+"/doc"))
+	assert.True(t, g.Equal(reloaded))
+
+	// saving again uses the new ETag
+	require.NoError(t, g.Save())
+	assert.Equal(t, `"v2"`, g.Metadata().ETag)
+}
+
+func TestGraphSaveError(t *testing.T) {
+	g := NewGraph(testServer.URL + "/missing")
+	assert.Error(t, g.Save())
+}
@@ -0,0 +1,80 @@
+//go:build ignore
+
+// gen.go generates terms.go from the list of terms in terms.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// initialisms are written in upper case in Go names, as in golint
+var initialisms = []string{"GTIN", "ISBN", "MPN", "SKU", "URL"}
+
+// goName returns the exported Go name of a property
+func goName(p string) string {
+	for _, i := range initialisms {
+		if strings.HasPrefix(strings.ToUpper(p), i) && strings.Trim(p[len(i):], "0123456789") == "" {
+			return strings.ToUpper(p)
+		}
+	}
+	return strings.ToUpper(p[:1]) + p[1:]
+}
+
+func main() {
+	f, err := os.Open("terms.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var types, props []string
+	isType := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if unicode.IsUpper(rune(line[0])) {
+			types = append(types, line)
+			isType[line] = true
+		} else {
+			props = append(props, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go from terms.txt; DO NOT EDIT.\n\n")
+	b.WriteString("package schema\n\n")
+	b.WriteString("// Types\nconst (\n")
+	for _, t := range types {
+		fmt.Fprintf(&b, "\t// %s is the %s%s type\n\t%s = NS + %q\n", t, "http://schema.org/", t, t, t)
+	}
+	b.WriteString(")\n\n// Properties\nconst (\n")
+	for _, p := range props {
+		name := goName(p)
+		if isType[name] {
+			name += "Property"
+		}
+		fmt.Fprintf(&b, "\t// %s is the %s%s property\n\t%s = NS + %q\n", name, "http://schema.org/", p, name, p)
+	}
+	b.WriteString(")\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("terms.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,12 @@
+// Package schema provides the IRIs of commonly used schema.org types and
+// properties.
+//
+// The constants are generated from terms.txt; add terms there and run
+// go generate to update them. Properties whose name would clash with a type
+// (e.g. event and Event) get a Property suffix (EventProperty).
+package schema
+
+//go:generate go run gen.go
+
+// NS is the schema.org namespace
+const NS = "http://schema.org/"
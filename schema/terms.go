@@ -0,0 +1,245 @@
+// Code generated by gen.go from terms.txt; DO NOT EDIT.
+
+package schema
+
+// Types
+const (
+	// Action is the http://schema.org/Action type
+	Action = NS + "Action"
+	// AggregateRating is the http://schema.org/AggregateRating type
+	AggregateRating = NS + "AggregateRating"
+	// Article is the http://schema.org/Article type
+	Article = NS + "Article"
+	// Book is the http://schema.org/Book type
+	Book = NS + "Book"
+	// BlogPosting is the http://schema.org/BlogPosting type
+	BlogPosting = NS + "BlogPosting"
+	// Brand is the http://schema.org/Brand type
+	Brand = NS + "Brand"
+	// BreadcrumbList is the http://schema.org/BreadcrumbList type
+	BreadcrumbList = NS + "BreadcrumbList"
+	// CreativeWork is the http://schema.org/CreativeWork type
+	CreativeWork = NS + "CreativeWork"
+	// Dataset is the http://schema.org/Dataset type
+	Dataset = NS + "Dataset"
+	// Date is the http://schema.org/Date type
+	Date = NS + "Date"
+	// DateTime is the http://schema.org/DateTime type
+	DateTime = NS + "DateTime"
+	// Event is the http://schema.org/Event type
+	Event = NS + "Event"
+	// FAQPage is the http://schema.org/FAQPage type
+	FAQPage = NS + "FAQPage"
+	// ImageObject is the http://schema.org/ImageObject type
+	ImageObject = NS + "ImageObject"
+	// ItemList is the http://schema.org/ItemList type
+	ItemList = NS + "ItemList"
+	// JobPosting is the http://schema.org/JobPosting type
+	JobPosting = NS + "JobPosting"
+	// ListItem is the http://schema.org/ListItem type
+	ListItem = NS + "ListItem"
+	// LocalBusiness is the http://schema.org/LocalBusiness type
+	LocalBusiness = NS + "LocalBusiness"
+	// Movie is the http://schema.org/Movie type
+	Movie = NS + "Movie"
+	// MusicRecording is the http://schema.org/MusicRecording type
+	MusicRecording = NS + "MusicRecording"
+	// NewsArticle is the http://schema.org/NewsArticle type
+	NewsArticle = NS + "NewsArticle"
+	// Offer is the http://schema.org/Offer type
+	Offer = NS + "Offer"
+	// Organization is the http://schema.org/Organization type
+	Organization = NS + "Organization"
+	// Person is the http://schema.org/Person type
+	Person = NS + "Person"
+	// Place is the http://schema.org/Place type
+	Place = NS + "Place"
+	// PostalAddress is the http://schema.org/PostalAddress type
+	PostalAddress = NS + "PostalAddress"
+	// Product is the http://schema.org/Product type
+	Product = NS + "Product"
+	// Question is the http://schema.org/Question type
+	Question = NS + "Question"
+	// Answer is the http://schema.org/Answer type
+	Answer = NS + "Answer"
+	// Rating is the http://schema.org/Rating type
+	Rating = NS + "Rating"
+	// Recipe is the http://schema.org/Recipe type
+	Recipe = NS + "Recipe"
+	// Review is the http://schema.org/Review type
+	Review = NS + "Review"
+	// Service is the http://schema.org/Service type
+	Service = NS + "Service"
+	// SoftwareApplication is the http://schema.org/SoftwareApplication type
+	SoftwareApplication = NS + "SoftwareApplication"
+	// Thing is the http://schema.org/Thing type
+	Thing = NS + "Thing"
+	// VideoObject is the http://schema.org/VideoObject type
+	VideoObject = NS + "VideoObject"
+	// WebPage is the http://schema.org/WebPage type
+	WebPage = NS + "WebPage"
+	// WebSite is the http://schema.org/WebSite type
+	WebSite = NS + "WebSite"
+	// InStock is the http://schema.org/InStock type
+	InStock = NS + "InStock"
+	// OutOfStock is the http://schema.org/OutOfStock type
+	OutOfStock = NS + "OutOfStock"
+	// PreOrder is the http://schema.org/PreOrder type
+	PreOrder = NS + "PreOrder"
+	// Discontinued is the http://schema.org/Discontinued type
+	Discontinued = NS + "Discontinued"
+	// EventScheduled is the http://schema.org/EventScheduled type
+	EventScheduled = NS + "EventScheduled"
+	// EventCancelled is the http://schema.org/EventCancelled type
+	EventCancelled = NS + "EventCancelled"
+	// EventPostponed is the http://schema.org/EventPostponed type
+	EventPostponed = NS + "EventPostponed"
+	// OnlineEventAttendanceMode is the http://schema.org/OnlineEventAttendanceMode type
+	OnlineEventAttendanceMode = NS + "OnlineEventAttendanceMode"
+	// OfflineEventAttendanceMode is the http://schema.org/OfflineEventAttendanceMode type
+	OfflineEventAttendanceMode = NS + "OfflineEventAttendanceMode"
+	// MixedEventAttendanceMode is the http://schema.org/MixedEventAttendanceMode type
+	MixedEventAttendanceMode = NS + "MixedEventAttendanceMode"
+)
+
+// Properties
+const (
+	// About is the http://schema.org/about property
+	About = NS + "about"
+	// AcceptedAnswer is the http://schema.org/acceptedAnswer property
+	AcceptedAnswer = NS + "acceptedAnswer"
+	// AdditionalType is the http://schema.org/additionalType property
+	AdditionalType = NS + "additionalType"
+	// Address is the http://schema.org/address property
+	Address = NS + "address"
+	// AddressCountry is the http://schema.org/addressCountry property
+	AddressCountry = NS + "addressCountry"
+	// AddressLocality is the http://schema.org/addressLocality property
+	AddressLocality = NS + "addressLocality"
+	// AddressRegion is the http://schema.org/addressRegion property
+	AddressRegion = NS + "addressRegion"
+	// AggregateRatingProperty is the http://schema.org/aggregateRating property
+	AggregateRatingProperty = NS + "aggregateRating"
+	// AlternateName is the http://schema.org/alternateName property
+	AlternateName = NS + "alternateName"
+	// Attendee is the http://schema.org/attendee property
+	Attendee = NS + "attendee"
+	// Author is the http://schema.org/author property
+	Author = NS + "author"
+	// Availability is the http://schema.org/availability property
+	Availability = NS + "availability"
+	// BestRating is the http://schema.org/bestRating property
+	BestRating = NS + "bestRating"
+	// BirthDate is the http://schema.org/birthDate property
+	BirthDate = NS + "birthDate"
+	// BrandProperty is the http://schema.org/brand property
+	BrandProperty = NS + "brand"
+	// DateCreated is the http://schema.org/dateCreated property
+	DateCreated = NS + "dateCreated"
+	// DatePublished is the http://schema.org/datePublished property
+	DatePublished = NS + "datePublished"
+	// DateModified is the http://schema.org/dateModified property
+	DateModified = NS + "dateModified"
+	// Description is the http://schema.org/description property
+	Description = NS + "description"
+	// Duration is the http://schema.org/duration property
+	Duration = NS + "duration"
+	// Email is the http://schema.org/email property
+	Email = NS + "email"
+	// Employee is the http://schema.org/employee property
+	Employee = NS + "employee"
+	// EndDate is the http://schema.org/endDate property
+	EndDate = NS + "endDate"
+	// EventProperty is the http://schema.org/event property
+	EventProperty = NS + "event"
+	// EventAttendanceMode is the http://schema.org/eventAttendanceMode property
+	EventAttendanceMode = NS + "eventAttendanceMode"
+	// EventStatus is the http://schema.org/eventStatus property
+	EventStatus = NS + "eventStatus"
+	// FamilyName is the http://schema.org/familyName property
+	FamilyName = NS + "familyName"
+	// Founder is the http://schema.org/founder property
+	Founder = NS + "founder"
+	// GTIN is the http://schema.org/gtin property
+	GTIN = NS + "gtin"
+	// GTIN13 is the http://schema.org/gtin13 property
+	GTIN13 = NS + "gtin13"
+	// Headline is the http://schema.org/headline property
+	Headline = NS + "headline"
+	// Identifier is the http://schema.org/identifier property
+	Identifier = NS + "identifier"
+	// Image is the http://schema.org/image property
+	Image = NS + "image"
+	// IsPartOf is the http://schema.org/isPartOf property
+	IsPartOf = NS + "isPartOf"
+	// Item is the http://schema.org/item property
+	Item = NS + "item"
+	// ItemListElement is the http://schema.org/itemListElement property
+	ItemListElement = NS + "itemListElement"
+	// JobTitle is the http://schema.org/jobTitle property
+	JobTitle = NS + "jobTitle"
+	// Keywords is the http://schema.org/keywords property
+	Keywords = NS + "keywords"
+	// Knows is the http://schema.org/knows property
+	Knows = NS + "knows"
+	// LegalName is the http://schema.org/legalName property
+	LegalName = NS + "legalName"
+	// Location is the http://schema.org/location property
+	Location = NS + "location"
+	// Logo is the http://schema.org/logo property
+	Logo = NS + "logo"
+	// MainEntity is the http://schema.org/mainEntity property
+	MainEntity = NS + "mainEntity"
+	// Manufacturer is the http://schema.org/manufacturer property
+	Manufacturer = NS + "manufacturer"
+	// MemberOf is the http://schema.org/memberOf property
+	MemberOf = NS + "memberOf"
+	// Model is the http://schema.org/model property
+	Model = NS + "model"
+	// Name is the http://schema.org/name property
+	Name = NS + "name"
+	// Offers is the http://schema.org/offers property
+	Offers = NS + "offers"
+	// Organizer is the http://schema.org/organizer property
+	Organizer = NS + "organizer"
+	// Performer is the http://schema.org/performer property
+	Performer = NS + "performer"
+	// Position is the http://schema.org/position property
+	Position = NS + "position"
+	// PostalCode is the http://schema.org/postalCode property
+	PostalCode = NS + "postalCode"
+	// Price is the http://schema.org/price property
+	Price = NS + "price"
+	// PriceCurrency is the http://schema.org/priceCurrency property
+	PriceCurrency = NS + "priceCurrency"
+	// PriceValidUntil is the http://schema.org/priceValidUntil property
+	PriceValidUntil = NS + "priceValidUntil"
+	// Publisher is the http://schema.org/publisher property
+	Publisher = NS + "publisher"
+	// RatingValue is the http://schema.org/ratingValue property
+	RatingValue = NS + "ratingValue"
+	// ReviewProperty is the http://schema.org/review property
+	ReviewProperty = NS + "review"
+	// ReviewRating is the http://schema.org/reviewRating property
+	ReviewRating = NS + "reviewRating"
+	// SameAs is the http://schema.org/sameAs property
+	SameAs = NS + "sameAs"
+	// Seller is the http://schema.org/seller property
+	Seller = NS + "seller"
+	// SKU is the http://schema.org/sku property
+	SKU = NS + "sku"
+	// StartDate is the http://schema.org/startDate property
+	StartDate = NS + "startDate"
+	// StreetAddress is the http://schema.org/streetAddress property
+	StreetAddress = NS + "streetAddress"
+	// Telephone is the http://schema.org/telephone property
+	Telephone = NS + "telephone"
+	// Text is the http://schema.org/text property
+	Text = NS + "text"
+	// URL is the http://schema.org/url property
+	URL = NS + "url"
+	// GivenName is the http://schema.org/givenName property
+	GivenName = NS + "givenName"
+	// WorksFor is the http://schema.org/worksFor property
+	WorksFor = NS + "worksFor"
+)
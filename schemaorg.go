@@ -0,0 +1,203 @@
+package rdf2go
+
+import (
+	"time"
+
+	"github.com/deiu/rdf2go/schema"
+)
+
+// SchemaPerson describes a schema:Person
+type SchemaPerson struct {
+	URI        string
+	Name       string
+	GivenName  string
+	FamilyName string
+	Email      string
+	Telephone  string
+	JobTitle   string
+	URL        string
+	Image      string
+	SameAs     []string
+	WorksFor   *SchemaOrganization
+}
+
+// SchemaOrganization describes a schema:Organization
+type SchemaOrganization struct {
+	URI       string
+	Name      string
+	LegalName string
+	Email     string
+	Telephone string
+	URL       string
+	Logo      string
+	SameAs    []string
+	Address   *SchemaPostalAddress
+}
+
+// SchemaPostalAddress describes a schema:PostalAddress
+type SchemaPostalAddress struct {
+	StreetAddress   string
+	PostalCode      string
+	AddressLocality string
+	AddressRegion   string
+	AddressCountry  string
+}
+
+// SchemaProduct describes a schema:Product
+type SchemaProduct struct {
+	URI         string
+	Name        string
+	Description string
+	SKU         string
+	GTIN        string
+	URL         string
+	Image       string
+	Brand       *SchemaOrganization
+	Offers      []*SchemaOffer
+}
+
+// SchemaOffer describes a schema:Offer. Price is a decimal number, and
+// Availability an IRI such as schema.InStock.
+type SchemaOffer struct {
+	URI           string
+	Price         string
+	PriceCurrency string
+	Availability  string
+	URL           string
+}
+
+// SchemaEvent describes a schema:Event
+type SchemaEvent struct {
+	URI         string
+	Name        string
+	Description string
+	URL         string
+	StartDate   time.Time
+	EndDate     time.Time
+	Location    *SchemaPlace
+	Organizer   *SchemaOrganization
+}
+
+// SchemaPlace describes a schema:Place
+type SchemaPlace struct {
+	URI     string
+	Name    string
+	Address *SchemaPostalAddress
+}
+
+// AddSchemaPerson adds the triples describing a person to the graph and
+// returns its subject, a blank node when p.URI is empty.
+func (g *Graph) AddSchemaPerson(p *SchemaPerson) Term {
+	s := subjectOrBlank(p.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.Person))
+	g.addLiteral(s, schema.Name, p.Name)
+	g.addLiteral(s, schema.GivenName, p.GivenName)
+	g.addLiteral(s, schema.FamilyName, p.FamilyName)
+	g.addLiteral(s, schema.Email, p.Email)
+	g.addLiteral(s, schema.Telephone, p.Telephone)
+	g.addLiteral(s, schema.JobTitle, p.JobTitle)
+	g.addResource(s, schema.URL, p.URL)
+	g.addResource(s, schema.Image, p.Image)
+	for _, uri := range p.SameAs {
+		g.addResource(s, schema.SameAs, uri)
+	}
+	if p.WorksFor != nil {
+		g.AddTriple(s, NewResource(schema.WorksFor), g.AddSchemaOrganization(p.WorksFor))
+	}
+	return s
+}
+
+// AddSchemaOrganization adds the triples describing an organization to the graph
+func (g *Graph) AddSchemaOrganization(o *SchemaOrganization) Term {
+	s := subjectOrBlank(o.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.Organization))
+	g.addLiteral(s, schema.Name, o.Name)
+	g.addLiteral(s, schema.LegalName, o.LegalName)
+	g.addLiteral(s, schema.Email, o.Email)
+	g.addLiteral(s, schema.Telephone, o.Telephone)
+	g.addResource(s, schema.URL, o.URL)
+	g.addResource(s, schema.Logo, o.Logo)
+	for _, uri := range o.SameAs {
+		g.addResource(s, schema.SameAs, uri)
+	}
+	if o.Address != nil {
+		g.AddTriple(s, NewResource(schema.Address), g.addSchemaPostalAddress(o.Address))
+	}
+	return s
+}
+
+func (g *Graph) addSchemaPostalAddress(a *SchemaPostalAddress) Term {
+	s := NewAnonNode()
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.PostalAddress))
+	g.addLiteral(s, schema.StreetAddress, a.StreetAddress)
+	g.addLiteral(s, schema.PostalCode, a.PostalCode)
+	g.addLiteral(s, schema.AddressLocality, a.AddressLocality)
+	g.addLiteral(s, schema.AddressRegion, a.AddressRegion)
+	g.addLiteral(s, schema.AddressCountry, a.AddressCountry)
+	return s
+}
+
+// AddSchemaProduct adds the triples describing a product and its offers to the graph
+func (g *Graph) AddSchemaProduct(p *SchemaProduct) Term {
+	s := subjectOrBlank(p.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.Product))
+	g.addLiteral(s, schema.Name, p.Name)
+	g.addLiteral(s, schema.Description, p.Description)
+	g.addLiteral(s, schema.SKU, p.SKU)
+	g.addLiteral(s, schema.GTIN, p.GTIN)
+	g.addResource(s, schema.URL, p.URL)
+	g.addResource(s, schema.Image, p.Image)
+	if p.Brand != nil {
+		g.AddTriple(s, NewResource(schema.BrandProperty), g.AddSchemaOrganization(p.Brand))
+	}
+	for _, o := range p.Offers {
+		g.AddTriple(s, NewResource(schema.Offers), g.AddSchemaOffer(o))
+	}
+	return s
+}
+
+// AddSchemaOffer adds the triples describing an offer to the graph
+func (g *Graph) AddSchemaOffer(o *SchemaOffer) Term {
+	s := subjectOrBlank(o.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.Offer))
+	if len(o.Price) > 0 {
+		g.AddTriple(s, NewResource(schema.Price), NewLiteralWithDatatype(o.Price, NewResource(nsXSD+"decimal")))
+	}
+	g.addLiteral(s, schema.PriceCurrency, o.PriceCurrency)
+	g.addResource(s, schema.Availability, o.Availability)
+	g.addResource(s, schema.URL, o.URL)
+	return s
+}
+
+// AddSchemaEvent adds the triples describing an event to the graph
+func (g *Graph) AddSchemaEvent(e *SchemaEvent) Term {
+	s := subjectOrBlank(e.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.Event))
+	g.addLiteral(s, schema.Name, e.Name)
+	g.addLiteral(s, schema.Description, e.Description)
+	g.addResource(s, schema.URL, e.URL)
+	if !e.StartDate.IsZero() {
+		g.AddTriple(s, NewResource(schema.StartDate), dateTimeLiteral(e.StartDate))
+	}
+	if !e.EndDate.IsZero() {
+		g.AddTriple(s, NewResource(schema.EndDate), dateTimeLiteral(e.EndDate))
+	}
+	if e.Location != nil {
+		g.AddTriple(s, NewResource(schema.Location), g.AddSchemaPlace(e.Location))
+	}
+	if e.Organizer != nil {
+		g.AddTriple(s, NewResource(schema.Organizer), g.AddSchemaOrganization(e.Organizer))
+	}
+	return s
+}
+
+// AddSchemaPlace adds the triples describing a place to the graph
+func (g *Graph) AddSchemaPlace(p *SchemaPlace) Term {
+	s := subjectOrBlank(p.URI)
+	g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(schema.Place))
+	g.addLiteral(s, schema.Name, p.Name)
+	if p.Address != nil {
+		g.AddTriple(s, NewResource(schema.Address), g.addSchemaPostalAddress(p.Address))
+	}
+	return s
+}
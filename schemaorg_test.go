@@ -0,0 +1,60 @@
+package rdf2go
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deiu/rdf2go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSchemaPerson(t *testing.T) {
+	g := NewGraph(testUri)
+	s := g.AddSchemaPerson(&SchemaPerson{
+		URI:    "https://example.org/alice",
+		Name:   "Alice",
+		Email:  "alice@example.org",
+		SameAs: []string{"https://alice.example"},
+		WorksFor: &SchemaOrganization{
+			Name:    "ACME",
+			Address: &SchemaPostalAddress{AddressLocality: "Paris", AddressCountry: "FR"},
+		},
+	})
+	assert.Equal(t, NewResource("https://example.org/alice"), s)
+	assert.NotNil(t, g.One(s, NewResource(nsRDF+"type"), NewResource(schema.Person)))
+	assert.NotNil(t, g.One(s, NewResource(schema.Name), NewLiteral("Alice")))
+	assert.NotNil(t, g.One(s, NewResource(schema.SameAs), NewResource("https://alice.example")))
+	org := g.One(s, NewResource(schema.WorksFor), nil).Object
+	assert.IsType(t, &BlankNode{}, org)
+	assert.NotNil(t, g.One(org, NewResource(nsRDF+"type"), NewResource(schema.Organization)))
+	addr := g.One(org, NewResource(schema.Address), nil).Object
+	assert.Equal(t, "Paris", g.value(addr, schema.AddressLocality))
+	assert.Nil(t, g.One(addr, NewResource(schema.StreetAddress), nil))
+	assert.Equal(t, 11, g.Len())
+}
+
+func TestAddSchemaProductAndEvent(t *testing.T) {
+	g := NewGraph(testUri)
+	p := g.AddSchemaProduct(&SchemaProduct{
+		Name: "Widget",
+		SKU:  "W-1",
+		Offers: []*SchemaOffer{
+			{Price: "9.99", PriceCurrency: "EUR", Availability: schema.InStock},
+		},
+	})
+	offer := g.One(p, NewResource(schema.Offers), nil).Object
+	assert.NotNil(t, g.One(offer, NewResource(schema.Price), NewLiteralWithDatatype("9.99", NewResource(nsXSD+"decimal"))))
+	assert.NotNil(t, g.One(offer, NewResource(schema.Availability), NewResource(schema.InStock)))
+
+	start := time.Date(2020, 5, 1, 9, 0, 0, 0, time.UTC)
+	e := g.AddSchemaEvent(&SchemaEvent{
+		URI:       "https://example.org/conf",
+		Name:      "Conf",
+		StartDate: start,
+		Location:  &SchemaPlace{Name: "Main hall"},
+	})
+	assert.NotNil(t, g.One(e, NewResource(schema.StartDate), NewLiteralWithDatatype("2020-05-01T09:00:00Z", NewResource(nsXSD+"dateTime"))))
+	assert.Nil(t, g.One(e, NewResource(schema.EndDate), nil))
+	place := g.One(e, NewResource(schema.Location), nil).Object
+	assert.Equal(t, "Main hall", g.value(place, schema.Name))
+}
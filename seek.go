@@ -0,0 +1,55 @@
+package rdf2go
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultParseOrder lists the mime types tried by ParseSeeker when none are
+// given
+var DefaultParseOrder = []string{"text/turtle", "application/ld+json"}
+
+// ParseSeeker parses RDF data from r, trying each of the given mime types in
+// turn (DefaultParseOrder if none are given), and returns the mime type of
+// the first successful attempt. After a failed attempt r is rewound to its
+// initial offset, so the input is read again instead of being kept in
+// memory by the caller. Only the successful attempt adds triples to the
+// graph, unless SetKeepPartial is enabled and an attempt fails after
+// parsing some triples, in which case its *PartialParseError is returned
+// without trying the other mime types. When all the attempts fail, the
+// returned error holds the error of each of them, prefixed with its mime
+// type.
+func (g *Graph) ParseSeeker(r io.ReadSeeker, mimes ...string) (string, error) {
+	if len(mimes) == 0 {
+		mimes = DefaultParseOrder
+	}
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	var errs []error
+	for i, mime := range mimes {
+		if i > 0 {
+			if _, err := r.Seek(start, io.SeekStart); err != nil {
+				return "", errors.Join(append(errs, err)...)
+			}
+		}
+		err := g.Parse(r, mime)
+		if err == nil {
+			return mime, nil
+		}
+		var perr *PartialParseError
+		if errors.As(err, &perr) {
+			return mime, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", mime, err))
+	}
+	return "", errors.Join(errs...)
+}
+
+// ParseReaderAt is like ParseSeeker, reading the first size bytes of r, e.g.
+// an *os.File or a *bytes.Reader
+func (g *Graph) ParseReaderAt(r io.ReaderAt, size int64, mimes ...string) (string, error) {
+	return g.ParseSeeker(io.NewSectionReader(r, 0, size), mimes...)
+}
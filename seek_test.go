@@ -0,0 +1,47 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeeker(t *testing.T) {
+	g := NewGraph(testUri)
+	mime, err := g.ParseSeeker(strings.NewReader(simpleTurtle))
+	require.NoError(t, err)
+	assert.Equal(t, "text/turtle", mime)
+	assert.Equal(t, 2, g.Len())
+
+	g = NewGraph(testUri)
+	jsonld := `{"@id": "http://example.org/#me", "http://xmlns.com/foaf/0.1/name": "Test"}`
+	mime, err = g.ParseSeeker(strings.NewReader(jsonld))
+	require.NoError(t, err)
+	assert.Equal(t, "application/ld+json", mime)
+	assert.Equal(t, 1, g.Len())
+
+	// the reader is rewound to where it was, not to the start of the data
+	r := strings.NewReader("garbage" + jsonld)
+	r.Seek(int64(len("garbage")), 0)
+	g = NewGraph(testUri)
+	mime, err = g.ParseSeeker(r)
+	require.NoError(t, err)
+	assert.Equal(t, "application/ld+json", mime)
+
+	g = NewGraph(testUri)
+	_, err = g.ParseSeeker(strings.NewReader("{ not rdf"), "application/ld+json", "text/turtle")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "application/ld+json: ")
+	assert.Contains(t, err.Error(), "text/turtle: ")
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestParseReaderAt(t *testing.T) {
+	g := NewGraph(testUri)
+	mime, err := g.ParseReaderAt(strings.NewReader(simpleTurtle), int64(len(simpleTurtle)), "application/ld+json", "text/turtle")
+	require.NoError(t, err)
+	assert.Equal(t, "text/turtle", mime)
+	assert.Equal(t, 2, g.Len())
+}
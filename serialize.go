@@ -0,0 +1,108 @@
+package rdf2go
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// SerializeOptions is used to configure SerializeWithOptions
+type SerializeOptions struct {
+	// Pretty separates subjects with a blank line in Turtle, ending the
+	// output with a new line, and indents JSON-LD
+	Pretty bool
+	// SortKeys sorts subjects, predicates and objects, so that serializing
+	// an unchanged graph gives the same output. Otherwise triples are
+	// written in the order of the store, which is faster for large graphs.
+	// JSON-LD compacted with a context is always sorted.
+	SortKeys bool
+	// BaseIRI is written as the @base of the document, and IRIs it resolves
+	// are written relative to it
+	BaseIRI string
+	// Context compacts JSON-LD output, see SetJSONLDContext. If nil, the
+	// context set on the graph is used, if any.
+	Context *JSONLDContext
+	// Indent is used to indent Pretty output, two spaces by default
+	Indent string
+}
+
+// SerializeWithOptions behaves like Serialize, with the output configured by
+// opts instead of the settings of the graph. Serialize uses the zero options
+// with SortKeys set.
+func (g *Graph) SerializeWithOptions(w io.Writer, mime string, opts SerializeOptions) error {
+	return g.serializeContext(context.Background(), w, mime, opts)
+}
+
+// indent returns the indentation of the predicates of a Turtle subject
+func (o SerializeOptions) indent() string {
+	if o.Pretty && len(o.Indent) > 0 {
+		return o.Indent
+	}
+	return "  "
+}
+
+// relative returns an IRI relative to the base IRI, if it resolves it
+func (o SerializeOptions) relative(iri string) string {
+	if len(o.BaseIRI) > 0 {
+		if rel, ok := relativeIRI(iri, o.BaseIRI); ok {
+			return rel
+		}
+	}
+	return iri
+}
+
+// writeJSON writes a JSON document, indented if Pretty is set
+func (o SerializeOptions) writeJSON(w io.Writer, doc interface{}) error {
+	var b []byte
+	var err error
+	if o.Pretty {
+		indent := o.Indent
+		if len(indent) == 0 {
+			indent = "  "
+		}
+		b, err = json.MarshalIndent(doc, "", indent)
+		b = append(b, '\n')
+	} else {
+		b, err = json.Marshal(doc)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// relativeIRI returns the part of an IRI following base, when resolving it
+// against base gives back the IRI
+func relativeIRI(iri string, base string) (string, bool) {
+	rel, ok := strings.CutPrefix(iri, base)
+	if !ok || resolveIRI(base, rel) != iri {
+		return "", false
+	}
+	return rel, true
+}
+
+// groupBySubject returns a closed channel holding the triples grouped by
+// subject, like IterBySubject, keeping the order in which subjects first
+// appear
+func groupBySubject(triples []*Triple) chan []*Triple {
+	index := make(map[string]int)
+	var groups [][]*Triple
+	for _, t := range triples {
+		key := encodeTerm(t.Subject)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], t)
+	}
+	ch := make(chan []*Triple, len(groups))
+	for _, group := range groups {
+		ch <- group
+	}
+	close(ch)
+	return ch
+}
@@ -0,0 +1,100 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serializeOptionsGraph() *Graph {
+	g := NewGraph("http://example.org/doc")
+	g.AddTriple(NewResource("http://example.org/doc#a"), NewResource(nsFOAF+"name"), NewLiteral("A"))
+	g.AddTriple(NewResource("http://example.org/doc#a"), NewResource(nsFOAF+"knows"), NewResource("http://example.org/doc#b"))
+	g.AddTriple(NewResource("http://example.org/doc#b"), NewResource(nsFOAF+"knows"), NewResource("http://other.example/c"))
+	return g
+}
+
+func TestSerializeWithOptionsTurtle(t *testing.T) {
+	g := serializeOptionsGraph()
+	var b strings.Builder
+	require.NoError(t, g.SerializeWithOptions(&b, "text/turtle", SerializeOptions{
+		Pretty:   true,
+		SortKeys: true,
+		BaseIRI:  "http://example.org/doc",
+		Indent:   "    ",
+	}))
+	assert.Equal(t, `@base <http://example.org/doc> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+<#a>
+    foaf:knows <#b> ;
+    foaf:name "A" .
+
+<#b>
+    foaf:knows <http://other.example/c> .
+`, b.String())
+
+	parsed := NewGraph("http://elsewhere.example/")
+	require.NoError(t, parsed.Parse(strings.NewReader(b.String()), "text/turtle"))
+	assert.True(t, g.Equal(parsed))
+
+	// Serialize sorts without the other options
+	out, err := g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	b.Reset()
+	require.NoError(t, g.SerializeWithOptions(&b, "text/turtle", SerializeOptions{SortKeys: true}))
+	assert.Equal(t, out, b.String())
+
+	b.Reset()
+	require.NoError(t, g.SerializeWithOptions(&b, "text/turtle", SerializeOptions{}))
+	parsed = NewGraph(testUri)
+	require.NoError(t, parsed.Parse(strings.NewReader(b.String()), "text/turtle"))
+	assert.True(t, g.Equal(parsed))
+}
+
+func TestSerializeWithOptionsJSONLD(t *testing.T) {
+	g := serializeOptionsGraph()
+	var b strings.Builder
+	require.NoError(t, g.SerializeWithOptions(&b, "application/ld+json", SerializeOptions{
+		Pretty:   true,
+		SortKeys: true,
+		BaseIRI:  "http://example.org/doc",
+	}))
+	assert.Contains(t, b.String(), "\n  \"@context\": {\n    \"@base\": \"http://example.org/doc\",")
+	assert.Contains(t, b.String(), `"@id": "#a"`)
+	assert.Contains(t, b.String(), `"@id": "http://other.example/c"`)
+
+	parsed := NewGraph("http://elsewhere.example/")
+	require.NoError(t, parsed.Parse(strings.NewReader(b.String()), "application/ld+json"))
+	assert.True(t, g.Equal(parsed))
+
+	b.Reset()
+	require.NoError(t, g.SerializeWithOptions(&b, "application/ld+json", SerializeOptions{
+		BaseIRI: "http://example.org/doc",
+		Context: &JSONLDContext{Vocab: nsFOAF},
+	}))
+	assert.Contains(t, b.String(), `"knows":{"@id":"#b"}`)
+	parsed = NewGraph(testUri)
+	require.NoError(t, parsed.Parse(strings.NewReader(b.String()), "application/ld+json"))
+	assert.True(t, g.Equal(parsed))
+}
+
+func TestRelativeIRI(t *testing.T) {
+	for _, c := range []struct {
+		iri, base, rel string
+		ok             bool
+	}{
+		{"http://example.org/doc#a", "http://example.org/doc", "#a", true},
+		{"http://example.org/dir/a/b", "http://example.org/dir/", "a/b", true},
+		{"http://example.org/dir/", "http://example.org/dir/", "", true},
+		{"http://example.org/docs/a", "http://example.org/doc", "", false},
+		{"http://example.org/dir/a:b", "http://example.org/dir/", "", false},
+		{"http://other.example/a", "http://example.org/", "", false},
+	} {
+		rel, ok := relativeIRI(c.iri, c.base)
+		assert.Equal(t, c.ok, ok, c.iri)
+		assert.Equal(t, c.rel, rel, c.iri)
+	}
+}
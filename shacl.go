@@ -0,0 +1,222 @@
+package rdf2go
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+const nsSH = "http://www.w3.org/ns/shacl#"
+
+// NodeShape describes a SHACL node shape, as used to extract the nodes it
+// targets from a data graph
+type NodeShape struct {
+	URI         string
+	TargetClass []string
+	TargetNode  []string
+	Properties  []*PropertyShape
+}
+
+// PropertyShape describes a property of a SHACL node shape
+type PropertyShape struct {
+	// Path is the IRI of the predicate, which is followed from the object to
+	// the subject if Inverse is set
+	Path    string
+	Inverse bool
+	// Name is the sh:name of the property, or the local name of its path
+	Name     string
+	MinCount int
+	// MaxCount is 0 when the number of values is not limited
+	MaxCount int
+	Datatype string
+	Class    string
+	// Node is the shape of the values, from sh:node, if any
+	Node *NodeShape
+}
+
+// NodeShape reads the SHACL node shape with the given URI (or blank node)
+// from the graph, along with the shapes its properties refer to with
+// sh:node. Property shapes whose path is not a predicate or an
+// sh:inversePath are left out.
+func (g *Graph) NodeShape(shape Term) *NodeShape {
+	return g.nodeShape(shape, make(map[string]*NodeShape))
+}
+
+func (g *Graph) nodeShape(shape Term, shapes map[string]*NodeShape) *NodeShape {
+	key := encodeTerm(shape)
+	if s, ok := shapes[key]; ok {
+		return s
+	}
+	s := &NodeShape{
+		URI:         shape.RawValue(),
+		TargetClass: g.resourceValues(shape, nsSH+"targetClass"),
+		TargetNode:  g.resourceValues(shape, nsSH+"targetNode"),
+	}
+	shapes[key] = s
+	for _, t := range g.All(shape, NewResource(nsSH+"property"), nil) {
+		if p := g.propertyShape(t.Object, shapes); p != nil {
+			s.Properties = append(s.Properties, p)
+		}
+	}
+	sort.Slice(s.Properties, func(i, j int) bool { return s.Properties[i].Name < s.Properties[j].Name })
+	return s
+}
+
+func (g *Graph) propertyShape(s Term, shapes map[string]*NodeShape) *PropertyShape {
+	path := g.One(s, NewResource(nsSH+"path"), nil)
+	if path == nil {
+		return nil
+	}
+	p := &PropertyShape{
+		Datatype: g.value(s, nsSH+"datatype"),
+		Class:    g.value(s, nsSH+"class"),
+	}
+	switch o := path.Object.(type) {
+	case *Resource:
+		p.Path = o.URI
+	case *BlankNode:
+		inverse := g.One(o, NewResource(nsSH+"inversePath"), nil)
+		if inverse == nil {
+			return nil
+		}
+		if _, ok := inverse.Object.(*Resource); !ok {
+			return nil
+		}
+		p.Path, p.Inverse = inverse.Object.RawValue(), true
+	default:
+		return nil
+	}
+	p.Name = g.value(s, nsSH+"name")
+	if len(p.Name) == 0 {
+		_, p.Name = splitPrefix(p.Path)
+	}
+	p.MinCount, _ = strconv.Atoi(g.value(s, nsSH+"minCount"))
+	p.MaxCount, _ = strconv.Atoi(g.value(s, nsSH+"maxCount"))
+	if node := g.One(s, NewResource(nsSH+"node"), nil); node != nil {
+		p.Node = g.nodeShape(node.Object, shapes)
+	}
+	return p
+}
+
+// Targets returns the nodes of the data targeted by the shape, through
+// sh:targetClass (including the instances of its sub classes declared in
+// the data) and sh:targetNode, sorted
+func (s *NodeShape) Targets(data *Graph) []Term {
+	var nodes []Term
+	for _, uri := range s.TargetNode {
+		nodes = appendUnique(nodes, NewResource(uri))
+	}
+	rdfType := NewResource(nsRDF + "type")
+	for _, uri := range s.TargetClass {
+		class := NewResource(uri)
+		classes := append([]Term{class}, data.Closure(class, NewResource(nsRDFS+"subClassOf"), true)...)
+		for _, c := range classes {
+			for _, t := range data.All(nil, rdfType, c) {
+				nodes = appendUnique(nodes, t.Subject)
+			}
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return encodeTerm(nodes[i]) < encodeTerm(nodes[j]) })
+	return nodes
+}
+
+// Extract returns a map for each node of the data targeted by the shape,
+// holding the node under "@id" and the values of each property under its
+// name. Properties with a MaxCount of 1 hold a single value, others a list.
+// Resources and blank nodes are written as their IRI or _:label, unless the
+// property has a node shape, in which case they are extracted with it.
+// Literals are converted to numbers and booleans according to their
+// datatype. The other constraints of the shape are not checked, use a SHACL
+// validator for that.
+func (s *NodeShape) Extract(data *Graph) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, node := range s.Targets(data) {
+		records = append(records, s.extractNode(data, node, make(map[string]bool)))
+	}
+	return records
+}
+
+// Decode extracts the nodes targeted by the shape, see Extract, and decodes
+// them into v, which is typically a pointer to a slice of structs whose
+// fields are tagged with the property names, e.g. `json:"name"`.
+func (s *NodeShape) Decode(data *Graph, v interface{}) error {
+	b, err := json.Marshal(s.Extract(data))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (s *NodeShape) extractNode(data *Graph, node Term, visited map[string]bool) map[string]interface{} {
+	record := map[string]interface{}{"@id": shapeNodeID(node)}
+	key := encodeTerm(node)
+	if visited[key] {
+		return record
+	}
+	visited[key] = true
+	defer delete(visited, key)
+	for _, p := range s.Properties {
+		var triples []*Triple
+		if p.Inverse {
+			triples = data.All(nil, NewResource(p.Path), node)
+		} else {
+			triples = data.All(node, NewResource(p.Path), nil)
+		}
+		var terms []Term
+		for _, t := range triples {
+			if p.Inverse {
+				terms = append(terms, t.Subject)
+			} else {
+				terms = append(terms, t.Object)
+			}
+		}
+		sort.Slice(terms, func(i, j int) bool { return encodeTerm(terms[i]) < encodeTerm(terms[j]) })
+		var values []interface{}
+		for _, o := range terms {
+			values = append(values, p.value(data, o, visited))
+		}
+		switch {
+		case len(values) == 0:
+		case p.MaxCount == 1:
+			record[p.Name] = values[0]
+		default:
+			record[p.Name] = values
+		}
+	}
+	return record
+}
+
+// value converts a value of the property to a Go value
+func (p *PropertyShape) value(data *Graph, o Term, visited map[string]bool) interface{} {
+	literal, ok := o.(*Literal)
+	if !ok {
+		if p.Node != nil {
+			return p.Node.extractNode(data, o, visited)
+		}
+		return shapeNodeID(o)
+	}
+	if literal.Datatype == nil {
+		return literal.Value
+	}
+	switch literal.Datatype.RawValue() {
+	case nsXSD + "boolean":
+		if b, err := strconv.ParseBool(literal.Value); err == nil {
+			return b
+		}
+	case nsXSD + "integer", nsXSD + "int", nsXSD + "long", nsXSD + "decimal", nsXSD + "double", nsXSD + "float":
+		// json.Number must hold a valid JSON number, which excludes forms
+		// such as "+1" or "INF"
+		if _, err := strconv.ParseFloat(literal.Value, 64); err == nil && json.Valid([]byte(literal.Value)) {
+			return json.Number(literal.Value)
+		}
+	}
+	return literal.Value
+}
+
+// shapeNodeID returns the IRI of a resource, or the _:label of a blank node
+func shapeNodeID(t Term) string {
+	if b, ok := t.(*BlankNode); ok {
+		return b.String()
+	}
+	return t.RawValue()
+}
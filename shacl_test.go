@@ -0,0 +1,68 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testShapes = `@prefix sh: <http://www.w3.org/ns/shacl#> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<#PersonShape> a sh:NodeShape ;
+  sh:targetClass foaf:Person ;
+  sh:property [ sh:path foaf:name ; sh:maxCount 1 ; sh:datatype xsd:string ] ;
+  sh:property [ sh:path foaf:age ; sh:maxCount 1 ] ;
+  sh:property [ sh:path foaf:knows ; sh:name "friends" ; sh:node <#PersonShape> ] ;
+  sh:property [ sh:path [ sh:inversePath foaf:member ] ; sh:name "groups" ] .
+`
+
+func TestNodeShapeExtract(t *testing.T) {
+	shapes := NewGraph(testUri)
+	require.NoError(t, shapes.Parse(strings.NewReader(testShapes), "text/turtle"))
+	shape := shapes.NodeShape(NewResource(testUri + "#PersonShape"))
+	require.Len(t, shape.Properties, 4)
+	assert.Equal(t, []string{"age", "friends", "groups", "name"},
+		[]string{shape.Properties[0].Name, shape.Properties[1].Name, shape.Properties[2].Name, shape.Properties[3].Name})
+	assert.True(t, shape.Properties[2].Inverse)
+	assert.Equal(t, 1, shape.Properties[3].MaxCount)
+	assert.Same(t, shape, shape.Properties[1].Node)
+
+	data := NewGraph(testUri)
+	require.NoError(t, data.Parse(strings.NewReader(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<#alice> a foaf:Person ; foaf:name "Alice" ; foaf:age "42"^^xsd:integer ; foaf:knows <#bob> .
+<#bob> a foaf:Person ; foaf:name "Bob" ; foaf:knows <#alice> .
+<#team> foaf:member <#alice> .
+<#doc> foaf:name "Not a person" .`), "text/turtle"))
+
+	records := shape.Extract(data)
+	require.Len(t, records, 2)
+	assert.Equal(t, testUri+"#alice", records[0]["@id"])
+	assert.Equal(t, "Alice", records[0]["name"])
+	assert.Equal(t, []interface{}{testUri + "#team"}, records[0]["groups"])
+	friends := records[0]["friends"].([]interface{})
+	require.Len(t, friends, 1)
+	bob := friends[0].(map[string]interface{})
+	assert.Equal(t, "Bob", bob["name"])
+	// the cycle back to alice stops at her id
+	assert.Equal(t, []interface{}{map[string]interface{}{"@id": testUri + "#alice"}}, bob["friends"])
+
+	type person struct {
+		ID      string `json:"@id"`
+		Name    string `json:"name"`
+		Age     int    `json:"age"`
+		Friends []struct {
+			Name string `json:"name"`
+		} `json:"friends"`
+	}
+	var people []person
+	require.NoError(t, shape.Decode(data, &people))
+	require.Len(t, people, 2)
+	assert.Equal(t, 42, people[0].Age)
+	assert.Equal(t, "Bob", people[0].Friends[0].Name)
+	assert.Equal(t, "Bob", people[1].Name)
+	assert.Equal(t, "Alice", people[1].Friends[0].Name)
+}
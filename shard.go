@@ -0,0 +1,168 @@
+package rdf2go
+
+import (
+	"hash/fnv"
+	"runtime"
+	"sync"
+)
+
+// shardedStore is a Store splitting triples across several map stores by the
+// hash of their subject. Each shard has its own lock, so writes to different
+// subjects do not contend, and scans of all the shards run in parallel.
+type shardedStore struct {
+	shards []*mapStore
+}
+
+// NewShardedStore creates a Store splitting triples by subject across n
+// shards, or across GOMAXPROCS shards if n is not greater than zero. It suits
+// graphs that are read and written by many goroutines at once, e.g.:
+//
+//	g := NewGraphWithStore(uri, NewShardedStore(0))
+func NewShardedStore(n int) Store {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	s := &shardedStore{shards: make([]*mapStore, n)}
+	for i := range s.shards {
+		s.shards[i] = NewMapStore().(*mapStore)
+	}
+	return s
+}
+
+// shard returns the shard holding the triples of a subject
+func (s *shardedStore) shard(subject Term) *mapStore {
+	h := fnv.New32a()
+	h.Write([]byte(encodeTerm(subject)))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Len returns the number of triples in the store
+func (s *shardedStore) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Add is used to add a Triple object to the store
+func (s *shardedStore) Add(t *Triple) {
+	s.shard(t.Subject).Add(t)
+}
+
+// BulkAdd adds the triples to their shards, filling the shards in parallel
+func (s *shardedStore) BulkAdd(triples []*Triple) {
+	parts := make(map[*mapStore][]*Triple)
+	for _, t := range triples {
+		shard := s.shard(t.Subject)
+		parts[shard] = append(parts[shard], t)
+	}
+	var wg sync.WaitGroup
+	for shard, part := range parts {
+		wg.Add(1)
+		go func(shard *mapStore, part []*Triple) {
+			defer wg.Done()
+			shard.BulkAdd(part)
+		}(shard, part)
+	}
+	wg.Wait()
+}
+
+// Count returns the number of triples matching a pattern, only looking in
+// the shard of the subject when it is given
+func (s *shardedStore) Count(subject Term, p Term, o Term) int {
+	if subject != nil {
+		return s.shard(subject).Count(subject, p, o)
+	}
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Count(subject, p, o)
+	}
+	return n
+}
+
+// AddIfAbsent adds the triple made of subject, p, o to its shard unless an
+// equal one is already there
+func (s *shardedStore) AddIfAbsent(subject Term, p Term, o Term) (*Triple, bool) {
+	return s.shard(subject).AddIfAbsent(subject, p, o)
+}
+
+// Remove is used to remove a Triple object
+func (s *shardedStore) Remove(t *Triple) {
+	s.shard(t.Subject).Remove(t)
+}
+
+// One returns one triple based on a triple pattern of S, P, O objects
+func (s *shardedStore) One(subject Term, p Term, o Term) *Triple {
+	if subject != nil {
+		return s.shard(subject).One(subject, p, o)
+	}
+	for _, shard := range s.shards {
+		if t := shard.One(subject, p, o); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// All is used to return all triples that match a given pattern of S, P, O
+// objects. Patterns without a subject are matched against all the shards in
+// parallel.
+func (s *shardedStore) All(subject Term, p Term, o Term) []*Triple {
+	if subject != nil {
+		return s.shard(subject).All(subject, p, o)
+	}
+	results := make([][]*Triple, len(s.shards))
+	var wg sync.WaitGroup
+	for i, shard := range s.shards {
+		wg.Add(1)
+		go func(i int, shard *mapStore) {
+			defer wg.Done()
+			results[i] = shard.All(subject, p, o)
+		}(i, shard)
+	}
+	wg.Wait()
+	var triples []*Triple
+	for _, r := range results {
+		triples = append(triples, r...)
+	}
+	return triples
+}
+
+// IterTriples provides a channel containing all the triples in the store.
+// Note that the returned channel is already closed.
+func (s *shardedStore) IterTriples() (ch chan *Triple) {
+	parts := make([]chan *Triple, len(s.shards))
+	n := 0
+	for i, shard := range s.shards {
+		parts[i] = shard.IterTriples()
+		n += len(parts[i])
+	}
+	ch = make(chan *Triple, n)
+	for _, part := range parts {
+		for t := range part {
+			ch <- t
+		}
+	}
+	close(ch)
+	return ch
+}
+
+// Triples returns all the triples in the store in a new slice
+func (s *shardedStore) Triples() []*Triple {
+	var triples []*Triple
+	for _, shard := range s.shards {
+		triples = append(triples, shard.Triples()...)
+	}
+	return triples
+}
+
+// Snapshot returns a store sharing the triples of each shard until either one
+// is modified
+func (s *shardedStore) Snapshot() Store {
+	snapshot := &shardedStore{shards: make([]*mapStore, len(s.shards))}
+	for i, shard := range s.shards {
+		snapshot.shards[i] = shard.Snapshot().(*mapStore)
+	}
+	return snapshot
+}
@@ -0,0 +1,47 @@
+package rdf2go
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedStore(t *testing.T) {
+	g := NewGraphWithStore(testUri, NewShardedStore(4))
+	require.NoError(t, g.Parse(strings.NewReader(largeTurtle(100)), "text/turtle"))
+	assert.Equal(t, 200, g.Len())
+
+	p7 := NewResource(testUri + "#p7")
+	name := NewResource("http://xmlns.com/foaf/0.1/name")
+	assert.Len(t, g.All(p7, nil, nil), 2)
+	assert.Equal(t, "\"Person 7\"", g.One(p7, name, nil).Object.String())
+	assert.Len(t, g.All(nil, name, nil), 100)
+	assert.NotNil(t, g.One(nil, nil, NewLiteral("Person 42")))
+
+	snapshot := g.Snapshot()
+	g.Remove(g.One(p7, name, nil))
+	assert.Equal(t, 199, g.Len())
+	assert.Equal(t, 200, snapshot.Len())
+	assert.Equal(t, 199, len(g.IterTriples()))
+}
+
+func TestShardedStoreConcurrentWrites(t *testing.T) {
+	g := NewGraphWithStore(testUri, NewShardedStore(0))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g.AddTriple(NewResource(fmt.Sprintf("#s%d-%d", i, j)), NewResource("#p"), NewLiteral("o"))
+				g.All(nil, NewResource("#p"), nil)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 800, g.Len())
+}
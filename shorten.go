@@ -0,0 +1,77 @@
+package rdf2go
+
+// Shortener abbreviates IRIs in the outputs meant for humans: Dump, the
+// visualization exporters (DOT, GraphML, Cytoscape and Mermaid) and error
+// messages
+type Shortener interface {
+	// Shorten returns the abbreviated form of an IRI, or the IRI itself
+	Shorten(iri string) string
+}
+
+// DefaultShortener is the Shortener used when none is given, which writes
+// IRIs of the common vocabularies (rdf, rdfs, foaf, schema, etc.) as
+// prefixed names
+var DefaultShortener Shortener = PrefixShortener(commonPrefixes)
+
+// PrefixShortener maps prefixes to namespaces, and writes IRIs as prefixed
+// names using the longest matching namespace
+type PrefixShortener map[string]string
+
+// Shorten returns the prefixed name of an IRI, or the IRI itself if no
+// namespace matches
+func (s PrefixShortener) Shorten(iri string) string {
+	return shortenIRI(iri, s)
+}
+
+// LastSegmentShortener writes IRIs as their fragment or, if they have none,
+// as the last segment of their path, e.g. "name" for
+// http://xmlns.com/foaf/0.1/name
+type LastSegmentShortener struct{}
+
+// Shorten returns the last segment of an IRI, or the IRI itself if it ends
+// with a separator
+func (LastSegmentShortener) Shorten(iri string) string {
+	if _, name := splitPrefix(iri); len(name) > 0 {
+		return name
+	}
+	return iri
+}
+
+// LabelShortener writes IRIs as the label the graph gives them with
+// rdfs:label, skos:prefLabel, foaf:name, schema:name or dct:title, and
+// uses Fallback, or DefaultShortener if it is nil, for the others
+type LabelShortener struct {
+	Graph    *Graph
+	Fallback Shortener
+}
+
+// Shorten returns the label of an IRI, or its form given by the fallback
+func (s LabelShortener) Shorten(iri string) string {
+	if label := s.Graph.label(NewResource(iri)); len(label) > 0 {
+		return label
+	}
+	if s.Fallback != nil {
+		return s.Fallback.Shorten(iri)
+	}
+	return DefaultShortener.Shorten(iri)
+}
+
+// ShortenerFunc turns a function into a Shortener
+type ShortenerFunc func(iri string) string
+
+// Shorten calls f(iri)
+func (f ShortenerFunc) Shorten(iri string) string {
+	return f(iri)
+}
+
+// optionShortener returns the Shortener of the options of an output: s if
+// set, the prefixes if set, or DefaultShortener
+func optionShortener(s Shortener, prefixes map[string]string) Shortener {
+	switch {
+	case s != nil:
+		return s
+	case prefixes != nil:
+		return PrefixShortener(prefixes)
+	}
+	return DefaultShortener
+}
@@ -0,0 +1,46 @@
+package rdf2go
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShorteners(t *testing.T) {
+	assert.Equal(t, "foaf:name", DefaultShortener.Shorten(nsFOAF+"name"))
+	assert.Equal(t, "ex:a", PrefixShortener{"ex": "http://example.org/"}.Shorten("http://example.org/a"))
+
+	last := LastSegmentShortener{}
+	assert.Equal(t, "name", last.Shorten(nsFOAF+"name"))
+	assert.Equal(t, "type", last.Shorten(nsRDF+"type"))
+	assert.Equal(t, "http://example.org/", last.Shorten("http://example.org/"))
+
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("http://example.org/alice"), NewResource(nsRDFS+"label"), NewLiteral("Alice"))
+	labels := LabelShortener{Graph: g}
+	assert.Equal(t, "Alice", labels.Shorten("http://example.org/alice"))
+	assert.Equal(t, "foaf:knows", labels.Shorten(nsFOAF+"knows"))
+	labels.Fallback = last
+	assert.Equal(t, "knows", labels.Shorten(nsFOAF+"knows"))
+
+	upper := ShortenerFunc(strings.ToUpper)
+	assert.Equal(t, "HTTP://A", upper.Shorten("http://a"))
+}
+
+func TestShortenerOptions(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, exportTestGraph().ExportDOT(&buf, ExportOptions{Shortener: LastSegmentShortener{}}))
+	assert.Contains(t, buf.String(), `n0 [label="alice", shape=ellipse];`)
+	assert.Contains(t, buf.String(), `n0 -> n1 [label="knows"];`)
+
+	// the shortener replaces the prefixes
+	buf.Reset()
+	g := exportTestGraph()
+	g.AddTriple(NewResource("http://example.org/bob"), NewResource(nsRDFS+"label"), NewLiteral("Bob"))
+	opts := DumpOptions{Compact: true, Prefixes: map[string]string{"ex": "http://example.org/"}, Shortener: LabelShortener{Graph: g}}
+	assert.NoError(t, g.Dump(&buf, opts))
+	assert.Contains(t, buf.String(), "Alice \"A\"  foaf:knows  Bob\n")
+	assert.NotContains(t, buf.String(), "ex:")
+}
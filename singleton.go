@@ -0,0 +1,74 @@
+package rdf2go
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AddSingleton adds the statement (s, p, o) using a singleton property: a new
+// property, unique to this statement, is minted from p and linked to it with
+// rdf:singletonPropertyOf. The singleton property is returned, so that the
+// statement can be annotated by adding triples about it.
+func (g *Graph) AddSingleton(s Term, p Term, o Term) Term {
+	sep := "#"
+	if strings.Contains(p.RawValue(), "#") {
+		sep = "-"
+	}
+	prefix := p.RawValue() + sep
+	g.mintMu.Lock()
+	first := 1
+	if g.mintNext[prefix] == 0 {
+		// start after the singleton properties of p already in the graph,
+		// looked up once
+		for _, t := range g.All(nil, NewResource(nsRDF+"singletonPropertyOf"), p) {
+			n, err := strconv.Atoi(strings.TrimPrefix(t.Subject.RawValue(), prefix))
+			if err == nil && n >= first {
+				first = n + 1
+			}
+		}
+	}
+	uri := g.mintCounter(prefix, first)
+	g.mintMu.Unlock()
+	sp := NewResource(uri)
+	g.AddTriple(sp, NewResource(nsRDF+"singletonPropertyOf"), p)
+	g.AddTriple(s, sp, o)
+	return sp
+}
+
+// Singletons returns the singleton properties used to state triples matching
+// the pattern (s, p, o), where p is the generic property
+func (g *Graph) Singletons(s Term, p Term, o Term) []Term {
+	var singletons []Term
+	for _, t := range g.All(nil, NewResource(nsRDF+"singletonPropertyOf"), p) {
+		if g.One(s, t.Subject, o) != nil {
+			singletons = append(singletons, t.Subject)
+		}
+	}
+	return singletons
+}
+
+// SingletonStatement returns the statement made with a singleton property,
+// using the generic property as predicate, or nil if sp is not used
+func (g *Graph) SingletonStatement(sp Term) *Triple {
+	p := g.One(sp, NewResource(nsRDF+"singletonPropertyOf"), nil)
+	if p == nil {
+		return nil
+	}
+	t := g.One(nil, sp, nil)
+	if t == nil {
+		return nil
+	}
+	return NewTriple(t.Subject, p.Object, t.Object)
+}
+
+// SingletonAnnotations returns the triples annotating the statement made with
+// a singleton property
+func (g *Graph) SingletonAnnotations(sp Term) []*Triple {
+	var annotations []*Triple
+	for _, t := range g.All(sp, nil, nil) {
+		if t.Predicate.RawValue() != nsRDF+"singletonPropertyOf" {
+			annotations = append(annotations, t)
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,65 @@
+package rdf2go
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingletonProperties(t *testing.T) {
+	g := NewGraph(testUri)
+	alice, bob := NewResource("http://example.org/alice"), NewResource("http://example.org/bob")
+	knows := NewResource(nsFOAF + "knows")
+
+	sp := g.AddSingleton(alice, knows, bob)
+	assert.Equal(t, NewResource(nsFOAF+"knows#1"), sp)
+	g.AddTriple(sp, NewResource(nsPROV+"generatedAtTime"), NewLiteral("2020-01-01"))
+	sp2 := g.AddSingleton(bob, knows, alice)
+	assert.Equal(t, NewResource(nsFOAF+"knows#2"), sp2)
+	assert.Equal(t, NewResource(nsRDF+"type-1"), g.AddSingleton(alice, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person")))
+
+	assert.Equal(t, []Term{sp}, g.Singletons(alice, knows, nil))
+	assert.Equal(t, 2, len(g.Singletons(nil, knows, nil)))
+	assert.Equal(t, 3, len(g.Singletons(nil, nil, nil)))
+	assert.Empty(t, g.Singletons(alice, knows, alice))
+
+	stmt := g.SingletonStatement(sp)
+	assert.True(t, stmt.Equal(NewTriple(alice, knows, bob)))
+	assert.Nil(t, g.SingletonStatement(knows))
+
+	annotations := g.SingletonAnnotations(sp)
+	assert.Equal(t, 1, len(annotations))
+	assert.Equal(t, "2020-01-01", annotations[0].Object.RawValue())
+}
+
+func TestSingletonPropertiesExisting(t *testing.T) {
+	g := NewGraph(testUri)
+	alice, bob := NewResource("http://example.org/alice"), NewResource("http://example.org/bob")
+	knows := NewResource(nsFOAF + "knows")
+	g.AddTriple(NewResource(nsFOAF+"knows#7"), NewResource(nsRDF+"singletonPropertyOf"), knows)
+
+	assert.Equal(t, NewResource(nsFOAF+"knows#8"), g.AddSingleton(alice, knows, bob))
+	assert.Equal(t, NewResource(nsFOAF+"knows#9"), g.AddSingleton(bob, knows, alice))
+}
+
+func TestSingletonPropertiesConcurrent(t *testing.T) {
+	g := NewGraph(testUri)
+	knows := NewResource(nsFOAF + "knows")
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g.AddSingleton(NewResource("http://example.org/"+strconv.Itoa(i)), knows, NewResource("http://example.org/bob"))
+		}(i)
+	}
+	wg.Wait()
+
+	sps := g.Singletons(nil, knows, nil)
+	assert.Equal(t, 50, len(sps))
+	for _, sp := range sps {
+		assert.Equal(t, 1, len(g.All(sp, nil, nil)))
+	}
+}
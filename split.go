@@ -0,0 +1,51 @@
+package rdf2go
+
+// SplitBySubject splits the graph into one graph per IRI subject, e.g. to
+// publish each entity as its own document or LDP resource, keyed and named
+// after the IRI. The graph of a subject holds its triples along with those
+// of the blank nodes reachable from it, which are copied into each graph
+// referring to them. Blank nodes that no IRI subject refers to get a graph
+// of their own, keyed by their _:label, unless they are reachable from such
+// a blank node sorted before them. The graphs keep the prefixes of g.
+func (g *Graph) SplitBySubject() map[string]*Graph {
+	bySubject := make(map[string][]*Triple)
+	var subjects []Term
+	for triples := range g.IterBySubject() {
+		bySubject[encodeTerm(triples[0].Subject)] = triples
+		subjects = append(subjects, triples[0].Subject)
+	}
+	docs := make(map[string]*Graph)
+	// blank nodes reached from another subject, which IRI subjects are
+	// sorted before
+	reached := make(map[string]bool)
+	for _, s := range subjects {
+		key := encodeTerm(s)
+		name := s.RawValue()
+		if _, ok := s.(*BlankNode); ok {
+			if reached[key] {
+				continue
+			}
+			name = s.String()
+		}
+		doc := NewGraph(name)
+		doc.addPrefixes(g.prefixes)
+		seen := map[string]bool{key: true}
+		queue := []Term{s}
+		for len(queue) > 0 {
+			triples := bySubject[encodeTerm(queue[0])]
+			queue = queue[1:]
+			doc.BulkAdd(triples)
+			for _, t := range triples {
+				if _, ok := t.Object.(*BlankNode); !ok {
+					continue
+				}
+				if k := encodeTerm(t.Object); !seen[k] {
+					seen[k], reached[k] = true, true
+					queue = append(queue, t.Object)
+				}
+			}
+		}
+		docs[name] = doc
+	}
+	return docs
+}
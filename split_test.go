@@ -0,0 +1,53 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBySubject(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> foaf:name "Alice" ;
+	foaf:knows <http://example.org/bob> ;
+	foaf:address [ foaf:city "Paris" ; foaf:geo [ foaf:lat "48.8" ] ] .
+<http://example.org/bob> foaf:name "Bob" ;
+	foaf:account _:shared .
+<http://example.org/carol> foaf:account _:shared .
+_:shared foaf:nick "sh" .
+_:orphan foaf:name "Nobody" ; foaf:knows _:other .
+_:other foaf:knows _:orphan .`, "text/turtle"))
+
+	docs := g.SplitBySubject()
+	require.Len(t, docs, 4)
+	alice := docs["http://example.org/alice"]
+	require.NotNil(t, alice)
+	assert.Equal(t, "http://example.org/alice", alice.URI())
+	assert.Equal(t, 6, alice.Len())
+	assert.Equal(t, "http://xmlns.com/foaf/0.1/", alice.Prefixes()["foaf"])
+	assert.NotNil(t, alice.One(nil, NewResource(nsFOAF+"lat"), NewLiteral("48.8")))
+	assert.Nil(t, alice.One(NewResource("http://example.org/bob"), nil, nil))
+
+	bob, carol := docs["http://example.org/bob"], docs["http://example.org/carol"]
+	assert.Equal(t, 3, bob.Len())
+	assert.Equal(t, 2, carol.Len())
+	assert.NotNil(t, carol.One(nil, NewResource(nsFOAF+"nick"), NewLiteral("sh")))
+
+	var orphans *Graph
+	for name, doc := range docs {
+		if name[0] == '_' {
+			orphans = doc
+		}
+	}
+	require.NotNil(t, orphans)
+	assert.Equal(t, 3, orphans.Len())
+
+	total := 0
+	for _, doc := range docs {
+		total += doc.Len()
+	}
+	assert.Equal(t, g.Len()+1, total)
+	assert.Empty(t, NewGraph(testUri).SplitBySubject())
+}
@@ -0,0 +1,304 @@
+package rdf2go
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Store is the interface implemented by the triple storage backing a Graph.
+// The default implementation keeps triples in a map; other implementations
+// (indexed, persistent, federated, ...) can be plugged in with
+// NewGraphWithStore without changing how callers use the Graph.
+type Store interface {
+	// Add adds a triple to the store
+	Add(t *Triple)
+	// Remove removes a triple from the store
+	Remove(t *Triple)
+	// One returns one triple matching a pattern of S, P, O objects, where nil matches anything
+	One(s Term, p Term, o Term) *Triple
+	// All returns all the triples matching a pattern of S, P, O objects
+	All(s Term, p Term, o Term) []*Triple
+	// IterTriples returns a closed channel containing all the triples in the store
+	IterTriples() chan *Triple
+	// Len returns the number of triples in the store
+	Len() int
+}
+
+// bulkAdder is implemented by stores that can add many triples more
+// efficiently than one at a time
+type bulkAdder interface {
+	BulkAdd(triples []*Triple)
+}
+
+// counter is implemented by stores that can count the triples matching a
+// pattern without collecting them
+type counter interface {
+	Count(s Term, p Term, o Term) int
+}
+
+// absentAdder is implemented by stores that can look for a triple and add it
+// if it is missing in a single step
+type absentAdder interface {
+	AddIfAbsent(s Term, p Term, o Term) (*Triple, bool)
+}
+
+// lister is implemented by stores that can return all their triples as a
+// slice without going through IterTriples
+type lister interface {
+	Triples() []*Triple
+}
+
+// snapshotter is implemented by stores that can create cheap snapshots
+type snapshotter interface {
+	Snapshot() Store
+}
+
+// mapStore is the default Store, keeping triples in a map keyed by their
+// value, so that a triple equal to one already in the store is not added
+// again. It is safe for concurrent use, so snapshots can be taken while
+// other goroutines write.
+type mapStore struct {
+	mu      sync.RWMutex
+	triples map[string]*Triple
+	// shared is set when the triples map is also referenced by a snapshot,
+	// in which case it must be copied before being modified
+	shared bool
+}
+
+// NewMapStore creates the default, map based, Store
+func NewMapStore() Store {
+	return &mapStore{
+		triples: make(map[string]*Triple),
+	}
+}
+
+// tripleKey returns the key of a triple in a mapStore, which is the same for
+// equal triples, and differs for different ones
+func tripleKey(s Term, p Term, o Term) string {
+	buf := make([]byte, 0, 64)
+	buf = appendTermKey(buf, s)
+	buf = appendTermKey(buf, p)
+	buf = appendTermKey(buf, o)
+	return string(buf)
+}
+
+// appendTermKey appends the key of a term to buf. Terms are written as
+// their kind followed by their length-prefixed parts, so that no value can
+// be mistaken for a separator. As in encodeTerm, xsd:string literals have
+// the key of plain literals unless DistinctStringLiterals is set.
+func appendTermKey(buf []byte, t Term) []byte {
+	field := func(buf []byte, v string) []byte {
+		buf = strconv.AppendInt(buf, int64(len(v)), 10)
+		return append(append(buf, ':'), v...)
+	}
+	switch t := t.(type) {
+	case *Resource:
+		return field(append(buf, 'I'), t.URI)
+	case *BlankNode:
+		return field(append(buf, 'B'), t.ID)
+	case *Literal:
+		buf = field(append(buf, 'L'), t.Value)
+		buf = field(buf, t.Language)
+		if datatype := t.datatype(); datatype != nil {
+			return appendTermKey(buf, datatype)
+		}
+		return append(buf, '-')
+	}
+	return append(buf, '-')
+}
+
+// Len returns the number of triples in the store
+func (m *mapStore) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.triples)
+}
+
+// One returns one triple based on a triple pattern of S, P, O objects. It
+// stops at the first matching triple, and looks up complete patterns by key.
+func (m *mapStore) One(s Term, p Term, o Term) *Triple {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if s != nil && p != nil && o != nil {
+		return m.triples[tripleKey(s, p, o)]
+	}
+	for _, triple := range m.triples {
+		if matchTriple(triple, s, p, o) {
+			return triple
+		}
+	}
+	return nil
+}
+
+// Count returns the number of triples matching a pattern of S, P, O objects,
+// where nil matches anything, without collecting them
+func (m *mapStore) Count(s Term, p Term, o Term) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	switch {
+	case s == nil && p == nil && o == nil:
+		return len(m.triples)
+	case s != nil && p != nil && o != nil:
+		if _, ok := m.triples[tripleKey(s, p, o)]; ok {
+			return 1
+		}
+		return 0
+	}
+	n := 0
+	for _, triple := range m.triples {
+		if matchTriple(triple, s, p, o) {
+			n++
+		}
+	}
+	return n
+}
+
+// IterTriples provides a channel containing all the triples in the store.
+// Note that the returned channel is already closed.
+func (m *mapStore) IterTriples() (ch chan *Triple) {
+	// This function returns a channel rather than a slice for backwards compatibility.
+	// It does not use a goroutine to populate the channel because that can trigger Go's 'concurrent map misuse'
+	// detector, and would have little performance benefit.
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ch = make(chan *Triple, len(m.triples))
+	for _, triple := range m.triples {
+		ch <- triple
+	}
+	close(ch)
+	return ch
+}
+
+// Triples returns all the triples in the store in a new slice
+func (m *mapStore) Triples() []*Triple {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	triples := make([]*Triple, 0, len(m.triples))
+	for _, triple := range m.triples {
+		triples = append(triples, triple)
+	}
+	return triples
+}
+
+// Add is used to add a Triple object to the store
+func (m *mapStore) Add(t *Triple) {
+	key := tripleKey(t.Subject, t.Predicate, t.Object)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.triples[key]; !ok {
+		m.unshare()
+		m.triples[key] = t
+	}
+}
+
+// AddIfAbsent adds the triple made of s, p, o unless an equal one is already
+// in the store, returning the triple held by the store and whether it was
+// added
+func (m *mapStore) AddIfAbsent(s Term, p Term, o Term) (*Triple, bool) {
+	key := tripleKey(s, p, o)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.triples[key]; ok {
+		return existing, false
+	}
+	m.unshare()
+	t := NewTriple(s, p, o)
+	m.triples[key] = t
+	return t, true
+}
+
+// BulkAdd is used to add a large number of Triple objects at once. Storage is
+// grown a single time up front instead of incrementally for every triple.
+func (m *mapStore) BulkAdd(triples []*Triple) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.shared || len(m.triples)+len(triples) > 2*len(m.triples) {
+		grown := make(map[string]*Triple, len(m.triples)+len(triples))
+		for key, t := range m.triples {
+			grown[key] = t
+		}
+		m.triples = grown
+		m.shared = false
+	}
+	for _, t := range triples {
+		key := tripleKey(t.Subject, t.Predicate, t.Object)
+		if _, ok := m.triples[key]; !ok {
+			m.triples[key] = t
+		}
+	}
+}
+
+// Remove is used to remove a Triple object, or the triple equal to it
+func (m *mapStore) Remove(t *Triple) {
+	key := tripleKey(t.Subject, t.Predicate, t.Object)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.triples[key]; !ok {
+		return
+	}
+	m.unshare()
+	delete(m.triples, key)
+}
+
+// Snapshot returns a store sharing the triples map until either one is modified
+func (m *mapStore) Snapshot() Store {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shared = true
+	return &mapStore{
+		triples: m.triples,
+		shared:  true,
+	}
+}
+
+// unshare copies the triples map if it is shared with a snapshot, with m.mu held
+func (m *mapStore) unshare() {
+	if !m.shared {
+		return
+	}
+	triples := make(map[string]*Triple, len(m.triples))
+	for key, t := range m.triples {
+		triples[key] = t
+	}
+	m.triples = triples
+	m.shared = false
+}
+
+// All is used to return all triples that match a given pattern of S, P, O objects
+func (m *mapStore) All(s Term, p Term, o Term) []*Triple {
+	var triples []*Triple
+	for triple := range m.IterTriples() {
+		if s != nil {
+			if p != nil {
+				if o != nil {
+					if triple.Subject.Equal(s) && triple.Predicate.Equal(p) && triple.Object.Equal(o) {
+						triples = append(triples, triple)
+					}
+				} else {
+					if triple.Subject.Equal(s) && triple.Predicate.Equal(p) {
+						triples = append(triples, triple)
+					}
+				}
+			} else {
+				if triple.Subject.Equal(s) {
+					triples = append(triples, triple)
+				}
+			}
+		} else if p != nil {
+			if o != nil {
+				if triple.Predicate.Equal(p) && triple.Object.Equal(o) {
+					triples = append(triples, triple)
+				}
+			} else {
+				if triple.Predicate.Equal(p) {
+					triples = append(triples, triple)
+				}
+			}
+		} else if o != nil {
+			if triple.Object.Equal(o) {
+				triples = append(triples, triple)
+			}
+		}
+	}
+	return triples
+}
@@ -0,0 +1,209 @@
+package rdf2go
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceStore is a minimal Store used to check that graphs work with
+// alternative implementations
+type sliceStore struct {
+	triples []*Triple
+}
+
+func (s *sliceStore) Add(t *Triple) {
+	s.triples = append(s.triples, t)
+}
+
+func (s *sliceStore) Remove(t *Triple) {
+	for i, triple := range s.triples {
+		if triple == t {
+			s.triples = append(s.triples[:i], s.triples[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *sliceStore) One(sub Term, p Term, o Term) *Triple {
+	for _, triple := range s.triples {
+		if matchTriple(triple, sub, p, o) {
+			return triple
+		}
+	}
+	return nil
+}
+
+func (s *sliceStore) All(sub Term, p Term, o Term) []*Triple {
+	var triples []*Triple
+	for _, triple := range s.triples {
+		if matchTriple(triple, sub, p, o) {
+			triples = append(triples, triple)
+		}
+	}
+	return triples
+}
+
+func (s *sliceStore) IterTriples() chan *Triple {
+	ch := make(chan *Triple, len(s.triples))
+	for _, triple := range s.triples {
+		ch <- triple
+	}
+	close(ch)
+	return ch
+}
+
+func (s *sliceStore) Len() int {
+	return len(s.triples)
+}
+
+func TestGraphWithStore(t *testing.T) {
+	store := &sliceStore{}
+	g := NewGraphWithStore(testUri, store)
+	assert.Equal(t, store, g.Store())
+
+	triple := NewTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+	g.Add(triple)
+	g.BulkAdd([]*Triple{NewTriple(NewResource("a"), NewResource("b"), NewResource("d"))})
+	assert.Equal(t, 2, g.Len())
+	assert.Equal(t, 2, len(g.All(NewResource("a"), nil, nil)))
+	assert.NotNil(t, g.One(nil, nil, NewResource("d")))
+
+	snap := g.Snapshot()
+	g.Remove(triple)
+	assert.Equal(t, 1, g.Len())
+	assert.Equal(t, 2, snap.Len())
+}
+
+func TestMapStoreSnapshot(t *testing.T) {
+	m := NewMapStore()
+	m.Add(NewTriple(NewResource("a"), NewResource("b"), NewResource("c")))
+	snap := m.(snapshotter).Snapshot()
+	m.Add(NewTriple(NewResource("a"), NewResource("b"), NewResource("d")))
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 1, snap.Len())
+}
+
+func TestSnapshotConcurrentWrites(t *testing.T) {
+	g := NewGraph(testUri)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			g.AddTriple(NewResource("a"), NewResource("b"), NewLiteral(fmt.Sprint(i)))
+		}
+		close(done)
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		snap := g.Snapshot()
+		n := snap.Len()
+		assert.Equal(t, n, len(snap.All(NewResource("a"), nil, nil)))
+	}
+	assert.Equal(t, 1000, g.Len())
+}
+
+func TestMapStoreValueKeyed(t *testing.T) {
+	g := NewGraph(testUri)
+	first, added := g.AddTripleIfAbsent(NewResource("a"), NewResource("b"), NewLiteral("c"))
+	assert.True(t, added)
+	same, added := g.AddTripleIfAbsent(NewResource("a"), NewResource("b"), NewLiteral("c"))
+	assert.False(t, added)
+	assert.True(t, first == same)
+
+	g.Add(NewTriple(NewResource("a"), NewResource("b"), NewLiteral("c")))
+	g.AddTriple(NewResource("a"), NewResource("b"), NewLiteralWithDatatype("c", NewResource(nsXSD+"string")))
+	assert.Equal(t, 1, g.Len())
+	assert.True(t, g.Contains(NewResource("a"), NewResource("b"), NewLiteral("c")))
+	assert.True(t, g.Contains(nil, NewResource("b"), nil))
+	assert.False(t, g.Contains(NewResource("a"), NewResource("b"), NewLiteral("d")))
+
+	// an equal triple removes the one held by the graph
+	g.Remove(NewTriple(NewResource("a"), NewResource("b"), NewLiteral("c")))
+	assert.Equal(t, 0, g.Len())
+	assert.False(t, g.Contains(NewResource("a"), NewResource("b"), NewLiteral("c")))
+
+	// stores that cannot add in one step fall back to a lookup
+	g = NewGraphWithStore(testUri, &sliceStore{})
+	_, added = g.AddTripleIfAbsent(NewResource("a"), NewResource("b"), NewResource("c"))
+	assert.True(t, added)
+	_, added = g.AddTripleIfAbsent(NewResource("a"), NewResource("b"), NewResource("c"))
+	assert.False(t, added)
+	assert.Equal(t, 1, g.Len())
+}
+
+func TestMapStoreKeyCollisions(t *testing.T) {
+	for _, pair := range [][2]*Triple{
+		{NewTriple(NewResource("a"), NewResource("b>\n<c"), NewResource("d")),
+			NewTriple(NewResource("a"), NewResource("b"), NewResource("c>\n<d"))},
+		{NewTriple(NewResource("a"), NewResource("b"), NewLiteralWithDatatype("c", NewResource("a b"))),
+			NewTriple(NewResource("a"), NewResource("b"), NewLiteralWithDatatype("c", NewResource("a%20b")))},
+		{NewTriple(NewResource("a"), NewResource("b"), NewLiteralWithLanguage("c", "en")),
+			NewTriple(NewResource("a"), NewResource("b"), NewLiteral("c\"@en"))},
+		{NewTriple(NewBlankNode("x"), NewResource("b"), NewResource("c")),
+			NewTriple(NewResource("_:x"), NewResource("b"), NewResource("c"))},
+	} {
+		g := NewGraph(testUri)
+		g.Add(pair[0])
+		g.Add(pair[1])
+		assert.Equal(t, 2, g.Len(), pair[1].String())
+		assert.NotNil(t, g.One(pair[1].Subject, pair[1].Predicate, pair[1].Object))
+	}
+}
+
+func TestGraphCount(t *testing.T) {
+	for _, g := range []*Graph{NewGraph(testUri), NewGraphWithStore(testUri, NewShardedStore(4)), NewGraphWithStore(testUri, &sliceStore{})} {
+		g.AddTriple(NewResource("a"), NewResource("b"), NewResource("c"))
+		g.AddTriple(NewResource("a"), NewResource("b"), NewResource("d"))
+		g.AddTriple(NewResource("a"), NewResource("e"), NewResource("c"))
+		g.AddTriple(NewResource("f"), NewResource("b"), NewResource("c"))
+
+		assert.Equal(t, 4, g.Count(nil, nil, nil))
+		assert.Equal(t, 3, g.Count(NewResource("a"), nil, nil))
+		assert.Equal(t, 2, g.Count(NewResource("a"), nil, NewResource("c")))
+		assert.Equal(t, 3, g.Count(nil, NewResource("b"), nil))
+		assert.Equal(t, 3, g.Count(nil, nil, NewResource("c")))
+		assert.Equal(t, 1, g.Count(NewResource("a"), NewResource("b"), NewResource("d")))
+		assert.Equal(t, 0, g.Count(NewResource("f"), NewResource("b"), NewResource("d")))
+
+		assert.True(t, g.Contains(NewResource("a"), nil, NewResource("c")))
+		assert.False(t, g.Contains(NewResource("f"), nil, NewResource("d")))
+	}
+}
+
+func TestGraphTriples(t *testing.T) {
+	for _, g := range []*Graph{NewGraph(testUri), NewGraphWithStore(testUri, NewShardedStore(4)), NewGraphWithStore(testUri, &sliceStore{})} {
+		g.AddTriple(NewResource("http://example.org/c"), NewResource("http://example.org/p"), NewLiteral("1"))
+		g.AddTriple(NewResource("http://example.org/a"), NewResource("http://example.org/q"), NewLiteral("2"))
+		g.AddTriple(NewResource("http://example.org/a"), NewResource("http://example.org/p"), NewLiteral("3"))
+		g.AddTriple(NewBlankNode("b"), NewResource("http://example.org/p"), NewLiteral("4"))
+
+		assert.Len(t, g.Triples(false), 4)
+		sorted := g.Triples(true)
+		var values []string
+		for _, triple := range sorted {
+			values = append(values, triple.Object.RawValue())
+		}
+		assert.Equal(t, []string{"3", "2", "1", "4"}, values)
+
+		// the slice belongs to the caller
+		sorted[0] = nil
+		assert.NotNil(t, g.Triples(true)[0])
+		assert.Empty(t, NewGraph(testUri).Triples(true))
+	}
+}
+
+func BenchmarkGraphContains(b *testing.B) {
+	g := NewGraph(testUri)
+	g.BulkAdd(benchTriples(100000))
+	s, p, o := NewResource(testUri), NewResource("b"), NewLiteral("99999")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Contains(s, p, o)
+		g.Contains(nil, p, nil)
+	}
+}
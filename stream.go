@@ -0,0 +1,245 @@
+package rdf2go
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// turtleChunkSize is the size from which the chunks of statements read by
+// a turtleChunker are cut
+const turtleChunkSize = 64 * 1024
+
+// ParseStream parses RDF data from a reader like Parse, but calls fn for
+// every triple instead of adding it to the graph, which is left untouched,
+// so that documents larger than memory can be processed. Turtle documents
+// are read a few statements at a time, and JSON-LD documents made of a top
+// level array, or line delimited, an element or a line at a time. Other
+// JSON-LD documents are read in full.
+// Parsing stops at the first error returned by fn, which is returned. On
+// syntax errors, fn may already have been called for the triples found
+// before the error.
+// The limits of the graph apply, except for the depth of blank nodes, which
+// is only checked within Turtle statements and JSON-LD elements.
+func (g *Graph) ParseStream(reader io.Reader, mime string, fn func(*Triple) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("could not parse %s data: %v", mime, r)
+		}
+	}()
+	ctx := context.Background()
+	reader = g.limits.reader(reader)
+	b := newTripleBuilder()
+	n := 0
+	emit := func() error {
+		for _, t := range b.reset() {
+			if err := fn(t); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	}
+	emitJSONLD := func() error {
+		if err := g.checkJSONLDTriples(b.triples); err != nil {
+			return err
+		}
+		return emit()
+	}
+	switch mimeParser[mime] {
+	case "turtle":
+		err = g.parseTurtleStream(ctx, reader, b, emit)
+	case "ndjsonld":
+		err = g.parseNDJSONLD(ctx, reader, b, emitJSONLD)
+	case "jsonld":
+		br := bufio.NewReader(reader)
+		if startsJSONArray(br) {
+			err = g.parseJSONLDStream(ctx, br, b, emitJSONLD)
+			break
+		}
+		b.triples, _, err = g.parse(ctx, br, mime)
+		if err == nil {
+			err = emit()
+		}
+		// g.parse already checked for empty input
+		return err
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, mime)
+	}
+	if err != nil {
+		return err
+	}
+	if n == 0 && g.parseMode == ParseStrict {
+		return ErrEmptyInput
+	}
+	return nil
+}
+
+// parseTurtleStream parses a Turtle document in chunks of whole statements,
+// calling each after every chunk. Positions in errors and warnings are
+// those of the whole document.
+func (g *Graph) parseTurtleStream(ctx context.Context, r io.Reader, b *tripleBuilder, each func() error) error {
+	br, err := streamReader(r)
+	if err != nil {
+		return err
+	}
+	c := &turtleChunker{r: br, line: 1, col: 1}
+	line, col := 1, 1
+	opts := turtleOptions{limits: g.limits, ctx: ctx}
+	if g.warn != nil {
+		opts.warn = func(w Warning) {
+			w.Line, w.Col = shiftPosition(w.Line, w.Col, line, col)
+			g.warn(w)
+		}
+	}
+	p := newTurtleParser(g.uri, b.add, opts)
+	for {
+		line, col = c.line, c.col
+		chunk, err := c.next()
+		if len(chunk) > 0 {
+			if perr := p.parse(chunk); perr != nil {
+				var e *ParseError
+				if errors.As(perr, &e) {
+					e.Line, e.Col = shiftPosition(e.Line, e.Col, line, col)
+				}
+				if ferr := each(); ferr != nil {
+					return ferr
+				}
+				return perr
+			}
+			if ferr := each(); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// shiftPosition converts a position within a chunk starting at line and col
+// to a position within the document
+func shiftPosition(chunkLine int, chunkCol int, line int, col int) (int, int) {
+	if chunkLine == 1 {
+		return line, col + chunkCol - 1
+	}
+	return line + chunkLine - 1, chunkCol
+}
+
+// streamReader returns a buffered reader of the input, without its UTF-8
+// byte order mark, if any. UTF-16 input cannot be streamed.
+func streamReader(r io.Reader) (*bufio.Reader, error) {
+	br := bufio.NewReader(r)
+	p, _ := br.Peek(len(bomUTF8))
+	switch {
+	case bytes.Equal(p, bomUTF8):
+		br.Discard(len(bomUTF8))
+	case bytes.HasPrefix(p, bomUTF16LE), bytes.HasPrefix(p, bomUTF16BE), looksUTF16(p):
+		return nil, errors.New("UTF-16 input cannot be streamed; convert it to UTF-8")
+	}
+	return br, nil
+}
+
+// turtleChunker splits a Turtle document into chunks of whole statements,
+// cut after a dot ending a statement once they reach turtleChunkSize. Dots
+// followed by white space, outside of IRIs, strings, comments, blank node
+// property lists and collections, always end a statement. line and col
+// give the position of the next chunk in the document.
+type turtleChunker struct {
+	r    *bufio.Reader
+	buf  []byte
+	line int
+	col  int
+}
+
+// next returns the next chunk, along with io.EOF at the end of the input
+func (c *turtleChunker) next() (string, error) {
+	c.buf = c.buf[:0]
+	depth := 0
+	var quote byte
+	long, escaped, iri, comment := false, false, false, false
+	for {
+		ch, err := c.read()
+		if err != nil {
+			return string(c.buf), err
+		}
+		switch {
+		case comment:
+			comment = ch != '\n' && ch != '\r'
+		case iri:
+			iri = ch != '>'
+		case quote != 0:
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == quote && !long:
+				quote = 0
+			case ch == quote && c.skipQuotes(quote, 2):
+				// the closing quotes are the last three of a run, the
+				// others belong to the string
+				for c.skipQuotes(quote, 1) {
+				}
+				quote = 0
+			}
+		case ch == '\\':
+			// escaped characters of local names, e.g. ex:a\'b
+			c.read()
+		case ch == '#':
+			comment = true
+		case ch == '<':
+			iri = true
+		case ch == '"' || ch == '\'':
+			quote, long = ch, c.skipQuotes(ch, 2)
+		case ch == '[' || ch == '(':
+			depth++
+		case ch == ']' || ch == ')':
+			depth--
+		case ch == '.' && depth == 0 && len(c.buf) >= turtleChunkSize:
+			p, err := c.r.Peek(1)
+			if err == io.EOF || err == nil && isTurtleSpace(p[0]) {
+				return string(c.buf), nil
+			}
+		}
+	}
+}
+
+// read reads a byte into the chunk, updating the position
+func (c *turtleChunker) read() (byte, error) {
+	ch, err := c.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	c.buf = append(c.buf, ch)
+	switch {
+	case ch == '\n':
+		c.line, c.col = c.line+1, 1
+	case utf8.RuneStart(ch):
+		c.col++
+	}
+	return ch, nil
+}
+
+// skipQuotes reads the next n bytes if they are all the quote character
+func (c *turtleChunker) skipQuotes(quote byte, n int) bool {
+	p, _ := c.r.Peek(n)
+	if len(p) < n || bytes.Count(p, []byte{quote}) < n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		c.read()
+	}
+	return true
+}
+
+func isTurtleSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
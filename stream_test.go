@@ -0,0 +1,102 @@
+package rdf2go
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamTurtle returns a Turtle document larger than a few chunks, whose
+// statements hold dots, quotes and brackets in IRIs, strings, comments, escaped
+// local names and nested blank nodes
+func streamTurtle() string {
+	var b strings.Builder
+	b.WriteString("@prefix ex: <http://example.org/> .\n")
+	for i := 0; b.Len() < 3*turtleChunkSize; i++ {
+		fmt.Fprintf(&b, "ex:s%d ex:p \"a. b\", '''c. \"\"d'''', \"\"\"e. \"\"\" ; # f. g\n", i)
+		fmt.Fprintf(&b, "\tex:q <http://example.org/h.#i>, ex:j\\.\\'k, [ ex:r ( 1 2.5 ) ], _:n%d .\n", i%10)
+	}
+	return b.String()
+}
+
+func TestParseStreamTurtle(t *testing.T) {
+	data := streamTurtle()
+	g := NewGraph(testUri)
+	require.NoError(t, g.Parse(strings.NewReader(data), "text/turtle"))
+
+	streamed := NewGraph(testUri)
+	err := NewGraph(testUri).ParseStream(strings.NewReader(data), "text/turtle", func(t *Triple) error {
+		streamed.Add(t)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, g.Len(), streamed.Len())
+	assert.True(t, g.Equal(streamed))
+	// labelled blank nodes are shared between chunks
+	assert.Len(t, streamed.All(NewResource("http://example.org/s1"), NewResource("http://example.org/q"), nil), 4)
+}
+
+func TestParseStreamTurtleErrors(t *testing.T) {
+	data := streamTurtle() + "ex:a ex:b ex:c ;\n  ex:d .\n"
+	var perr, serr *ParseError
+	require.ErrorAs(t, NewGraph(testUri).Parse(strings.NewReader(data), "text/turtle"), &perr)
+
+	n := 0
+	err := NewGraph(testUri).ParseStream(strings.NewReader(data), "text/turtle", func(t *Triple) error {
+		n++
+		return nil
+	})
+	require.ErrorAs(t, err, &serr)
+	assert.Equal(t, perr.Line, serr.Line)
+	assert.Equal(t, perr.Col, serr.Col)
+	assert.Greater(t, n, 0)
+
+	stop := errors.New("stop")
+	n = 0
+	err = NewGraph(testUri).ParseStream(strings.NewReader(data), "text/turtle", func(t *Triple) error {
+		n++
+		if n == 10 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 10, n)
+
+	err = NewGraph(testUri).ParseStream(strings.NewReader(""), "text/n3", func(t *Triple) error { return nil })
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	g := NewGraph(testUri)
+	g.SetParseMode(ParseStrict)
+	err = g.ParseStream(strings.NewReader("# nothing\n"), "text/turtle", func(t *Triple) error { return nil })
+	assert.ErrorIs(t, err, ErrEmptyInput)
+}
+
+func TestParseStreamJSONLD(t *testing.T) {
+	for mime, data := range map[string]string{
+		"application/ld+json":  `[{"@id": "http://a", "http://b": "c"}, {"@id": "http://d", "http://b": {"@id": "_:x"}}]`,
+		"application/x-ndjson": "{\"@id\": \"http://a\", \"http://b\": \"c\"}\n{\"@id\": \"http://d\", \"http://b\": {\"@id\": \"_:x\"}}\n",
+	} {
+		var triples []*Triple
+		g := NewGraph(testUri)
+		err := g.ParseStream(strings.NewReader(data), mime, func(t *Triple) error {
+			triples = append(triples, t)
+			return nil
+		})
+		require.NoError(t, err, mime)
+		assert.Len(t, triples, 2, mime)
+		assert.Equal(t, 0, g.Len())
+	}
+
+	var triples []*Triple
+	err := NewGraph(testUri).ParseStream(strings.NewReader(`{"@id": "http://a", "http://b": "c"}`), "application/ld+json", func(t *Triple) error {
+		triples = append(triples, t)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, triples, 1)
+}
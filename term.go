@@ -20,9 +20,11 @@
 package rdf2go
 
 import (
-	"fmt"
-	"math/rand"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	rdf "github.com/deiu/gon3"
 	jsonld "github.com/linkeddata/gojsonld"
@@ -53,7 +55,7 @@ func NewResource(uri string) (term Term) {
 
 // String returns the NTriples representation of this resource.
 func (term Resource) String() (str string) {
-	return fmt.Sprintf("<%s>", term.URI)
+	return "<" + escapeIRI(term.URI) + ">"
 }
 
 // RawValue returns the string value of the a resource without brackets.
@@ -77,6 +79,13 @@ type Literal struct {
 	Datatype Term
 }
 
+// DistinctStringLiterals restores the RDF 1.0 behaviour, where a plain
+// literal and the xsd:string literal of the same value are different terms.
+// By default, they are the same term as in RDF 1.1: Equal considers them
+// equal, parsers return plain literals for xsd:string values, and
+// serializers write plain literals.
+var DistinctStringLiterals = false
+
 // NewLiteral returns a new literal with the given value.
 func NewLiteral(value string) (term Term) {
 	return Term(&Literal{Value: value})
@@ -94,23 +103,63 @@ func NewLiteralWithDatatype(value string, datatype Term) (term Term) {
 
 // String returns the NTriples representation of this literal.
 func (term Literal) String() string {
-	str := term.Value
-	str = strings.Replace(str, "\\", "\\\\", -1)
-	str = strings.Replace(str, "\"", "\\\"", -1)
-	str = strings.Replace(str, "\n", "\\n", -1)
-	str = strings.Replace(str, "\r", "\\r", -1)
-	str = strings.Replace(str, "\t", "\\t", -1)
-
-	str = fmt.Sprintf("\"%s\"", str)
-
-	// if term.Language != "" {
-	str += atLang(term.Language)
-	// } else
-	if term.Datatype != nil {
-		str += "^^" + term.Datatype.String()
+	return string(term.appendNTriples(make([]byte, 0, len(term.Value)+2)))
+}
+
+// appendNTriples appends the NTriples representation of the literal to buf.
+// Values without characters to escape, the most common case, are copied
+// as is.
+func (term Literal) appendNTriples(buf []byte) []byte {
+	buf = append(buf, '"')
+	buf = appendEscaped(buf, term.Value)
+	buf = append(buf, '"')
+	buf = append(buf, atLang(term.Language)...)
+	if datatype := term.datatype(); datatype != nil {
+		buf = append(buf, "^^"...)
+		buf = appendNTriplesTerm(buf, datatype)
+	}
+	return buf
+}
+
+// appendEscaped appends a literal value to buf, escaping the characters
+// that cannot appear as is in NTriples
+func appendEscaped(buf []byte, value string) []byte {
+	if !strings.ContainsAny(value, "\\\"\n\r\t") {
+		return append(buf, value...)
+	}
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\', '"':
+			buf = append(buf, '\\', c)
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		default:
+			buf = append(buf, c)
+		}
 	}
+	return buf
+}
 
-	return str
+// appendNTriplesTerm appends the NTriples representation of a term to buf
+func appendNTriplesTerm(buf []byte, t Term) []byte {
+	switch t := t.(type) {
+	case *Resource:
+		buf = append(buf, '<')
+		buf = append(buf, escapeIRI(t.URI)...)
+		return append(buf, '>')
+	case *BlankNode:
+		buf = append(buf, "_:"...)
+		return append(buf, t.ID...)
+	case *Literal:
+		return t.appendNTriples(buf)
+	case nil:
+		return append(buf, "nil"...)
+	}
+	return append(buf, t.String()...)
 }
 
 func (term Literal) RawValue() string {
@@ -132,17 +181,39 @@ func (term Literal) Equal(other Term) bool {
 		return false
 	}
 
-	if (term.Datatype == nil && spec.Datatype != nil) || (term.Datatype != nil && spec.Datatype == nil) {
+	d1, d2 := term.datatype(), spec.datatype()
+
+	if (d1 == nil && d2 != nil) || (d1 != nil && d2 == nil) {
 		return false
 	}
 
-	if term.Datatype != nil && spec.Datatype != nil && !term.Datatype.Equal(spec.Datatype) {
+	if d1 != nil && d2 != nil && !d1.Equal(d2) {
 		return false
 	}
 
 	return true
 }
 
+// datatype returns the datatype of the literal, which is nil for xsd:string
+// unless DistinctStringLiterals is set, so that xsd:string literals are
+// handled like plain ones
+func (term Literal) datatype() Term {
+	if !DistinctStringLiterals && term.Datatype != nil && term.Datatype.RawValue() == nsXSD+"string" {
+		return nil
+	}
+	return term.Datatype
+}
+
+// newParsedLiteral returns the literal with the given value and datatype
+// read by a parser, which is a plain literal for xsd:string unless
+// DistinctStringLiterals is set
+func newParsedLiteral(value string, datatype Term) Term {
+	if datatype = (Literal{Datatype: datatype}).datatype(); datatype == nil {
+		return NewLiteral(value)
+	}
+	return NewLiteralWithDatatype(value, datatype)
+}
+
 // BlankNode is an RDF blank node i.e. an unqualified URI/IRI.
 type BlankNode struct {
 	ID string
@@ -153,9 +224,33 @@ func NewBlankNode(id string) (term Term) {
 	return Term(&BlankNode{ID: id})
 }
 
-// NewAnonNode returns a new blank node with a pseudo-randomly generated ID.
+// AnonNodeUUIDs makes NewAnonNode use random UUIDs as IDs instead of the
+// process counter, e.g. n6f1c2d3e4b5a49c8a7d6e5f4c3b2a190, so that blank
+// nodes created by different processes do not share IDs.
+var AnonNodeUUIDs = false
+
+// anonNodes counts the blank nodes created by NewAnonNode
+var anonNodes atomic.Uint64
+
+// NewAnonNode returns a new blank node with an ID that is unique within the
+// process, even when called concurrently: n1, n2, etc. or, with
+// AnonNodeUUIDs set, a random UUID.
 func NewAnonNode() (term Term) {
-	return Term(&BlankNode{ID: fmt.Sprint("n", rand.Int())})
+	if AnonNodeUUIDs {
+		return Term(&BlankNode{ID: "n" + newUUID()})
+	}
+	return Term(&BlankNode{ID: "n" + strconv.FormatUint(anonNodes.Add(1), 10)})
+}
+
+// newUUID returns a random (version 4) UUID as 32 hexadecimal digits
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return hex.EncodeToString(b[:])
 }
 
 // String returns the NTriples representation of the blank node.
@@ -210,7 +305,7 @@ func rdf2term(term rdf.Term) Term {
 			return NewLiteralWithLanguage(term.LexicalForm, term.LanguageTag)
 		}
 		if term.DatatypeIRI != nil && len(term.DatatypeIRI.String()) > 0 {
-			return NewLiteralWithDatatype(term.LexicalForm, NewResource(debrack(term.DatatypeIRI.String())))
+			return newParsedLiteral(term.LexicalForm, NewResource(debrack(term.DatatypeIRI.String())))
 		}
 		return NewLiteral(term.RawValue())
 	case *rdf.IRI:
@@ -229,7 +324,7 @@ func jterm2term(term jsonld.Term) Term {
 			return NewLiteralWithLanguage(term.RawValue(), term.Language)
 		}
 		if term.Datatype != nil && len(term.Datatype.String()) > 0 {
-			return NewLiteralWithDatatype(term.Value, NewResource(term.Datatype.RawValue()))
+			return newParsedLiteral(term.Value, NewResource(term.Datatype.RawValue()))
 		}
 		return NewLiteral(term.Value)
 	case *jsonld.Resource:
@@ -259,7 +354,7 @@ func term2jterm(term Term) jsonld.Term {
 func encodeTerm(iterm Term) string {
 	switch term := iterm.(type) {
 	case *Resource:
-		return fmt.Sprintf("<%s>", term.URI)
+		return "<" + term.URI + ">"
 	case *Literal:
 		return term.String()
 	case *BlankNode:
@@ -1,9 +1,12 @@
 package rdf2go
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type fakeTerm struct {
@@ -52,7 +55,12 @@ func TestTermLiteralEqual(t *testing.T) {
 	assert.False(t, t1.Equal(NewLiteralWithLanguage("test1", "fr")))
 
 	t1 = NewLiteralWithDatatype("test1", NewResource("http://www.w3.org/2001/XMLSchema#string"))
+	assert.True(t, t1.Equal(NewLiteral("test1")))
+	assert.True(t, NewLiteral("test1").Equal(t1))
+	assert.False(t, t1.Equal(NewLiteralWithLanguage("test1", "en")))
+	DistinctStringLiterals = true
 	assert.False(t, t1.Equal(NewLiteral("test1")))
+	DistinctStringLiterals = false
 	assert.True(t, t1.Equal(NewLiteralWithDatatype("test1", NewResource("http://www.w3.org/2001/XMLSchema#string"))))
 	assert.False(t, t1.Equal(NewLiteralWithDatatype("test1", NewResource("http://www.w3.org/2001/XMLSchema#int"))))
 }
@@ -63,10 +71,40 @@ func TestTermNewLiteralWithLanguage(t *testing.T) {
 }
 
 func TestTermNewLiteralWithDatatype(t *testing.T) {
-	s := NewLiteralWithDatatype("test", NewResource("http://www.w3.org/2001/XMLSchema#string"))
+	s := NewLiteralWithDatatype("1", NewResource("http://www.w3.org/2001/XMLSchema#integer"))
+	assert.Equal(t, "\"1\"^^<http://www.w3.org/2001/XMLSchema#integer>", s.String())
+
+	// xsd:string literals are written as plain literals
+	s = NewLiteralWithDatatype("test", NewResource("http://www.w3.org/2001/XMLSchema#string"))
+	assert.Equal(t, "\"test\"", s.String())
+	DistinctStringLiterals = true
+	defer func() { DistinctStringLiterals = false }()
 	assert.Equal(t, "\"test\"^^<http://www.w3.org/2001/XMLSchema#string>", s.String())
 }
 
+func TestSerializeStringLiteral(t *testing.T) {
+	g := NewGraph(testUri)
+	g.AddTriple(NewResource("http://example.org/a"), NewResource("http://example.org/b"),
+		NewLiteralWithDatatype("c", NewResource(nsXSD+"string")))
+	outputs := map[string]string{
+		"String":         g.String(),
+		"AppendNTriples": string(g.AppendNTriples(nil)),
+		"Triple.String":  g.One(nil, nil, nil).String(),
+	}
+	var b strings.Builder
+	require.NoError(t, g.WriteNTriples(&b))
+	outputs["WriteNTriples"] = b.String()
+	for _, mime := range []string{"text/turtle", "application/ld+json"} {
+		out, err := g.SerializeString(mime)
+		require.NoError(t, err)
+		outputs[mime] = out
+	}
+	for name, out := range outputs {
+		assert.NotContains(t, out, "string", name)
+		assert.Contains(t, out, `"c"`, name)
+	}
+}
+
 func TestTermNewBlankNode(t *testing.T) {
 	id := NewBlankNode("n1")
 	assert.Equal(t, "_:n1", id.String())
@@ -79,6 +117,38 @@ func TestTermNewAnonNode(t *testing.T) {
 	assert.True(t, id.String()[:3] == "_:n")
 }
 
+func TestTermNewAnonNodeUnique(t *testing.T) {
+	const workers, n = 8, 1000
+	ids := make(chan string, workers*n)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				ids <- NewAnonNode().RawValue()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	seen := make(map[string]bool)
+	for id := range ids {
+		assert.False(t, seen[id], id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, workers*n)
+
+	AnonNodeUUIDs = true
+	defer func() { AnonNodeUUIDs = false }()
+	a, b := NewAnonNode().RawValue(), NewAnonNode().RawValue()
+	assert.Regexp(t, `^n[0-9a-f]{12}4[0-9a-f]{3}[89ab][0-9a-f]{15}$`, a)
+	assert.NotEqual(t, a, b)
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString("_:"+a+" <http://example.org/p> \"x\" .", "text/turtle"))
+	assert.Equal(t, 1, g.Len())
+}
+
 func TestTermBNodeEqual(t *testing.T) {
 	id1 := NewBlankNode("n1")
 	id2 := NewBlankNode("n1")
@@ -147,3 +217,29 @@ func TestDefrag(t *testing.T) {
 	assert.Equal(t, "test", defrag("test"))
 	assert.Equal(t, "test", defrag("test#me"))
 }
+
+func TestStringLiteralSemantics(t *testing.T) {
+	g := NewGraph(testUri)
+	require.NoError(t, g.ParseString(`<http://a> <http://b> "x"^^<http://www.w3.org/2001/XMLSchema#string> .`, "text/turtle"))
+	lit := g.One(nil, nil, nil).Object.(*Literal)
+	assert.Nil(t, lit.Datatype)
+
+	g = NewGraph(testUri)
+	g.AddTriple(NewResource("http://a"), NewResource("http://b"), NewLiteralWithDatatype("x", NewResource(nsXSD+"string")))
+	out, err := g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "XMLSchema")
+	out, err = g.SerializeString("application/ld+json")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "@type")
+	assert.Equal(t, `<http://a> <http://b> "x" .`+"\n", string(g.CanonicalNTriples()))
+
+	DistinctStringLiterals = true
+	defer func() { DistinctStringLiterals = false }()
+	out, err = g.SerializeString("text/turtle")
+	require.NoError(t, err)
+	assert.Contains(t, out, "xsd:string")
+	g = NewGraph(testUri)
+	require.NoError(t, g.ParseString(`<http://a> <http://b> "x"^^<http://www.w3.org/2001/XMLSchema#string> .`, "text/turtle"))
+	assert.NotNil(t, g.One(nil, nil, nil).Object.(*Literal).Datatype)
+}
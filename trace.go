@@ -0,0 +1,53 @@
+package rdf2go
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracer is used to create spans around network fetches, parsing and
+// serialization. It can be implemented on top of OpenTelemetry (or any other
+// tracing library) without rdf2go depending on it.
+type Tracer interface {
+	// Start creates a span as a child of the span found in ctx (if any) and
+	// returns a context holding the new span
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a unit of work created by a Tracer
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+var (
+	tracerMu sync.RWMutex
+	tracer   Tracer
+)
+
+// SetTracer sets the Tracer used by all graphs. Passing nil disables
+// tracing, which is the default.
+func SetTracer(t Tracer) {
+	tracerMu.Lock()
+	tracer = t
+	tracerMu.Unlock()
+}
+
+// startSpan starts a span with the current Tracer, or a span doing nothing
+// if tracing is disabled
+func startSpan(ctx context.Context, name string) (context.Context, Span) {
+	tracerMu.RLock()
+	t := tracer
+	tracerMu.RUnlock()
+	if t == nil {
+		return ctx, noopSpan{}
+	}
+	return t.Start(ctx, name)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
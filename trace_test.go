@@ -0,0 +1,94 @@
+package rdf2go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.err = err }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attrs: make(map[string]interface{})}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestTracing(t *testing.T) {
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	root := &testSpan{name: "root", attrs: make(map[string]interface{})}
+	ctx := context.WithValue(context.Background(), spanKey{}, root)
+	g := NewGraph("")
+	assert.NoError(t, g.LoadURIContext(ctx, testServer.URL+"/foo#me"))
+	assert.Error(t, g.LoadURIContext(ctx, testServer.URL+"/missing"))
+	assert.NoError(t, g.SerializeContext(ctx, &bytes.Buffer{}, "text/turtle"))
+
+	assert.Equal(t, 4, len(tracer.spans))
+	load, parse, failed, serialize := tracer.spans[0], tracer.spans[1], tracer.spans[2], tracer.spans[3]
+	assert.Equal(t, "rdf2go.LoadURI", load.name)
+	assert.Equal(t, root, load.parent)
+	assert.Equal(t, testServer.URL+"/foo", load.attrs["uri"])
+	assert.Equal(t, 200, load.attrs["status"])
+	assert.Equal(t, "rdf2go.Parse", parse.name)
+	assert.Equal(t, load, parse.parent)
+	assert.Equal(t, "text/turtle", parse.attrs["mime"])
+	assert.Equal(t, 2, parse.attrs["triples"])
+	assert.Error(t, failed.err)
+	assert.Equal(t, "rdf2go.Serialize", serialize.name)
+	assert.Equal(t, 2, serialize.attrs["triples"])
+	for _, span := range tracer.spans {
+		assert.True(t, span.ended, fmt.Sprint(span.name))
+	}
+}
+
+func TestLoadURIContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g := NewGraph("")
+	assert.Error(t, g.LoadURIContext(ctx, testServer.URL+"/foo"))
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestParseContextTracing(t *testing.T) {
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	root := &testSpan{name: "root", attrs: make(map[string]interface{})}
+	ctx := context.WithValue(context.Background(), spanKey{}, root)
+	g := NewGraph(testUri)
+	assert.NoError(t, g.ParseContext(ctx, strings.NewReader("<a> <b> <c> ."), "text/turtle"))
+
+	assert.Equal(t, 1, len(tracer.spans))
+	assert.Equal(t, "rdf2go.Parse", tracer.spans[0].name)
+	assert.Equal(t, root, tracer.spans[0].parent)
+	assert.Equal(t, 1, tracer.spans[0].attrs["triples"])
+}
@@ -19,10 +19,6 @@
 
 package rdf2go
 
-import (
-	"fmt"
-)
-
 // Triple contains a subject, a predicate and an object term.
 type Triple struct {
 	Subject   Term
@@ -41,22 +37,19 @@ func NewTriple(subject Term, predicate Term, object Term) (triple *Triple) {
 
 // String returns the NTriples representation of this triple.
 func (triple Triple) String() (str string) {
-	subjStr := "nil"
-	if triple.Subject != nil {
-		subjStr = triple.Subject.String()
-	}
-
-	predStr := "nil"
-	if triple.Predicate != nil {
-		predStr = triple.Predicate.String()
-	}
-
-	objStr := "nil"
-	if triple.Object != nil {
-		objStr = triple.Object.String()
-	}
+	return string(triple.AppendNTriples(nil))
+}
 
-	return fmt.Sprintf("%s %s %s .", subjStr, predStr, objStr)
+// AppendNTriples appends the NTriples representation of this triple to buf
+// and returns the extended buffer, which avoids allocating a string for
+// each triple when serializing many of them.
+func (triple Triple) AppendNTriples(buf []byte) []byte {
+	buf = appendNTriplesTerm(buf, triple.Subject)
+	buf = append(buf, ' ')
+	buf = appendNTriplesTerm(buf, triple.Predicate)
+	buf = append(buf, ' ')
+	buf = appendNTriplesTerm(buf, triple.Object)
+	return append(buf, " ."...)
 }
 
 // Equal returns this triple is equivalent to the argument.
@@ -14,3 +14,13 @@ func TestTripleEquals(t *testing.T) {
 func TestTripleString(t *testing.T) {
 	assert.Equal(t, "<a> <b> <c> .", one.String())
 }
+
+func TestTripleAppendNTriples(t *testing.T) {
+	buf := []byte("# header\n")
+	buf = one.AppendNTriples(buf)
+	lit := NewTriple(NewBlankNode("n0"), NewResource("b"), NewLiteralWithDatatype("a \"quoted\"\tvalue\\", NewResource("d")))
+	buf = lit.AppendNTriples(append(buf, '\n'))
+	assert.Equal(t, "# header\n<a> <b> <c> .\n_:n0 <b> \"a \\\"quoted\\\"\\tvalue\\\\\"^^<d> .", string(buf))
+	assert.Equal(t, "_:n0 <b> \"a \\\"quoted\\\"\\tvalue\\\\\"^^<d> .", lit.String())
+	assert.Equal(t, "nil <b> nil .", Triple{Predicate: NewResource("b")}.String())
+}
@@ -0,0 +1,129 @@
+package rdf2go
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TripleLog appends the changes made to a graph to a writer, so that the
+// state of the graph at any time can be rebuilt with ReplayLog. Each change
+// is a line holding its time in RFC 3339 format, + for an addition or - for
+// a removal, and the triple in N-Triples, e.g.
+//
+//	2024-03-01T12:00:00Z + <http://example.org/a> <http://example.org/b> "c" .
+//
+// A TripleLog is safe for concurrent use.
+type TripleLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+	now func() time.Time
+}
+
+// NewTripleLog creates a TripleLog writing to w, which is typically a file
+// opened for appending
+func NewTripleLog(w io.Writer) *TripleLog {
+	return &TripleLog{w: w, now: time.Now}
+}
+
+// Add records the addition of a triple
+func (l *TripleLog) Add(t *Triple) error {
+	return l.write('+', t)
+}
+
+// Remove records the removal of a triple
+func (l *TripleLog) Remove(t *Triple) error {
+	return l.write('-', t)
+}
+
+// Err returns the first error met while writing to the log, if any
+func (l *TripleLog) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+func (l *TripleLog) write(op byte, t *Triple) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	line := l.now().UTC().Format(time.RFC3339Nano) + " " + string(op) + " " + t.String() + "\n"
+	_, err := io.WriteString(l.w, line)
+	if err != nil && l.err == nil {
+		l.err = err
+	}
+	return err
+}
+
+// NewLoggedStore wraps a Store so that the triples added to it and removed
+// from it are recorded in the log. Write errors are available from the Err
+// method of the log.
+func NewLoggedStore(s Store, log *TripleLog) Store {
+	return &loggedStore{Store: s, log: log}
+}
+
+type loggedStore struct {
+	Store
+	log *TripleLog
+}
+
+func (s *loggedStore) Add(t *Triple) {
+	s.Store.Add(t)
+	s.log.Add(t)
+}
+
+func (s *loggedStore) Remove(t *Triple) {
+	s.Store.Remove(t)
+	s.log.Remove(t)
+}
+
+// ReplayLog rebuilds a graph from a log written by a TripleLog. If a time is
+// given, only the changes made until then are replayed, which gives the
+// state of the graph at that time.
+func ReplayLog(r io.Reader, until ...time.Time) (*Graph, error) {
+	g := NewGraph("")
+	var triples []*Triple
+	p := newTurtleParser("", func(s Term, pred Term, o Term) {
+		triples = append(triples, NewTriple(s, pred, o))
+	}, turtleOptions{})
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<30)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+		stamp, rest, _ := strings.Cut(text, " ")
+		op, statement, _ := strings.Cut(rest, " ")
+		at, err := time.Parse(time.RFC3339Nano, stamp)
+		if err != nil {
+			return g, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(until) > 0 && at.After(until[0]) {
+			break
+		}
+		triples = triples[:0]
+		err = p.parse(statement)
+		if err == nil && len(triples) != 1 {
+			err = fmt.Errorf("expected one triple, found %d", len(triples))
+		}
+		if err != nil {
+			return g, fmt.Errorf("line %d: %w", line, err)
+		}
+		switch op {
+		case "+":
+			g.Add(triples[0])
+		case "-":
+			t := triples[0]
+			if found := g.One(t.Subject, t.Predicate, t.Object); found != nil {
+				g.Remove(found)
+			}
+		default:
+			return g, fmt.Errorf("line %d: unknown operation %q", line, op)
+		}
+	}
+	return g, scanner.Err()
+}
@@ -0,0 +1,55 @@
+package rdf2go
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripleLog(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewTripleLog(&buf)
+	clock := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	log.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+	g := NewGraphWithStore(testUri, NewLoggedStore(NewMapStore(), log))
+	me, name := NewResource("http://example.org/#me"), NewResource(nsFOAF+"name")
+	b := NewBlankNode("x")
+	g.AddTriple(me, name, NewLiteral("Me \"quoted\"\nline"))
+	g.AddTriple(me, NewResource(nsFOAF+"knows"), b)
+	g.AddTriple(b, name, NewLiteralWithLanguage("B", "en"))
+	g.Remove(g.One(me, name, nil))
+	g.AddTriple(me, name, NewLiteralWithDatatype("Me", NewResource(nsXSD+"string")))
+	require.NoError(t, log.Err())
+	assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+
+	replayed, err := ReplayLog(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.True(t, g.Equal(replayed), Diff(g, replayed).String())
+
+	// the state after the first three changes
+	past, err := ReplayLog(bytes.NewReader(buf.Bytes()), time.Date(2024, 3, 1, 12, 3, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 3, past.Len())
+	assert.Equal(t, "Me \"quoted\"\nline", past.One(me, name, nil).Object.RawValue())
+	knows := past.One(me, NewResource(nsFOAF+"knows"), nil)
+	assert.NotNil(t, past.One(knows.Object, name, nil))
+}
+
+func TestReplayLogErrors(t *testing.T) {
+	for _, log := range []string{
+		"yesterday + <a> <b> <c> .",
+		"2024-03-01T12:00:00Z * <a> <b> <c> .",
+		"2024-03-01T12:00:00Z + <a> <b> .",
+		"2024-03-01T12:00:00Z + <a> <b> <c>, <d> .",
+	} {
+		_, err := ReplayLog(strings.NewReader(log))
+		assert.Error(t, err, log)
+	}
+}
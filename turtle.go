@@ -0,0 +1,963 @@
+package rdf2go
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// turtleParser reads Turtle documents (https://www.w3.org/TR/turtle/),
+// calling emit for every triple found
+type turtleParser struct {
+	data     string
+	pos      int
+	base     string
+	prefixes map[string]string
+	bnodes   map[string]Term
+	nbnodes  int
+	emit     func(s Term, p Term, o Term)
+	warn     func(Warning)
+	limits   Limits
+	// depth is the number of blank node property lists and collections
+	// being parsed
+	depth int
+	ctx   context.Context
+}
+
+// maxTurtleNesting is the deepest nesting of blank node property lists and
+// collections accepted by the parser, which is recursive
+const maxTurtleNesting = 1000
+
+// turtleOptions holds the optional settings of parseTurtle
+type turtleOptions struct {
+	// warn is called for recoverable issues, unless it is nil
+	warn   func(Warning)
+	limits Limits
+	// ctx stops the parser between statements once it is done, unless it
+	// is nil
+	ctx context.Context
+}
+
+// parseTurtle parses a Turtle document, resolving relative IRIs against base
+func parseTurtle(data string, base string, emit func(s Term, p Term, o Term), opts turtleOptions) error {
+	return newTurtleParser(base, emit, opts).parse(data)
+}
+
+// newTurtleParser creates a parser emitting the triples of the documents it
+// parses. Prefixes and blank node labels are kept from one document to the
+// next.
+func newTurtleParser(base string, emit func(s Term, p Term, o Term), opts turtleOptions) *turtleParser {
+	p := &turtleParser{
+		base:     base,
+		prefixes: make(map[string]string),
+		bnodes:   make(map[string]Term),
+		emit:     emit,
+		warn:     opts.warn,
+		limits:   opts.limits,
+		ctx:      opts.ctx,
+	}
+	if p.warn != nil {
+		dups := make(duplicateChecker)
+		p.emit = func(s Term, pred Term, o Term) {
+			if dups.seen(s, pred, o) {
+				p.warnf(p.pos, WarnDuplicateTriple, "%s", NewTriple(s, pred, o))
+			}
+			emit(s, pred, o)
+		}
+	}
+	return p
+}
+
+// parse parses a Turtle document
+func (p *turtleParser) parse(data string) error {
+	p.data, p.pos = data, 0
+	if !utf8.ValidString(data) {
+		for p.pos < len(data) {
+			r, size := utf8.DecodeRuneInString(data[p.pos:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			p.pos += size
+		}
+		return p.errorf("invalid UTF-8 encoding")
+	}
+	for {
+		p.skipWS()
+		if p.eof() {
+			return nil
+		}
+		if p.ctx != nil && p.ctx.Err() != nil {
+			return p.ctx.Err()
+		}
+		err := p.statement()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (p *turtleParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+// peek returns the next byte, or 0 at the end of the input
+func (p *turtleParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+// peekRune returns the rune found at offset bytes from the current position
+func (p *turtleParser) peekRune(offset int) rune {
+	if p.pos+offset >= len(p.data) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(p.data[p.pos+offset:])
+	return r
+}
+
+// advance moves past the rune at the current position
+func (p *turtleParser) advance() {
+	_, size := utf8.DecodeRuneInString(p.data[p.pos:])
+	p.pos += size
+}
+
+func (p *turtleParser) errorf(format string, args ...interface{}) error {
+	line, col := position(p.data, p.pos)
+	return &ParseError{Line: line, Col: col, Token: p.token(), Msg: fmt.Sprintf(format, args...)}
+}
+
+// warnf reports a warning at the given offset
+func (p *turtleParser) warnf(offset int, kind WarningKind, format string, args ...interface{}) {
+	if p.warn == nil {
+		return
+	}
+	line, col := position(p.data, offset)
+	p.warn(Warning{Line: line, Col: col, Kind: kind, Msg: fmt.Sprintf(format, args...)})
+}
+
+// hintf behaves like errorf, adding a hint on how to fix the input
+func (p *turtleParser) hintf(hint string, format string, args ...interface{}) error {
+	err := p.errorf(format, args...)
+	err.(*ParseError).Hint = hint
+	return err
+}
+
+// token returns the input at the current position, up to the next white
+// space or delimiter
+func (p *turtleParser) token() string {
+	if p.eof() {
+		return ""
+	}
+	end := p.pos
+	for end < len(p.data) {
+		r, size := utf8.DecodeRuneInString(p.data[end:])
+		if unicode.IsSpace(r) || (end > p.pos && strings.ContainsRune("<>\"'{}()[];,", r)) {
+			break
+		}
+		end += size
+	}
+	if end == p.pos {
+		_, size := utf8.DecodeRuneInString(p.data[end:])
+		end += size
+	}
+	return p.data[p.pos:end]
+}
+
+// skipWS skips white space and comments
+func (p *turtleParser) skipWS() {
+	for !p.eof() {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			for !p.eof() && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// expect skips white space and consumes the byte c
+func (p *turtleParser) expect(c byte) error {
+	p.skipWS()
+	if p.peek() != c {
+		return p.errorf("expected %q, found %s", c, p.found())
+	}
+	p.pos++
+	return nil
+}
+
+// found describes what is at the current position, for error messages
+func (p *turtleParser) found() string {
+	if p.eof() {
+		return "end of input"
+	}
+	return strconv.QuoteRune(p.peekRune(0))
+}
+
+// keyword returns true if the input continues with the given keyword
+// (compared case-insensitively) followed by a character that cannot be part
+// of a prefixed name
+func (p *turtleParser) keyword(word string) bool {
+	end := p.pos + len(word)
+	if end > len(p.data) || !strings.EqualFold(p.data[p.pos:end], word) {
+		return false
+	}
+	r := p.peekRune(len(word))
+	return r != ':' && !isPNChars(r)
+}
+
+func (p *turtleParser) statement() error {
+	switch {
+	case p.peek() == '@':
+		p.pos++
+		switch {
+		// unlike their SPARQL forms, @prefix and @base are case-sensitive
+		case strings.HasPrefix(p.data[p.pos:], "prefix") && p.keyword("prefix"):
+			p.pos += len("prefix")
+			return p.prefixDirective(true)
+		case strings.HasPrefix(p.data[p.pos:], "base") && p.keyword("base"):
+			p.pos += len("base")
+			return p.baseDirective(true)
+		}
+		return p.hintf("use @prefix or @base", "unknown directive")
+	case p.keyword("prefix"):
+		p.pos += len("prefix")
+		return p.prefixDirective(false)
+	case p.keyword("base"):
+		p.pos += len("base")
+		return p.baseDirective(false)
+	}
+	err := p.triples()
+	if err != nil {
+		return err
+	}
+	return p.expect('.')
+}
+
+func (p *turtleParser) prefixDirective(dot bool) error {
+	p.skipWS()
+	start := p.pos
+	prefix := p.pnPrefix()
+	if p.peek() != ':' {
+		p.pos = start
+		return p.errorf("expected a prefix declaration, found %s", p.found())
+	}
+	p.pos++
+	p.skipWS()
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+	p.prefixes[prefix] = iri
+	if dot {
+		return p.expect('.')
+	}
+	return nil
+}
+
+func (p *turtleParser) baseDirective(dot bool) error {
+	p.skipWS()
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+	p.base = iri
+	if dot {
+		return p.expect('.')
+	}
+	return nil
+}
+
+func (p *turtleParser) triples() error {
+	if p.peek() == '[' {
+		s, list, err := p.blankNodePropertyList()
+		if err != nil {
+			return err
+		}
+		p.skipWS()
+		if list && p.peek() == '.' {
+			return nil
+		}
+		return p.predicateObjectList(s)
+	}
+	s, err := p.subject()
+	if err != nil {
+		return err
+	}
+	return p.predicateObjectList(s)
+}
+
+func (p *turtleParser) subject() (Term, error) {
+	p.skipWS()
+	switch c := p.peek(); {
+	case c == '_' && p.peekRune(1) == ':':
+		return p.blankNodeLabel()
+	case c == '(':
+		return p.collection()
+	case c == '"' || c == '\'' || c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return nil, p.errorf("literals cannot be used as subjects")
+	}
+	return p.iri()
+}
+
+func (p *turtleParser) predicateObjectList(s Term) error {
+	for {
+		pred, err := p.verb()
+		if err != nil {
+			return err
+		}
+		err = p.objectList(s, pred)
+		if err != nil {
+			return err
+		}
+		p.skipWS()
+		if p.peek() != ';' {
+			return nil
+		}
+		for p.peek() == ';' {
+			p.pos++
+			p.skipWS()
+		}
+		switch p.peek() {
+		case '.', ']', 0:
+			return nil
+		}
+	}
+}
+
+func (p *turtleParser) verb() (Term, error) {
+	p.skipWS()
+	if p.peek() == 'a' && p.peekRune(1) != ':' && !isPNChars(p.peekRune(1)) {
+		p.pos++
+		return NewResource(nsRDF + "type"), nil
+	}
+	return p.iri()
+}
+
+func (p *turtleParser) objectList(s Term, pred Term) error {
+	for {
+		o, err := p.object()
+		if err != nil {
+			return err
+		}
+		p.emit(s, pred, o)
+		p.skipWS()
+		if p.peek() != ',' {
+			return nil
+		}
+		p.pos++
+	}
+}
+
+func (p *turtleParser) object() (Term, error) {
+	p.skipWS()
+	switch c := p.peek(); {
+	case c == '_' && p.peekRune(1) == ':':
+		return p.blankNodeLabel()
+	case c == '[':
+		s, _, err := p.blankNodePropertyList()
+		return s, err
+	case c == '(':
+		return p.collection()
+	case c == '"' || c == '\'':
+		return p.rdfLiteral()
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return p.numericLiteral()
+	case p.keyword("true"), p.keyword("false"):
+		value := "true"
+		if c == 'f' {
+			value = "false"
+		}
+		if p.data[p.pos:p.pos+len(value)] != value {
+			return nil, p.hintf("use true or false", "booleans must be written in lowercase")
+		}
+		p.pos += len(value)
+		return NewLiteralWithDatatype(value, NewResource(nsXSD+"boolean")), nil
+	}
+	return p.iri()
+}
+
+// newBlankNode returns a blank node with a label unique to the document
+func (p *turtleParser) newBlankNode() Term {
+	b := NewBlankNode("a" + strconv.Itoa(p.nbnodes))
+	p.nbnodes++
+	return b
+}
+
+func (p *turtleParser) blankNodeLabel() (Term, error) {
+	p.pos += 2
+	start := p.pos
+	r := p.peekRune(0)
+	if !isPNCharsU(r) && !(r >= '0' && r <= '9') {
+		return nil, p.errorf("invalid blank node label")
+	}
+	p.advance()
+	p.scanName(false)
+	label := p.data[start:p.pos]
+	if b, ok := p.bnodes[label]; ok {
+		return b, nil
+	}
+	b := p.newBlankNode()
+	p.bnodes[label] = b
+	return b, nil
+}
+
+// nest records entering a blank node property list or a collection, and
+// fails if they are nested too deeply. The caller must decrement p.depth
+// once done.
+func (p *turtleParser) nest() error {
+	p.depth++
+	max := maxTurtleNesting
+	if p.limits.MaxBlankNodeDepth > 0 && p.limits.MaxBlankNodeDepth < max {
+		max = p.limits.MaxBlankNodeDepth
+	}
+	if p.depth > max {
+		return p.errorf("blank nodes are nested more than %d levels deep", max)
+	}
+	return nil
+}
+
+// blankNodePropertyList parses [ ... ], returning the blank node and whether
+// it had properties (as opposed to the empty [] form)
+func (p *turtleParser) blankNodePropertyList() (Term, bool, error) {
+	defer func() { p.depth-- }()
+	if err := p.nest(); err != nil {
+		return nil, false, err
+	}
+	p.pos++
+	b := p.newBlankNode()
+	p.skipWS()
+	if p.peek() == ']' {
+		p.pos++
+		return b, false, nil
+	}
+	err := p.predicateObjectList(b)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, p.expect(']')
+}
+
+func (p *turtleParser) collection() (Term, error) {
+	defer func() { p.depth-- }()
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	p.pos++
+	var items []Term
+	for {
+		p.skipWS()
+		if p.eof() {
+			return nil, p.errorf("unterminated collection")
+		}
+		if p.peek() == ')' {
+			p.pos++
+			break
+		}
+		item, err := p.object()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return NewResource(nsRDF + "nil"), nil
+	}
+	head := p.newBlankNode()
+	node := head
+	for i, item := range items {
+		p.emit(node, NewResource(nsRDF+"first"), item)
+		next := NewResource(nsRDF + "nil")
+		if i < len(items)-1 {
+			next = p.newBlankNode()
+		}
+		p.emit(node, NewResource(nsRDF+"rest"), next)
+		node = next
+	}
+	return head, nil
+}
+
+func (p *turtleParser) iri() (Term, error) {
+	p.skipWS()
+	if p.peek() == '<' {
+		iri, err := p.iriRef()
+		if err != nil {
+			return nil, err
+		}
+		return NewResource(iri), nil
+	}
+	return p.prefixedName()
+}
+
+// iriRef parses <...> and returns the IRI resolved against the base
+func (p *turtleParser) iriRef() (string, error) {
+	if p.peek() != '<' {
+		return "", p.errorf("expected an IRI, found %s", p.found())
+	}
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated IRI")
+		}
+		c := p.data[p.pos]
+		switch {
+		case c == '>':
+			ref := b.String()
+			if p.warn != nil && len(iriScheme(ref)) == 0 {
+				p.warnf(start, WarnRelativeIRI, "resolved %q against %q", ref, p.base)
+			}
+			iri := resolveIRI(p.base, ref)
+			if err := p.checkIRI(len(iri)); err != nil {
+				return "", err
+			}
+			p.pos++
+			return iri, nil
+		case c == '\\':
+			r, err := p.uchar()
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		case c <= 0x20 || strings.IndexByte("<\"{}|^`", c) >= 0:
+			return "", p.hintf("percent-encode it", "invalid character %q in IRI", c)
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+		if err := p.checkIRI(b.Len()); err != nil {
+			return "", err
+		}
+	}
+}
+
+// checkIRI enforces the maximum IRI length while IRIs are being read
+func (p *turtleParser) checkIRI(n int) error {
+	if max := p.limits.MaxIRILength; max > 0 && n > max {
+		return p.errorf("IRI length exceeds the maximum of %d", max)
+	}
+	return nil
+}
+
+// checkLiteral enforces the maximum literal length while strings are being read
+func (p *turtleParser) checkLiteral(n int) error {
+	if max := p.limits.MaxLiteralLength; max > 0 && n > max {
+		return p.errorf("literal length exceeds the maximum of %d", max)
+	}
+	return nil
+}
+
+// uchar parses a \u or \U escape sequence
+func (p *turtleParser) uchar() (rune, error) {
+	n := 0
+	switch p.peekRune(1) {
+	case 'u':
+		n = 4
+	case 'U':
+		n = 8
+	default:
+		return 0, p.errorf("invalid escape sequence")
+	}
+	start := p.pos + 2
+	if start+n > len(p.data) {
+		return 0, p.errorf("invalid escape sequence")
+	}
+	v, err := strconv.ParseUint(p.data[start:start+n], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(v)) {
+		return 0, p.errorf("invalid escape sequence")
+	}
+	p.pos = start + n
+	return rune(v), nil
+}
+
+func (p *turtleParser) prefixedName() (Term, error) {
+	start := p.pos
+	prefix := p.pnPrefix()
+	if p.peek() != ':' {
+		p.pos = start
+		return nil, p.errorf("expected an IRI, found %s", p.found())
+	}
+	ns, ok := p.prefixes[prefix]
+	if !ok {
+		p.pos = start
+		return nil, p.hintf(fmt.Sprintf("declare it with @prefix %s: <...> .", prefix), "undefined prefix %q", prefix)
+	}
+	p.pos++
+	local, err := p.pnLocal()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkIRI(len(ns) + len(local)); err != nil {
+		return nil, err
+	}
+	return NewResource(ns + local), nil
+}
+
+// pnPrefix scans the prefix of a prefixed name (which may be empty)
+func (p *turtleParser) pnPrefix() string {
+	start := p.pos
+	if !isPNCharsBase(p.peekRune(0)) {
+		return ""
+	}
+	p.advance()
+	p.scanName(false)
+	return p.data[start:p.pos]
+}
+
+// scanName scans PN_CHARS and dots (plus colons if allowed), not ending
+// with a dot
+func (p *turtleParser) scanName(colons bool) {
+	for !p.eof() {
+		r := p.peekRune(0)
+		if !isPNChars(r) && r != '.' && !(colons && r == ':') {
+			break
+		}
+		p.advance()
+	}
+	for p.data[p.pos-1] == '.' {
+		p.pos--
+	}
+}
+
+// pnLocal scans the local part of a prefixed name, unescaping it
+func (p *turtleParser) pnLocal() (string, error) {
+	var b strings.Builder
+	first := true
+	for !p.eof() {
+		r := p.peekRune(0)
+		switch {
+		case r == '%':
+			if p.pos+2 >= len(p.data) || !isHex(p.data[p.pos+1]) || !isHex(p.data[p.pos+2]) {
+				return "", p.errorf("invalid percent encoding")
+			}
+			b.WriteString(p.data[p.pos : p.pos+3])
+			p.pos += 3
+		case r == '\\':
+			c := p.peekRune(1)
+			if c < 0 || !strings.ContainsRune("_~.-!$&'()*+,;=/?#@%", c) {
+				return "", p.errorf("invalid escape sequence in local name")
+			}
+			b.WriteRune(c)
+			p.pos += 2
+		case r == '.':
+			// dots are allowed, but neither first nor last
+			dots := 1
+			for p.peekRune(dots) == '.' {
+				dots++
+			}
+			next := p.peekRune(dots)
+			if first || !(isPNChars(next) || next == ':' || next == '%' || next == '\\') {
+				return b.String(), nil
+			}
+			b.WriteString(p.data[p.pos : p.pos+dots])
+			p.pos += dots
+		case r == ':' || isPNChars(r) && (!first || isPNCharsU(r) || r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			p.advance()
+		default:
+			return b.String(), nil
+		}
+		first = false
+	}
+	return b.String(), nil
+}
+
+func (p *turtleParser) rdfLiteral() (Term, error) {
+	value, err := p.string()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.peek() == '@':
+		p.pos++
+		start := p.pos
+		for !p.eof() && isLangChar(p.data[p.pos], p.pos == start) {
+			p.pos++
+		}
+		lang := p.data[start:p.pos]
+		if len(lang) == 0 || lang[len(lang)-1] == '-' || strings.Contains(lang, "--") {
+			return nil, p.errorf("invalid language tag %q", lang)
+		}
+		if p.warn != nil {
+			if msg := warnLanguageCase(lang); len(msg) > 0 {
+				p.warnf(start-1, WarnLanguageCase, "%s", msg)
+			}
+		}
+		return NewLiteralWithLanguage(value, lang), nil
+	case strings.HasPrefix(p.data[p.pos:], "^^"):
+		p.pos += 2
+		dt, err := p.iri()
+		if err != nil {
+			return nil, err
+		}
+		return newParsedLiteral(value, dt), nil
+	}
+	return NewLiteral(value), nil
+}
+
+func isLangChar(c byte, first bool) bool {
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && (c == '-' || (c >= '0' && c <= '9'))
+}
+
+// string parses a quoted string, in any of the four Turtle forms
+func (p *turtleParser) string() (string, error) {
+	q := p.data[p.pos]
+	long := strings.HasPrefix(p.data[p.pos:], strings.Repeat(string(q), 3))
+	if long {
+		p.pos += 3
+	} else {
+		p.pos++
+	}
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.data[p.pos]
+		switch {
+		case c == q && !long:
+			p.pos++
+			return b.String(), nil
+		case c == q && strings.HasPrefix(p.data[p.pos:], strings.Repeat(string(q), 3)):
+			// a long string may end with up to two extra quotes
+			end := p.pos + 3
+			for end < len(p.data) && p.data[end] == q && end-p.pos < 5 {
+				end++
+			}
+			b.WriteString(p.data[p.pos : end-3])
+			if err := p.checkLiteral(b.Len()); err != nil {
+				return "", err
+			}
+			p.pos = end
+			return b.String(), nil
+		case c == '\\':
+			switch e := p.peekRune(1); e {
+			case 'u', 'U':
+				r, err := p.uchar()
+				if err != nil {
+					return "", err
+				}
+				b.WriteRune(r)
+				continue
+			case 't':
+				b.WriteByte('\t')
+			case 'b':
+				b.WriteByte('\b')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 'f':
+				b.WriteByte('\f')
+			case '"', '\'', '\\':
+				b.WriteRune(e)
+			default:
+				return "", p.errorf("invalid escape sequence")
+			}
+			p.pos += 2
+		case (c == '\n' || c == '\r') && !long:
+			return "", p.hintf(`use """long strings""" for text spanning several lines`, "line break in string")
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+		if err := p.checkLiteral(b.Len()); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (p *turtleParser) numericLiteral() (Term, error) {
+	start := p.pos
+	if c := p.peek(); c == '+' || c == '-' {
+		p.pos++
+	}
+	digits := p.digits()
+	datatype := "integer"
+	if p.peek() == '.' {
+		// the dot is only part of the number if followed by digits or an
+		// exponent, otherwise it ends the statement
+		save := p.pos
+		p.pos++
+		fraction := p.digits()
+		if fraction > 0 || (digits > 0 && p.exponentAhead()) {
+			datatype = "decimal"
+			digits += fraction
+		} else {
+			p.pos = save
+		}
+	}
+	if digits == 0 {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+	if p.exponentAhead() {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+		p.digits()
+		datatype = "double"
+	}
+	return NewLiteralWithDatatype(p.data[start:p.pos], NewResource(nsXSD+datatype)), nil
+}
+
+func (p *turtleParser) digits() int {
+	n := 0
+	for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+		p.pos++
+		n++
+	}
+	return n
+}
+
+// exponentAhead returns true if the input continues with a valid exponent
+func (p *turtleParser) exponentAhead() bool {
+	if c := p.peek(); c != 'e' && c != 'E' {
+		return false
+	}
+	i := p.pos + 1
+	if i < len(p.data) && (p.data[i] == '+' || p.data[i] == '-') {
+		i++
+	}
+	return i < len(p.data) && p.data[i] >= '0' && p.data[i] <= '9'
+}
+
+func isPNCharsBase(r rune) bool {
+	switch {
+	case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		return true
+	case r < 0xC0:
+		return false
+	}
+	return r <= 0xD6 || (r >= 0xD8 && r <= 0xF6) || (r >= 0xF8 && r <= 0x2FF) ||
+		(r >= 0x370 && r <= 0x37D) || (r >= 0x37F && r <= 0x1FFF) ||
+		(r >= 0x200C && r <= 0x200D) || (r >= 0x2070 && r <= 0x218F) ||
+		(r >= 0x2C00 && r <= 0x2FEF) || (r >= 0x3001 && r <= 0xD7FF) ||
+		(r >= 0xF900 && r <= 0xFDCF) || (r >= 0xFDF0 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0xEFFFF)
+}
+
+func isPNCharsU(r rune) bool {
+	return r == '_' || isPNCharsBase(r)
+}
+
+func isPNChars(r rune) bool {
+	return isPNCharsU(r) || r == '-' || (r >= '0' && r <= '9') || r == 0xB7 ||
+		(r >= 0x300 && r <= 0x36F) || (r >= 0x203F && r <= 0x2040)
+}
+
+// resolveIRI resolves a (possibly relative) IRI reference against a base
+// IRI, following RFC 3986. Unlike net/url, it leaves the characters of the
+// IRIs untouched.
+func resolveIRI(base string, ref string) string {
+	if len(base) == 0 || iriScheme(ref) != "" {
+		return ref
+	}
+	scheme := iriScheme(base)
+	rest := base[len(scheme):]
+	if len(scheme) > 0 {
+		scheme += ":"
+		rest = base[len(scheme):]
+	}
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		rest = rest[:i]
+	}
+	if strings.HasPrefix(ref, "#") {
+		return scheme + rest + ref
+	}
+	authority := ""
+	if strings.HasPrefix(rest, "//") {
+		end := strings.IndexAny(rest[2:], "/?")
+		if end < 0 {
+			end = len(rest) - 2
+		}
+		authority = rest[:2+end]
+		rest = rest[2+end:]
+	}
+	path, query := rest, ""
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		path, query = rest[:i], rest[i:]
+	}
+	switch {
+	case strings.HasPrefix(ref, "//"):
+		return scheme + ref
+	case len(ref) == 0:
+		return scheme + authority + path + query
+	case strings.HasPrefix(ref, "?"):
+		return scheme + authority + path + ref
+	case strings.HasPrefix(ref, "/"):
+		return scheme + authority + removeDotSegments(ref)
+	}
+	// merge the relative path with the base path
+	if len(authority) > 0 && len(path) == 0 {
+		path = "/"
+	}
+	merged := path[:strings.LastIndexByte(path, '/')+1] + ref
+	return scheme + authority + removeDotSegments(merged)
+}
+
+// iriScheme returns the scheme of an IRI, or "" for relative references
+func iriScheme(iri string) string {
+	for i := 0; i < len(iri); i++ {
+		c := iri[i]
+		switch {
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case i > 0 && ((c >= '0' && c <= '9') || c == '+' || c == '-' || c == '.'):
+		case i > 0 && c == ':':
+			return iri[:i]
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// removeDotSegments removes . and .. segments from the path of an IRI,
+// leaving the query and fragment untouched
+func removeDotSegments(iri string) string {
+	path, suffix := iri, ""
+	if i := strings.IndexAny(iri, "?#"); i >= 0 {
+		path, suffix = iri[:i], iri[i:]
+	}
+	if !strings.Contains(path, ".") {
+		return iri
+	}
+	var out []string
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		last := i == len(segments)-1
+		switch seg {
+		case ".":
+			if last {
+				out = append(out, "")
+			}
+		case "..":
+			if len(out) > 1 {
+				out = out[:len(out)-1]
+			}
+			if last {
+				out = append(out, "")
+			}
+		default:
+			out = append(out, seg)
+		}
+	}
+	return strings.Join(out, "/") + suffix
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
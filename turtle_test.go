@@ -0,0 +1,197 @@
+package rdf2go
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// turtleLines parses data and returns the sorted N-Triples lines of the result
+func turtleLines(t *testing.T, base string, data string) []string {
+	var lines []string
+	err := parseTurtle(data, base, func(s Term, p Term, o Term) {
+		lines = append(lines, NewTriple(s, p, o).String())
+	}, turtleOptions{})
+	assert.NoError(t, err, data)
+	sort.Strings(lines)
+	return lines
+}
+
+func TestTurtleParser(t *testing.T) {
+	for _, tc := range []struct {
+		data     string
+		expected []string
+	}{
+		{
+			`<a> <b> <c> .`,
+			[]string{`<http://ex.org/dir/a> <http://ex.org/dir/b> <http://ex.org/dir/c> .`},
+		},
+		{
+			"@prefix : <http://ex.org/ns#> .\nPREFIX ex: <http://ex.org/>\n:a a ex:C ; ex:p :b, ex:c ;; .",
+			[]string{
+				`<http://ex.org/ns#a> <http://ex.org/p> <http://ex.org/c> .`,
+				`<http://ex.org/ns#a> <http://ex.org/p> <http://ex.org/ns#b> .`,
+				`<http://ex.org/ns#a> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://ex.org/C> .`,
+			},
+		},
+		{
+			"@base <http://other.org/x/> . <../y> <#p> <?q> .\nBASE <http://third.org/>\n<z> <p> <//host/path> .",
+			[]string{
+				`<http://other.org/y> <http://other.org/x/#p> <http://other.org/x/?q> .`,
+				`<http://third.org/z> <http://third.org/p> <http://host/path> .`,
+			},
+		},
+		{
+			`<s> <p> "plain", 'single', "tab\there"@en-GB, "1"^^<http://www.w3.org/2001/XMLSchema#int> .`,
+			[]string{
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "1"^^<http://www.w3.org/2001/XMLSchema#int> .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "plain" .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "single" .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "tab\there"@en-GB .`,
+			},
+		},
+		{
+			"<s> <p> \"\"\"multi\n\"line\" \"\"\"\", '''it's''' .",
+			[]string{
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "it's" .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "multi\n\"line\" \"" .`,
+			},
+		},
+		{
+			`<s> <p> 1, -2.5, .5e1, 1.E2, true, false .`,
+			[]string{
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "-2.5"^^<http://www.w3.org/2001/XMLSchema#decimal> .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> ".5e1"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "1.E2"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "false"^^<http://www.w3.org/2001/XMLSchema#boolean> .`,
+				`<http://ex.org/dir/s> <http://ex.org/dir/p> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .`,
+			},
+		},
+		{
+			`<s> <p> 1.`,
+			[]string{`<http://ex.org/dir/s> <http://ex.org/dir/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .`},
+		},
+		{
+			"_:x <p> [ <q> _:x ] . [] <r> ( 1 _:x ) . [ <t> () ] .",
+			[]string{
+				`_:a0 <http://ex.org/dir/p> _:a1 .`,
+				`_:a1 <http://ex.org/dir/q> _:a0 .`,
+				`_:a2 <http://ex.org/dir/r> _:a3 .`,
+				`_:a3 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+				`_:a3 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:a4 .`,
+				`_:a4 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> _:a0 .`,
+				`_:a4 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .`,
+				`_:a5 <http://ex.org/dir/t> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .`,
+			},
+		},
+		{
+			"@prefix ex: <http://ex.org/> . # comment\nex:a.b ex:c\\~d ex:e%20f, ex:, ex:g. ",
+			[]string{
+				`<http://ex.org/a.b> <http://ex.org/c~d> <http://ex.org/> .`,
+				`<http://ex.org/a.b> <http://ex.org/c~d> <http://ex.org/e%20f> .`,
+				`<http://ex.org/a.b> <http://ex.org/c~d> <http://ex.org/g> .`,
+			},
+		},
+		{
+			`<s\u00E9> <p> "caf\u00e9 \U0001F600" .`,
+			[]string{`<http://ex.org/dir/sé> <http://ex.org/dir/p> "café 😀" .`},
+		},
+	} {
+		assert.Equal(t, tc.expected, turtleLines(t, "http://ex.org/dir/doc", tc.data), tc.data)
+	}
+}
+
+func TestParseTurtleEscapedBackslash(t *testing.T) {
+	// a backslash escaped at the end of a string must not escape the quote
+	g := NewGraph(testUri)
+	assert.NoError(t, g.Parse(strings.NewReader(`<#a> <#b> "C:\\dir\\" ; <#c> "\\\"" .`), "text/turtle"))
+	assert.NotNil(t, g.One(NewResource(testUri+"#a"), NewResource(testUri+"#b"), NewLiteral(`C:\dir\`)))
+	assert.NotNil(t, g.One(NewResource(testUri+"#a"), NewResource(testUri+"#c"), NewLiteral(`\"`)))
+}
+
+func TestParseTurtleErrors(t *testing.T) {
+	for _, data := range []string{
+		`<a> <b> "\uZZZZ" .`,
+		`<a> <b> "\q" .`,
+		`<a> <b> "unterminated .`,
+		"<a> <b> \"line\nbreak\" .",
+		`<a> <b> <c>`,
+		`<a> <b> .`,
+		`<a b> <c> <d> .`,
+		`ex:a <b> <c> .`,
+		`"lit" <b> <c> .`,
+		`<a> <b> "x"@ .`,
+		`<a> <b> ( <c> .`,
+		`<a> <b> [ <c> <d> .`,
+		`@foo <a> .`,
+		`@prefixfoo: <http://example.org/> .`,
+		`@basex <http://example.org/> .`,
+		`@PREFIX ex: <http://example.org/> .`,
+		`<a> <b> TRUE .`,
+		"<a> <b> \"\xff\" .",
+	} {
+		err := parseTurtle(data, "", func(s Term, p Term, o Term) {}, turtleOptions{})
+		var perr *ParseError
+		assert.ErrorAs(t, err, &perr, data)
+	}
+	err := parseTurtle("<a> <b> <c> .\n<d> <e> \"\\x\" .", "", func(s Term, p Term, o Term) {}, turtleOptions{})
+	assert.EqualError(t, err, "line 2, column 10: invalid escape sequence")
+}
+
+func TestParseTurtleNesting(t *testing.T) {
+	deep := "<a> <b> " + strings.Repeat("(", 3000000) + strings.Repeat(")", 3000000) + " ."
+	g := NewGraph(testUri)
+	g.SetLimits(Limits{MaxBlankNodeDepth: 10})
+	err := g.SafeParse(strings.NewReader(deep), "text/turtle")
+	assert.EqualError(t, err, `line 1, column 19: blank nodes are nested more than 10 levels deep`)
+
+	g = NewGraph(testUri)
+	err = g.SafeParse(strings.NewReader(deep), "text/turtle")
+	assert.EqualError(t, err, `line 1, column 1009: blank nodes are nested more than 1000 levels deep`)
+
+	nested := "<a> <b> " + strings.Repeat("[ <c> ", 500) + "<d>" + strings.Repeat(" ]", 500) + " ."
+	err = g.SafeParse(strings.NewReader(nested), "text/turtle")
+	assert.NoError(t, err)
+	assert.Equal(t, 501, g.Len())
+}
+
+func TestResolveIRI(t *testing.T) {
+	base := "http://a/b/c/d;p?q"
+	for ref, expected := range map[string]string{
+		"g:h":        "g:h",
+		"g":          "http://a/b/c/g",
+		"./g":        "http://a/b/c/g",
+		"g/":         "http://a/b/c/g/",
+		"/g":         "http://a/g",
+		"//g":        "http://g",
+		"?y":         "http://a/b/c/d;p?y",
+		"#s":         "http://a/b/c/d;p?q#s",
+		"":           "http://a/b/c/d;p?q",
+		".":          "http://a/b/c/",
+		"..":         "http://a/b/",
+		"../g":       "http://a/b/g",
+		"../../g":    "http://a/g",
+		"g;x=1/../y": "http://a/b/c/y",
+	} {
+		assert.Equal(t, expected, resolveIRI(base, ref), ref)
+	}
+	assert.Equal(t, "https://example.org#me", resolveIRI("https://example.org", "#me"))
+	assert.Equal(t, "https://example.org/a", resolveIRI("https://example.org", "a"))
+	assert.Equal(t, "a", resolveIRI("", "a"))
+}
+
+func BenchmarkTurtleParser(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("@prefix foaf: <http://xmlns.com/foaf/0.1/> .\n")
+	for i := 0; i < 1000; i++ {
+		sb.WriteString("<#p" + string(rune('a'+i%26)) + "> a foaf:Person ; foaf:name \"Name\"@en ; foaf:age 42 ; foaf:knows [ foaf:name 'Other' ] .\n")
+	}
+	data := sb.String()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseTurtle(data, testUri, func(s Term, p Term, o Term) {}, turtleOptions{})
+	}
+}
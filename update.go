@@ -0,0 +1,31 @@
+package rdf2go
+
+// SetOne makes o the only value of p for s, removing the other values, as
+// done when saving a single-valued form field. A nil o removes all the
+// values. It returns the number of triples removed.
+func (g *Graph) SetOne(s Term, p Term, o Term) int {
+	removed := 0
+	for _, t := range g.All(s, p, nil) {
+		if o != nil && t.Object.Equal(o) {
+			continue
+		}
+		g.Remove(t)
+		removed++
+	}
+	if o != nil {
+		g.AddTriple(s, p, o)
+	}
+	return removed
+}
+
+// ReplaceObject replaces the value old of p for s with new, and returns
+// whether old was a value of p for s. Nothing is added when it was not.
+func (g *Graph) ReplaceObject(s Term, p Term, old Term, new Term) bool {
+	t := g.One(s, p, old)
+	if t == nil {
+		return false
+	}
+	g.Remove(t)
+	g.AddTriple(s, p, new)
+	return true
+}
@@ -0,0 +1,40 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOne(t *testing.T) {
+	g := NewGraph(testUri)
+	s, name := NewResource("http://example.org/#me"), NewResource(nsFOAF+"name")
+	g.AddTriple(s, name, NewLiteral("A"))
+	g.AddTriple(s, name, NewLiteral("B"))
+	g.AddTriple(s, NewResource(nsFOAF+"nick"), NewLiteral("a"))
+
+	assert.Equal(t, 2, g.SetOne(s, name, NewLiteral("C")))
+	assert.Equal(t, 2, g.Len())
+	assert.Equal(t, "C", g.One(s, name, nil).Object.RawValue())
+
+	assert.Equal(t, 0, g.SetOne(s, name, NewLiteral("C")))
+	assert.Equal(t, 2, g.Len())
+
+	assert.Equal(t, 1, g.SetOne(s, name, nil))
+	assert.Nil(t, g.One(s, name, nil))
+	assert.Equal(t, 1, g.Len())
+}
+
+func TestReplaceObject(t *testing.T) {
+	g := NewGraph(testUri)
+	s, knows := NewResource("http://example.org/#me"), NewResource(nsFOAF+"knows")
+	bob, carol := NewResource("http://example.org/#bob"), NewResource("http://example.org/#carol")
+	g.AddTriple(s, knows, bob)
+
+	assert.True(t, g.ReplaceObject(s, knows, bob, carol))
+	assert.False(t, g.Contains(s, knows, bob))
+	assert.True(t, g.Contains(s, knows, carol))
+
+	assert.False(t, g.ReplaceObject(s, knows, bob, carol))
+	assert.Equal(t, 1, g.Len())
+}
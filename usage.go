@@ -0,0 +1,188 @@
+package rdf2go
+
+import "fmt"
+
+// FindingKind identifies the kind of issue reported by a Finding
+type FindingKind int
+
+const (
+	// FindingUndefined reports a predicate or class from a namespace of the
+	// ontology that the ontology does not define
+	FindingUndefined FindingKind = iota + 1
+	// FindingDeprecated reports a term marked with owl:deprecated
+	FindingDeprecated
+	// FindingDomain reports a subject whose types do not include the
+	// rdfs:domain of the predicate
+	FindingDomain
+	// FindingRange reports an object that does not match the rdfs:range of
+	// the predicate
+	FindingRange
+)
+
+var findingKindNames = map[FindingKind]string{
+	FindingUndefined:  "undefined term",
+	FindingDeprecated: "deprecated term",
+	FindingDomain:     "domain violation",
+	FindingRange:      "range violation",
+}
+
+func (k FindingKind) String() string {
+	if name, ok := findingKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("FindingKind(%d)", int(k))
+}
+
+// Finding describes an issue found by CheckUsage in a triple of the data
+type Finding struct {
+	Kind FindingKind
+	// Term is the term of the ontology the finding is about
+	Term   Term
+	Triple *Triple
+	Msg    string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s (in %s)", f.Kind, f.Msg, f.Triple)
+}
+
+// CheckUsage checks how the data uses the vocabulary defined by an ontology.
+// It reports the predicates and classes that belong to a namespace of the
+// ontology but are not defined by it, the use of deprecated terms, and the
+// triples contradicting the rdfs:domain and rdfs:range of their predicate.
+// Since RDFS allows types to be inferred, domains and ranges are only
+// checked for nodes that have an explicit rdf:type in the data. Findings are
+// sorted by triple.
+func CheckUsage(data *Graph, ontology *Graph) []Finding {
+	c := &usageChecker{
+		data:       data,
+		ontology:   ontology,
+		namespaces: make(map[string]bool),
+		supers:     make(map[string]map[string]bool),
+	}
+	for triple := range ontology.IterTriples() {
+		if r, ok := triple.Subject.(*Resource); ok {
+			base, _ := splitPrefix(r.URI)
+			c.namespaces[base] = true
+		}
+	}
+	var findings []Finding
+	rdfType := NewResource(nsRDF + "type")
+	for _, triple := range data.Triples(true) {
+		findings = append(findings, c.checkTerm(triple, triple.Predicate)...)
+		if triple.Predicate.Equal(rdfType) {
+			findings = append(findings, c.checkTerm(triple, triple.Object)...)
+			continue
+		}
+		findings = append(findings, c.checkDomain(triple)...)
+		findings = append(findings, c.checkRange(triple)...)
+	}
+	return findings
+}
+
+type usageChecker struct {
+	data       *Graph
+	ontology   *Graph
+	namespaces map[string]bool
+	// supers caches the super classes of each class, including itself
+	supers map[string]map[string]bool
+}
+
+// checkTerm reports an undefined or deprecated term
+func (c *usageChecker) checkTerm(triple *Triple, t Term) []Finding {
+	r, ok := t.(*Resource)
+	if !ok {
+		return nil
+	}
+	if c.ontology.One(t, nil, nil) == nil {
+		base, _ := splitPrefix(r.URI)
+		if c.namespaces[base] {
+			return []Finding{{Kind: FindingUndefined, Term: t, Triple: triple, Msg: fmt.Sprintf("%s is not defined by the ontology", t)}}
+		}
+		return nil
+	}
+	d := c.ontology.One(t, NewResource(nsOWL+"deprecated"), nil)
+	if d != nil && d.Object.RawValue() == "true" {
+		return []Finding{{Kind: FindingDeprecated, Term: t, Triple: triple, Msg: fmt.Sprintf("%s is deprecated", t)}}
+	}
+	return nil
+}
+
+// checkDomain reports a subject whose types do not include the domains of
+// the predicate
+func (c *usageChecker) checkDomain(triple *Triple) []Finding {
+	var findings []Finding
+	for _, d := range c.ontology.All(triple.Predicate, NewResource(nsRDFS+"domain"), nil) {
+		if !c.hasType(triple.Subject, d.Object) {
+			findings = append(findings, Finding{Kind: FindingDomain, Term: d.Object, Triple: triple,
+				Msg: fmt.Sprintf("%s is not a %s", triple.Subject, d.Object)})
+		}
+	}
+	return findings
+}
+
+// checkRange reports an object that does not match the ranges of the
+// predicate
+func (c *usageChecker) checkRange(triple *Triple) []Finding {
+	var findings []Finding
+	for _, r := range c.ontology.All(triple.Predicate, NewResource(nsRDFS+"range"), nil) {
+		if !c.inRange(triple.Object, r.Object) {
+			findings = append(findings, Finding{Kind: FindingRange, Term: r.Object, Triple: triple,
+				Msg: fmt.Sprintf("%s is not a %s", triple.Object, r.Object)})
+		}
+	}
+	return findings
+}
+
+// inRange returns whether an object may belong to a range
+func (c *usageChecker) inRange(o Term, rng Term) bool {
+	uri := rng.RawValue()
+	literal, isLiteral := o.(*Literal)
+	switch {
+	case uri == nsRDFS+"Literal":
+		return isLiteral
+	case uri == nsRDF+"langString":
+		return isLiteral && len(literal.Language) > 0
+	case len(uri) > len(nsXSD) && uri[:len(nsXSD)] == nsXSD:
+		if !isLiteral {
+			return false
+		}
+		if literal.Datatype == nil {
+			return len(literal.Language) == 0 && uri == nsXSD+"string"
+		}
+		return literal.Datatype.RawValue() == uri
+	case isLiteral:
+		return false
+	}
+	return c.hasType(o, rng)
+}
+
+// hasType returns whether a node has no explicit type in the data, or has a
+// type that is the class or one of its sub classes
+func (c *usageChecker) hasType(node Term, class Term) bool {
+	types := c.data.All(node, NewResource(nsRDF+"type"), nil)
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if c.superClasses(t.Object)[encodeTerm(class)] {
+			return true
+		}
+	}
+	return false
+}
+
+// superClasses returns the classes a class is a sub class of in the
+// ontology, directly or not, including itself
+func (c *usageChecker) superClasses(class Term) map[string]bool {
+	key := encodeTerm(class)
+	if supers, ok := c.supers[key]; ok {
+		return supers
+	}
+	supers := map[string]bool{key: true}
+	for _, super := range c.ontology.Closure(class, NewResource(nsRDFS+"subClassOf"), false) {
+		supers[encodeTerm(super)] = true
+	}
+	c.supers[key] = supers
+	return supers
+}
@@ -0,0 +1,50 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOntology = `@prefix ex: <http://example.org/ns#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:Agent a owl:Class .
+ex:Person a owl:Class ; rdfs:subClassOf ex:Agent .
+ex:Document a owl:Class .
+ex:name a owl:DatatypeProperty ; rdfs:range xsd:string .
+ex:age a owl:DatatypeProperty ; rdfs:domain ex:Person ; rdfs:range xsd:integer .
+ex:knows a owl:ObjectProperty ; rdfs:domain ex:Agent ; rdfs:range ex:Agent .
+ex:nick a owl:DatatypeProperty ; owl:deprecated true .`
+
+func TestCheckUsage(t *testing.T) {
+	ontology := NewGraph("")
+	require.NoError(t, ontology.ParseString(testOntology, "text/turtle"))
+	data := NewGraph("")
+	require.NoError(t, data.ParseString(`@prefix ex: <http://example.org/ns#> .
+<http://example.org/a> a ex:Person ; ex:name "A" ; ex:age 42 ; ex:knows <http://example.org/b>, <http://example.org/doc>, <http://example.org/c> ; ex:nick "a" .
+<http://example.org/b> a ex:Agent ; ex:age "old" ; ex:colour "red" .
+<http://example.org/doc> a ex:Document ; ex:knows <http://example.org/a> .
+<http://example.org/c> a ex:Robot ; ex:name "C"@en .
+<http://example.org/d> ex:age 1 ; <http://xmlns.com/foaf/0.1/name> "D" .`, "text/turtle"))
+
+	findings := CheckUsage(data, ontology)
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Kind.String()+" "+f.Triple.Subject.RawValue()+" "+f.Term.RawValue())
+	}
+	assert.Equal(t, []string{
+		"range violation http://example.org/a http://example.org/ns#Agent",
+		"range violation http://example.org/a http://example.org/ns#Agent",
+		"deprecated term http://example.org/a http://example.org/ns#nick",
+		"domain violation http://example.org/b http://example.org/ns#Person",
+		"range violation http://example.org/b http://www.w3.org/2001/XMLSchema#integer",
+		"undefined term http://example.org/b http://example.org/ns#colour",
+		"range violation http://example.org/c http://www.w3.org/2001/XMLSchema#string",
+		"undefined term http://example.org/c http://example.org/ns#Robot",
+		"domain violation http://example.org/doc http://example.org/ns#Agent",
+	}, got)
+	assert.Contains(t, findings[2].String(), "deprecated term: <http://example.org/ns#nick> is deprecated")
+}
@@ -0,0 +1,581 @@
+package rdf2go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Base contexts of the W3C Verifiable Credentials data model
+const (
+	CredentialsContextV1 = "https://www.w3.org/2018/credentials/v1"
+	CredentialsContextV2 = "https://www.w3.org/ns/credentials/v2"
+)
+
+const (
+	nsCred = "https://www.w3.org/2018/credentials#"
+	nsSec  = "https://w3id.org/security#"
+	// nsIssuerDependent is the @vocab of the v2 credentials context, used
+	// for terms the issuer did not define
+	nsIssuerDependent = "https://www.w3.org/ns/credentials/issuer-dependent#"
+)
+
+// vcTerms maps the terms defined by the credentials context to their IRIs
+var vcTerms = map[string]string{
+	"VerifiableCredential":   nsCred + "VerifiableCredential",
+	"VerifiablePresentation": nsCred + "VerifiablePresentation",
+	"issuer":                 nsCred + "issuer",
+	"issuanceDate":           nsCred + "issuanceDate",
+	"expirationDate":         nsCred + "expirationDate",
+	"validFrom":              nsCred + "validFrom",
+	"validUntil":             nsCred + "validUntil",
+	"credentialSubject":      nsCred + "credentialSubject",
+	"credentialStatus":       nsCred + "credentialStatus",
+	"credentialSchema":       nsCred + "credentialSchema",
+	"proof":                  nsSec + "proof",
+	"created":                nsDCT + "created",
+	"verificationMethod":     nsSec + "verificationMethod",
+	"proofPurpose":           nsSec + "proofPurpose",
+	"proofValue":             nsSec + "proofValue",
+	"jws":                    nsSec + "jws",
+	"challenge":              nsSec + "challenge",
+	"domain":                 nsSec + "domain",
+	"nonce":                  nsSec + "nonce",
+	"assertionMethod":        nsSec + "assertionMethod",
+	"authentication":         nsSec + "authenticationMethod",
+}
+
+// vcIDTerms lists the terms whose string values are IRIs
+var vcIDTerms = map[string]bool{
+	"issuer":               true,
+	"verificationMethod":   true,
+	"proofPurpose":         true,
+	"controller":           true,
+	"authentication":       true,
+	"assertionMethod":      true,
+	"keyAgreement":         true,
+	"capabilityInvocation": true,
+	"capabilityDelegation": true,
+	"serviceEndpoint":      true,
+	"alsoKnownAs":          true,
+}
+
+// vcJSONTerms lists the terms whose values are kept as rdf:JSON literals
+var vcJSONTerms = map[string]bool{
+	"publicKeyJwk": true,
+}
+
+// vcDateTerms lists the terms whose values are xsd:dateTime literals
+var vcDateTerms = map[string]bool{
+	"issuanceDate":   true,
+	"expirationDate": true,
+	"validFrom":      true,
+	"validUntil":     true,
+	"created":        true,
+}
+
+// Credential is a W3C Verifiable Credential. Properties that are not part of
+// the core data model (including the credential subject claims) are kept as
+// generic JSON values. The proof is kept verbatim so that it round-trips
+// byte for byte through ParseCredential and json.Marshal.
+type Credential struct {
+	Context []interface{} `json:"@context"`
+	ID      string        `json:"id,omitempty"`
+	Type    []string      `json:"type"`
+	Issuer  string        `json:"issuer"`
+	// IssuerProperties holds the properties of an issuer given as an object,
+	// other than its id (e.g. name or image)
+	IssuerProperties  map[string]interface{} `json:"-"`
+	IssuanceDate      string                 `json:"issuanceDate,omitempty"`
+	ExpirationDate    string                 `json:"expirationDate,omitempty"`
+	ValidFrom         string                 `json:"validFrom,omitempty"`
+	ValidUntil        string                 `json:"validUntil,omitempty"`
+	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	Proof             json.RawMessage        `json:"proof,omitempty"`
+}
+
+// ParseCredential decodes a JSON-LD Verifiable Credential and validates its
+// structure
+func ParseCredential(data []byte) (*Credential, error) {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	c := &Credential{}
+	// type and issuer may be given in a short (string) or long form
+	if t, ok := raw["type"]; ok {
+		var single string
+		if json.Unmarshal(t, &single) == nil {
+			raw["type"], _ = json.Marshal([]string{single})
+		}
+	}
+	var issuerProperties map[string]interface{}
+	if i, ok := raw["issuer"]; ok {
+		var issuer map[string]interface{}
+		if json.Unmarshal(i, &issuer) == nil {
+			id, _ := issuer["id"].(string)
+			raw["issuer"], _ = json.Marshal(id)
+			delete(issuer, "id")
+			if len(issuer) > 0 {
+				issuerProperties = issuer
+			}
+		}
+	}
+	if ctx, ok := raw["@context"]; ok {
+		var single string
+		if json.Unmarshal(ctx, &single) == nil {
+			raw["@context"], _ = json.Marshal([]string{single})
+		}
+	}
+	normalized, _ := json.Marshal(raw)
+	err = json.Unmarshal(normalized, c)
+	if err != nil {
+		return nil, err
+	}
+	c.IssuerProperties = issuerProperties
+	return c, c.Validate()
+}
+
+// MarshalJSON encodes the credential, writing the issuer as an object when
+// it has properties
+func (c Credential) MarshalJSON() ([]byte, error) {
+	type plain Credential
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.IssuerProperties) == 0 {
+		return data, err
+	}
+	var raw map[string]json.RawMessage
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	raw["issuer"], err = json.Marshal(c.issuerNode())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(raw)
+}
+
+// issuerNode returns the issuer as a JSON value, a string or an object
+func (c *Credential) issuerNode() interface{} {
+	if len(c.IssuerProperties) == 0 {
+		return c.Issuer
+	}
+	issuer := map[string]interface{}{"id": c.Issuer}
+	for k, v := range c.IssuerProperties {
+		issuer[k] = v
+	}
+	return issuer
+}
+
+// Validate checks that the credential has the structure required by the
+// Verifiable Credentials data model
+func (c *Credential) Validate() error {
+	if len(c.Context) == 0 {
+		return errors.New("credential has no @context")
+	}
+	if c.Context[0] != CredentialsContextV1 && c.Context[0] != CredentialsContextV2 {
+		return fmt.Errorf("the first @context of a credential must be %s or %s", CredentialsContextV1, CredentialsContextV2)
+	}
+	found := false
+	for _, t := range c.Type {
+		if t == "VerifiableCredential" {
+			found = true
+		}
+	}
+	if !found {
+		return errors.New("credential type must include VerifiableCredential")
+	}
+	if len(c.Issuer) == 0 {
+		return errors.New("credential has no issuer")
+	}
+	if c.Context[0] == CredentialsContextV1 && len(c.IssuanceDate) == 0 {
+		return errors.New("credential has no issuanceDate")
+	}
+	if len(c.CredentialSubject) == 0 {
+		return errors.New("credential has no credentialSubject")
+	}
+	if len(c.Proof) > 0 {
+		var proof map[string]interface{}
+		err := json.Unmarshal(c.Proof, &proof)
+		if err != nil {
+			return fmt.Errorf("invalid credential proof: %v", err)
+		}
+		if _, ok := proof["type"]; !ok {
+			return errors.New("credential proof has no type")
+		}
+	}
+	return nil
+}
+
+// vcContext resolves the terms used in a credential, using the built-in terms
+// plus the terms and @vocab found in inline context objects. The v2
+// credentials context has an @vocab, which applies unless an inline context
+// sets another one. Relative IRIs (e.g. #key-1) are resolved against base.
+type vcContext struct {
+	terms map[string]string
+	vocab string
+	base  string
+}
+
+func newVCContext(terms map[string]string, contexts []interface{}) *vcContext {
+	ctx := &vcContext{terms: make(map[string]string)}
+	for k, v := range terms {
+		ctx.terms[k] = v
+	}
+	if len(contexts) > 0 && contexts[0] == CredentialsContextV2 {
+		ctx.vocab = nsIssuerDependent
+	}
+	for _, c := range contexts {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, v := range m {
+			switch v := v.(type) {
+			case string:
+				if k == "@vocab" {
+					ctx.vocab = v
+				} else {
+					ctx.terms[k] = v
+				}
+			case map[string]interface{}:
+				if id, ok := v["@id"].(string); ok {
+					ctx.terms[k] = id
+				}
+			}
+		}
+	}
+	for k, v := range ctx.terms {
+		ctx.terms[k] = ctx.expandPrefix(v)
+	}
+	return ctx
+}
+
+// securityContext returns the context used inside proof blocks and DID
+// documents, where the security vocabulary defines the proof and key types
+// (e.g. Ed25519Signature2020)
+func (ctx *vcContext) securityContext() *vcContext {
+	if len(ctx.vocab) > 0 && ctx.vocab != nsIssuerDependent {
+		return ctx
+	}
+	return &vcContext{terms: ctx.terms, vocab: nsSec, base: ctx.base}
+}
+
+// resolve turns a relative IRI reference into an absolute IRI
+func (ctx *vcContext) resolve(iri string) string {
+	if strings.HasPrefix(iri, "#") {
+		return defrag(ctx.base) + iri
+	}
+	return iri
+}
+
+func (ctx *vcContext) expand(term string) (string, error) {
+	if iri, ok := ctx.terms[term]; ok {
+		return ctx.expandPrefix(iri), nil
+	}
+	if strings.Contains(term, ":") {
+		return ctx.expandPrefix(term), nil
+	}
+	if len(ctx.vocab) > 0 {
+		return ctx.vocab + term, nil
+	}
+	return "", fmt.Errorf("term %q is not defined in the credential context", term)
+}
+
+// expandPrefix expands compact IRIs using prefixes defined in the context
+func (ctx *vcContext) expandPrefix(iri string) string {
+	prefix, local, ok := strings.Cut(iri, ":")
+	if !ok || strings.HasPrefix(local, "//") {
+		return iri
+	}
+	if ns, ok := ctx.terms[prefix]; ok {
+		return ns + local
+	}
+	return iri
+}
+
+// compact returns the term used for an IRI
+func (ctx *vcContext) compact(iri string) string {
+	keys := make([]string, 0, len(ctx.terms))
+	for k := range ctx.terms {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if ctx.terms[k] == iri {
+			return k
+		}
+	}
+	if len(ctx.vocab) > 0 && strings.HasPrefix(iri, ctx.vocab) {
+		return iri[len(ctx.vocab):]
+	}
+	for _, k := range keys {
+		ns := ctx.terms[k]
+		if strings.HasSuffix(ns, "#") || strings.HasSuffix(ns, "/") {
+			if strings.HasPrefix(iri, ns) && len(iri) > len(ns) {
+				return k + ":" + iri[len(ns):]
+			}
+		}
+	}
+	return iri
+}
+
+// Graph converts the credential into triples, including its proof
+func (c *Credential) Graph() (*Graph, error) {
+	ctx := newVCContext(vcTerms, c.Context)
+	g := NewGraph(c.ID)
+	node := map[string]interface{}{
+		"type":              toInterfaces(c.Type),
+		"issuer":            c.issuerNode(),
+		"credentialSubject": c.CredentialSubject,
+	}
+	if len(c.ID) > 0 {
+		node["id"] = c.ID
+	}
+	for k, v := range map[string]string{
+		"issuanceDate":   c.IssuanceDate,
+		"expirationDate": c.ExpirationDate,
+		"validFrom":      c.ValidFrom,
+		"validUntil":     c.ValidUntil,
+	} {
+		if len(v) > 0 {
+			node[k] = v
+		}
+	}
+	if len(c.Proof) > 0 {
+		var proof interface{}
+		err := json.Unmarshal(c.Proof, &proof)
+		if err != nil {
+			return nil, err
+		}
+		node["proof"] = proof
+	}
+	_, err := g.addVCNode(ctx, node)
+	return g, err
+}
+
+func toInterfaces(strs []string) []interface{} {
+	values := make([]interface{}, len(strs))
+	for i, s := range strs {
+		values[i] = s
+	}
+	return values
+}
+
+// addVCNode adds the triples describing a JSON node object and returns its subject
+func (g *Graph) addVCNode(ctx *vcContext, node map[string]interface{}) (Term, error) {
+	var s Term
+	if id, ok := node["id"].(string); ok {
+		s = NewResource(ctx.resolve(id))
+	} else {
+		s = NewAnonNode()
+	}
+	keys := make([]string, 0, len(node))
+	for k := range node {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "id" || k == "@context" {
+			continue
+		}
+		values, ok := node[k].([]interface{})
+		if !ok {
+			values = []interface{}{node[k]}
+		}
+		if k == "type" {
+			for _, v := range values {
+				t, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid type %v", v)
+				}
+				iri, err := ctx.expand(t)
+				if err != nil {
+					return nil, err
+				}
+				g.AddTriple(s, NewResource(nsRDF+"type"), NewResource(iri))
+			}
+			continue
+		}
+		p, err := ctx.expand(k)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			o, err := g.vcValue(ctx, k, v)
+			if err != nil {
+				return nil, err
+			}
+			g.AddTriple(s, NewResource(p), o)
+		}
+	}
+	return s, nil
+}
+
+func (g *Graph) vcValue(ctx *vcContext, key string, v interface{}) (Term, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if value, ok := v["@value"]; ok {
+			lit := &Literal{Value: fmt.Sprint(value)}
+			if lang, ok := v["@language"].(string); ok {
+				lit.Language = lang
+			}
+			if dt, ok := v["@type"].(string); ok {
+				iri, err := ctx.expand(dt)
+				if err != nil {
+					return nil, err
+				}
+				lit.Datatype = NewResource(iri)
+			}
+			return lit, nil
+		}
+		if vcJSONTerms[key] {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			return NewLiteralWithDatatype(string(data), NewResource(nsRDF+"JSON")), nil
+		}
+		if key == "proof" {
+			ctx = ctx.securityContext()
+		}
+		return g.addVCNode(ctx, v)
+	case string:
+		if vcIDTerms[key] {
+			if _, ok := ctx.terms[v]; ok {
+				return NewResource(ctx.terms[v]), nil
+			}
+			return NewResource(ctx.expandPrefix(ctx.resolve(v))), nil
+		}
+		if vcDateTerms[key] {
+			return NewLiteralWithDatatype(v, NewResource(nsXSD+"dateTime")), nil
+		}
+		return NewLiteral(v), nil
+	case bool:
+		return NewLiteralWithDatatype(strconv.FormatBool(v), NewResource(nsXSD+"boolean")), nil
+	case float64:
+		if v == float64(int64(v)) {
+			return NewLiteralWithDatatype(strconv.FormatInt(int64(v), 10), NewResource(nsXSD+"integer")), nil
+		}
+		return NewLiteralWithDatatype(strconv.FormatFloat(v, 'E', -1, 64), NewResource(nsXSD+"double")), nil
+	}
+	return nil, fmt.Errorf("unsupported value %v for %s", v, key)
+}
+
+// CredentialFromGraph rebuilds a credential from its triples. The subject is
+// the credential node, and contexts are the @context entries used to compact
+// property IRIs back to terms (the credentials context is added if missing).
+func CredentialFromGraph(g *Graph, subject Term, contexts ...interface{}) (*Credential, error) {
+	if len(contexts) == 0 || contexts[0] != CredentialsContextV1 && contexts[0] != CredentialsContextV2 {
+		contexts = append([]interface{}{CredentialsContextV1}, contexts...)
+	}
+	ctx := newVCContext(vcTerms, contexts)
+	node := g.vcNode(ctx, subject, make(map[string]bool))
+	c := &Credential{Context: contexts}
+	if r, ok := subject.(*Resource); ok {
+		c.ID = r.URI
+	}
+	for _, t := range g.All(subject, NewResource(nsRDF+"type"), nil) {
+		c.Type = append(c.Type, ctx.compact(t.Object.RawValue()))
+	}
+	sort.Strings(c.Type)
+	c.Issuer = g.value(subject, nsCred+"issuer")
+	if issuer := g.One(subject, NewResource(nsCred+"issuer"), nil); issuer != nil && g.One(issuer.Object, nil, nil) != nil {
+		props := g.vcNode(ctx, issuer.Object, make(map[string]bool))
+		delete(props, "id")
+		c.IssuerProperties = props
+	}
+	c.IssuanceDate = g.value(subject, nsCred+"issuanceDate")
+	c.ExpirationDate = g.value(subject, nsCred+"expirationDate")
+	c.ValidFrom = g.value(subject, nsCred+"validFrom")
+	c.ValidUntil = g.value(subject, nsCred+"validUntil")
+	if cs, ok := node["credentialSubject"].(map[string]interface{}); ok {
+		c.CredentialSubject = cs
+	}
+	if proof, ok := node["proof"]; ok {
+		data, err := json.Marshal(proof)
+		if err != nil {
+			return nil, err
+		}
+		c.Proof = data
+	}
+	return c, c.Validate()
+}
+
+// vcNode builds a JSON node object from the triples of a subject
+func (g *Graph) vcNode(ctx *vcContext, s Term, visited map[string]bool) map[string]interface{} {
+	node := make(map[string]interface{})
+	if r, ok := s.(*Resource); ok {
+		node["id"] = r.URI
+	}
+	key := encodeTerm(s)
+	if visited[key] {
+		return node
+	}
+	visited[key] = true
+	for _, t := range g.All(s, nil, nil) {
+		var k string
+		var v interface{}
+		if t.Predicate.RawValue() == nsRDF+"type" {
+			k = "type"
+			v = ctx.compact(t.Object.RawValue())
+		} else {
+			k = ctx.compact(t.Predicate.RawValue())
+			v = g.vcJSONValue(ctx, k, t.Object, visited)
+		}
+		if existing, ok := node[k]; ok {
+			list, ok := existing.([]interface{})
+			if !ok {
+				list = []interface{}{existing}
+			}
+			node[k] = append(list, v)
+		} else {
+			node[k] = v
+		}
+	}
+	return node
+}
+
+func (g *Graph) vcJSONValue(ctx *vcContext, key string, o Term, visited map[string]bool) interface{} {
+	switch o := o.(type) {
+	case *Literal:
+		if o.Datatype == nil || len(o.Language) > 0 {
+			if len(o.Language) > 0 {
+				return map[string]interface{}{"@value": o.Value, "@language": o.Language}
+			}
+			return o.Value
+		}
+		switch o.Datatype.RawValue() {
+		case nsXSD + "dateTime", nsXSD + "string":
+			return o.Value
+		case nsXSD + "boolean":
+			return o.Value == "true"
+		case nsXSD + "integer", nsXSD + "double":
+			if f, err := strconv.ParseFloat(o.Value, 64); err == nil {
+				return f
+			}
+		case nsRDF + "JSON":
+			var v interface{}
+			if json.Unmarshal([]byte(o.Value), &v) == nil {
+				return v
+			}
+		}
+		return map[string]interface{}{"@value": o.Value, "@type": ctx.compact(o.Datatype.RawValue())}
+	case *Resource:
+		if vcIDTerms[key] {
+			return ctx.compact(o.URI)
+		}
+		if g.One(o, nil, nil) == nil {
+			return map[string]interface{}{"id": o.URI}
+		}
+	}
+	if key == "proof" {
+		ctx = ctx.securityContext()
+	}
+	return g.vcNode(ctx, o, visited)
+}
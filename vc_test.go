@@ -0,0 +1,127 @@
+package rdf2go
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const exampleCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    {"ex": "https://example.org/vocab#", "degree": "ex:degree", "name": "http://schema.org/name"}
+  ],
+  "id": "http://example.edu/credentials/3732",
+  "type": ["VerifiableCredential", "ex:UniversityDegreeCredential"],
+  "issuer": {"id": "https://example.edu/issuers/14"},
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {"type": "ex:BachelorDegree", "name": "Bachelor of Science and Arts"}
+  },
+  "proof": {"type":"Ed25519Signature2020","created":"2021-11-13T18:19:39Z","verificationMethod":"https://example.edu/issuers/14#key-1","proofPurpose":"assertionMethod","proofValue":"z58DAdFfa9"}
+}`
+
+func TestParseCredential(t *testing.T) {
+	c, err := ParseCredential([]byte(exampleCredential))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.edu/issuers/14", c.Issuer)
+	assert.Equal(t, []string{"VerifiableCredential", "ex:UniversityDegreeCredential"}, c.Type)
+
+	// the proof block round-trips unchanged
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	again, err := ParseCredential(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(c.Proof), string(again.Proof))
+	assert.Equal(t, `{"type":"Ed25519Signature2020","created":"2021-11-13T18:19:39Z","verificationMethod":"https://example.edu/issuers/14#key-1","proofPurpose":"assertionMethod","proofValue":"z58DAdFfa9"}`, string(again.Proof))
+}
+
+func TestCredentialValidate(t *testing.T) {
+	for _, data := range []string{
+		`{"@context": ["https://example.org/ctx"], "type": "VerifiableCredential", "issuer": "a", "issuanceDate": "2010-01-01T00:00:00Z", "credentialSubject": {"id": "b"}}`,
+		`{"@context": "https://www.w3.org/2018/credentials/v1", "type": "Other", "issuer": "a", "issuanceDate": "2010-01-01T00:00:00Z", "credentialSubject": {"id": "b"}}`,
+		`{"@context": "https://www.w3.org/2018/credentials/v1", "type": "VerifiableCredential", "issuanceDate": "2010-01-01T00:00:00Z", "credentialSubject": {"id": "b"}}`,
+		`{"@context": "https://www.w3.org/2018/credentials/v1", "type": "VerifiableCredential", "issuer": "a", "credentialSubject": {"id": "b"}}`,
+		`{"@context": "https://www.w3.org/2018/credentials/v1", "type": "VerifiableCredential", "issuer": "a", "issuanceDate": "2010-01-01T00:00:00Z"}`,
+		`{"@context": "https://www.w3.org/2018/credentials/v1", "type": "VerifiableCredential", "issuer": "a", "issuanceDate": "2010-01-01T00:00:00Z", "credentialSubject": {"id": "b"}, "proof": {"proofValue": "x"}}`,
+	} {
+		_, err := ParseCredential([]byte(data))
+		assert.Error(t, err, data)
+	}
+	_, err := ParseCredential([]byte(`{"@context": "https://www.w3.org/ns/credentials/v2", "type": "VerifiableCredential", "issuer": "a", "credentialSubject": {"id": "b"}}`))
+	assert.NoError(t, err)
+}
+
+func TestCredentialGraph(t *testing.T) {
+	c, err := ParseCredential([]byte(exampleCredential))
+	assert.NoError(t, err)
+	g, err := c.Graph()
+	assert.NoError(t, err)
+
+	vc := NewResource("http://example.edu/credentials/3732")
+	assert.NotNil(t, g.One(vc, NewResource(nsRDF+"type"), NewResource(nsCred+"VerifiableCredential")))
+	assert.NotNil(t, g.One(vc, NewResource(nsRDF+"type"), NewResource("https://example.org/vocab#UniversityDegreeCredential")))
+	assert.NotNil(t, g.One(vc, NewResource(nsCred+"issuer"), NewResource("https://example.edu/issuers/14")))
+	assert.NotNil(t, g.One(vc, NewResource(nsCred+"issuanceDate"), NewLiteralWithDatatype("2010-01-01T19:23:24Z", NewResource(nsXSD+"dateTime"))))
+	holder := NewResource("did:example:ebfeb1f712ebc6f1c276e12ec21")
+	assert.NotNil(t, g.One(vc, NewResource(nsCred+"credentialSubject"), holder))
+	degree := g.One(holder, NewResource("https://example.org/vocab#degree"), nil)
+	assert.NotNil(t, degree)
+	assert.NotNil(t, g.One(degree.Object, NewResource(nsSDO+"name"), NewLiteral("Bachelor of Science and Arts")))
+	proof := g.One(vc, NewResource(nsSec+"proof"), nil)
+	assert.NotNil(t, proof)
+	assert.NotNil(t, g.One(proof.Object, NewResource(nsSec+"proofPurpose"), NewResource(nsSec+"assertionMethod")))
+
+	back, err := CredentialFromGraph(g, vc, c.Context...)
+	assert.NoError(t, err)
+	assert.Equal(t, c.Issuer, back.Issuer)
+	assert.Equal(t, c.IssuanceDate, back.IssuanceDate)
+	assert.Equal(t, []string{"VerifiableCredential", "ex:UniversityDegreeCredential"}, back.Type)
+	assert.JSONEq(t, string(c.Proof), string(back.Proof))
+	subject, _ := json.Marshal(c.CredentialSubject)
+	backSubject, _ := json.Marshal(back.CredentialSubject)
+	assert.JSONEq(t, string(subject), string(backSubject))
+}
+
+func TestCredentialUndefinedTerm(t *testing.T) {
+	c, err := ParseCredential([]byte(`{"@context": "https://www.w3.org/2018/credentials/v1", "type": "VerifiableCredential", "issuer": "a", "issuanceDate": "2010-01-01T00:00:00Z", "credentialSubject": {"id": "b", "unknown": 1}}`))
+	assert.NoError(t, err)
+	_, err = c.Graph()
+	assert.Error(t, err)
+}
+
+func TestCredentialV2Vocab(t *testing.T) {
+	c, err := ParseCredential([]byte(`{"@context": "https://www.w3.org/ns/credentials/v2", "type": "VerifiableCredential", "issuer": "https://example.edu/issuers/14", "credentialSubject": {"name": "Alice"}}`))
+	assert.NoError(t, err)
+	g, err := c.Graph()
+	assert.NoError(t, err)
+	assert.NotNil(t, g.One(nil, NewResource(nsIssuerDependent+"name"), NewLiteral("Alice")))
+
+	back, err := CredentialFromGraph(g, g.One(nil, NewResource(nsCred+"issuer"), nil).Subject, c.Context...)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "Alice"}, back.CredentialSubject)
+}
+
+func TestCredentialIssuerObject(t *testing.T) {
+	data := `{"@context": ["https://www.w3.org/2018/credentials/v1", {"name": "http://schema.org/name"}], "type": "VerifiableCredential", "issuer": {"id": "https://example.edu/issuers/14", "name": "Example University"}, "issuanceDate": "2010-01-01T00:00:00Z", "credentialSubject": {"id": "did:example:b"}}`
+	c, err := ParseCredential([]byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.edu/issuers/14", c.Issuer)
+	assert.Equal(t, map[string]interface{}{"name": "Example University"}, c.IssuerProperties)
+
+	out, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"issuer":{"id":"https://example.edu/issuers/14","name":"Example University"}`)
+
+	g, err := c.Graph()
+	assert.NoError(t, err)
+	issuer := NewResource("https://example.edu/issuers/14")
+	assert.NotNil(t, g.One(issuer, NewResource(nsSDO+"name"), NewLiteral("Example University")))
+
+	vc := g.One(nil, NewResource(nsCred+"issuer"), issuer).Subject
+	back, err := CredentialFromGraph(g, vc, c.Context...)
+	assert.NoError(t, err)
+	assert.Equal(t, c.IssuerProperties, back.IssuerProperties)
+}
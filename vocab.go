@@ -0,0 +1,39 @@
+package rdf2go
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed vocab/*.ttl
+var builtinVocabs embed.FS
+
+// BuiltinVocabs returns the names of the vocabularies embedded in the
+// package, which LoadBuiltinVocab loads, sorted
+func BuiltinVocabs() []string {
+	entries, _ := builtinVocabs.ReadDir("vocab")
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadBuiltinVocab adds a core vocabulary embedded in the package to the
+// graph, without network access, e.g. to give a reasoner or validator the
+// classes and properties of FOAF. The name is one of BuiltinVocabs: "foaf",
+// "owl", "rdf", "rdfs", "skos" or "xsd". The embedded copies hold the
+// classes, properties and datatypes of the vocabularies with their labels,
+// hierarchy, domains and ranges, but not their documentation.
+func (g *Graph) LoadBuiltinVocab(name string) error {
+	data, err := builtinVocabs.ReadFile("vocab/" + name + ".ttl")
+	if err != nil {
+		return fmt.Errorf("unknown vocabulary %q", name)
+	}
+	return g.Parse(bytes.NewReader(data), "text/turtle")
+}
@@ -0,0 +1,32 @@
+package rdf2go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBuiltinVocab(t *testing.T) {
+	assert.Equal(t, []string{"foaf", "owl", "rdf", "rdfs", "skos", "xsd"}, BuiltinVocabs())
+	for _, name := range BuiltinVocabs() {
+		g := NewGraph("")
+		require.NoError(t, g.LoadBuiltinVocab(name), name)
+		assert.NotZero(t, g.Len(), name)
+	}
+
+	g := NewGraph("")
+	require.NoError(t, g.LoadBuiltinVocab("foaf"))
+	require.NoError(t, g.LoadBuiltinVocab("rdfs"))
+	person := g.OWLOntology().Class(nsFOAF + "Person")
+	require.NotNil(t, person)
+	assert.Equal(t, "Person", person.Label)
+	assert.Contains(t, person.SubClassOf, nsFOAF+"Agent")
+	knows := g.OWLOntology().Property(nsFOAF + "knows")
+	require.NotNil(t, knows)
+	assert.Equal(t, []string{nsFOAF + "Person"}, knows.Domain)
+	assert.NotNil(t, g.One(NewResource(nsFOAF+"Agent"), NewResource(nsRDFS+"isDefinedBy"), NewResource(nsFOAF)))
+	assert.NotNil(t, g.One(NewResource(nsRDFS+"label"), NewResource(nsRDF+"type"), NewResource(nsRDF+"Property")))
+
+	assert.EqualError(t, g.LoadBuiltinVocab("dc"), `unknown vocabulary "dc"`)
+}
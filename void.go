@@ -0,0 +1,97 @@
+package rdf2go
+
+import (
+	"io"
+	"sort"
+	"strconv"
+)
+
+// DatasetStatistics describes the size of a graph, as written to VoID
+// descriptions so that loaders can size their structures before reading
+// the data
+type DatasetStatistics struct {
+	Triples    int
+	Subjects   int
+	Predicates int
+	Objects    int
+	// Classes is the number of distinct objects of rdf:type
+	Classes int
+	// Vocabularies holds the namespaces of the predicates and classes, sorted
+	Vocabularies []string
+}
+
+// Statistics counts the triples and distinct terms of the graph
+func (g *Graph) Statistics() *DatasetStatistics {
+	subjects, predicates := make(map[string]bool), make(map[string]bool)
+	objects, classes := make(map[string]bool), make(map[string]bool)
+	vocabularies := make(map[string]bool)
+	st := &DatasetStatistics{}
+	for triple := range g.IterTriples() {
+		st.Triples++
+		subjects[encodeTerm(triple.Subject)] = true
+		objects[encodeTerm(triple.Object)] = true
+		p := triple.Predicate.RawValue()
+		if !predicates[p] {
+			predicates[p] = true
+			ns, _ := splitPrefix(p)
+			vocabularies[ns] = true
+		}
+		if _, ok := triple.Object.(*Resource); ok && p == nsRDF+"type" {
+			class := triple.Object.RawValue()
+			if !classes[class] {
+				classes[class] = true
+				ns, _ := splitPrefix(class)
+				vocabularies[ns] = true
+			}
+		}
+	}
+	st.Subjects, st.Predicates, st.Objects, st.Classes = len(subjects), len(predicates), len(objects), len(classes)
+	delete(vocabularies, "")
+	for ns := range vocabularies {
+		st.Vocabularies = append(st.Vocabularies, ns)
+	}
+	sort.Strings(st.Vocabularies)
+	return st
+}
+
+// AddVoIDStatistics adds the triples describing the statistics of a
+// void:Dataset to the graph
+func (g *Graph) AddVoIDStatistics(dataset Term, st *DatasetStatistics) {
+	g.AddTriple(dataset, NewResource(nsRDF+"type"), NewResource(nsVOID+"Dataset"))
+	count := func(p string, n int) {
+		g.AddTriple(dataset, NewResource(nsVOID+p), NewLiteralWithDatatype(strconv.Itoa(n), NewResource(nsXSD+"integer")))
+	}
+	count("triples", st.Triples)
+	count("distinctSubjects", st.Subjects)
+	count("properties", st.Predicates)
+	count("distinctObjects", st.Objects)
+	count("classes", st.Classes)
+	for _, ns := range st.Vocabularies {
+		g.addResource(dataset, nsVOID+"vocabulary", ns)
+	}
+}
+
+// VoIDStatistics reads the statistics of a void:Dataset from the graph
+func (g *Graph) VoIDStatistics(dataset Term) *DatasetStatistics {
+	st := &DatasetStatistics{Vocabularies: g.resourceValues(dataset, nsVOID+"vocabulary")}
+	st.Triples, _ = strconv.Atoi(g.value(dataset, nsVOID+"triples"))
+	st.Subjects, _ = strconv.Atoi(g.value(dataset, nsVOID+"distinctSubjects"))
+	st.Predicates, _ = strconv.Atoi(g.value(dataset, nsVOID+"properties"))
+	st.Objects, _ = strconv.Atoi(g.value(dataset, nsVOID+"distinctObjects"))
+	st.Classes, _ = strconv.Atoi(g.value(dataset, nsVOID+"classes"))
+	return st
+}
+
+// SerializeWithVoID serializes the graph to w like Serialize, and writes
+// the VoID description of its statistics to sidecar in the same format,
+// with the graph as void:Dataset
+func (g *Graph) SerializeWithVoID(w io.Writer, sidecar io.Writer, mime string) error {
+	err := g.Serialize(w, mime)
+	if err != nil {
+		return err
+	}
+	void := NewGraph(g.uri)
+	void.SetPrefix("void", nsVOID)
+	void.AddVoIDStatistics(g.term, g.Statistics())
+	return void.Serialize(sidecar, mime)
+}
@@ -0,0 +1,42 @@
+package rdf2go
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoIDStatistics(t *testing.T) {
+	g := NewGraph("http://example.org/data")
+	alice, bob := NewResource("http://example.org/alice"), NewResource("http://example.org/bob")
+	g.AddTriple(alice, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person"))
+	g.AddTriple(bob, NewResource(nsRDF+"type"), NewResource(nsFOAF+"Person"))
+	g.AddTriple(alice, NewResource(nsFOAF+"knows"), bob)
+	g.AddTriple(alice, NewResource(nsSDO+"name"), NewLiteral("Alice"))
+
+	st := g.Statistics()
+	assert.Equal(t, &DatasetStatistics{
+		Triples:      4,
+		Subjects:     2,
+		Predicates:   3,
+		Objects:      3,
+		Classes:      1,
+		Vocabularies: []string{nsSDO, nsRDF, nsFOAF},
+	}, st)
+
+	var data, sidecar bytes.Buffer
+	require.NoError(t, g.SerializeWithVoID(&data, &sidecar, "text/turtle"))
+	assert.Contains(t, sidecar.String(), "void:triples")
+	loaded := NewGraph("http://example.org/data")
+	require.NoError(t, loaded.Parse(&data, "text/turtle"))
+	assert.Equal(t, 4, loaded.Len())
+
+	void := NewGraph("http://example.org/data")
+	require.NoError(t, void.Parse(&sidecar, "text/turtle"))
+	assert.NotNil(t, void.One(g.Term(), NewResource(nsRDF+"type"), NewResource(nsVOID+"Dataset")))
+	assert.Equal(t, st, void.VoIDStatistics(g.Term()))
+
+	assert.Equal(t, &DatasetStatistics{}, NewGraph(testUri).Statistics())
+}
@@ -0,0 +1,97 @@
+package rdf2go
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WarningKind identifies the kind of issue reported by a Warning
+type WarningKind int
+
+const (
+	// WarnRelativeIRI reports a relative IRI that was resolved against the base
+	WarnRelativeIRI WarningKind = iota + 1
+	// WarnUndefinedPrefix reports an IRI that looks like a prefixed name
+	// using an undefined prefix, and was kept as is
+	WarnUndefinedPrefix
+	// WarnDuplicateTriple reports a triple appearing more than once in the input
+	WarnDuplicateTriple
+	// WarnLanguageCase reports a language tag whose language is not in lowercase
+	WarnLanguageCase
+)
+
+var warningKindNames = map[WarningKind]string{
+	WarnRelativeIRI:     "relative IRI",
+	WarnUndefinedPrefix: "undefined prefix",
+	WarnDuplicateTriple: "duplicate triple",
+	WarnLanguageCase:    "language tag case",
+}
+
+func (k WarningKind) String() string {
+	if name, ok := warningKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("WarningKind(%d)", int(k))
+}
+
+// Warning describes a recoverable issue found while parsing. Line and Col
+// start at 1; both are 0 when the position is not known.
+type Warning struct {
+	Line int
+	Col  int
+	Kind WarningKind
+	Msg  string
+}
+
+func (w Warning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s: %s", w.Line, w.Col, w.Kind, w.Msg)
+	}
+	return fmt.Sprintf("%s: %s", w.Kind, w.Msg)
+}
+
+// SetWarningHandler sets a function called for every warning found while
+// parsing data into the graph. Warnings are not reported if handler is nil.
+func (g *Graph) SetWarningHandler(handler func(Warning)) {
+	g.warn = handler
+}
+
+// duplicateChecker reports triples that were already seen
+type duplicateChecker map[string]bool
+
+func (d duplicateChecker) seen(s Term, p Term, o Term) bool {
+	key := encodeTerm(s) + " " + encodeTerm(p) + " " + encodeTerm(o)
+	if d[key] {
+		return true
+	}
+	d[key] = true
+	return false
+}
+
+// warnLanguageCase returns a warning message if the primary language subtag
+// of a language tag is not in lowercase, or an empty string otherwise
+func warnLanguageCase(lang string) string {
+	primary := lang
+	if i := strings.IndexByte(lang, '-'); i >= 0 {
+		primary = lang[:i]
+	}
+	if primary == strings.ToLower(primary) {
+		return ""
+	}
+	return fmt.Sprintf("language tag %q should be written %q", lang, strings.ToLower(primary)+lang[len(primary):])
+}
+
+// warnTriples checks the triples produced by the JSON-LD parser, which
+// already removes duplicates and lowercases language tags, for IRIs using
+// undefined prefixes
+func warnTriples(triples []*Triple, warn func(Warning)) {
+	for _, t := range triples {
+		for _, term := range []Term{t.Subject, t.Predicate, t.Object} {
+			if r, ok := term.(*Resource); ok {
+				if _, ok := commonPrefixes[iriScheme(r.URI)]; ok {
+					warn(Warning{Kind: WarnUndefinedPrefix, Msg: fmt.Sprintf("%q uses an undefined prefix", r.URI)})
+				}
+			}
+		}
+	}
+}
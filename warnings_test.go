@@ -0,0 +1,51 @@
+package rdf2go
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWarningsTurtle(t *testing.T) {
+	var warnings []Warning
+	g := NewGraph(testUri)
+	g.SetWarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	})
+	err := g.Parse(strings.NewReader("<#a> <http://b> \"x\"@EN-gb .\n<#a> <http://b> \"x\"@EN-gb ."), "text/turtle")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`line 1, column 1: relative IRI: resolved "#a" against "` + testUri + `"`,
+		`line 1, column 20: language tag case: language tag "EN-gb" should be written "en-gb"`,
+		`line 2, column 1: relative IRI: resolved "#a" against "` + testUri + `"`,
+		`line 2, column 20: language tag case: language tag "EN-gb" should be written "en-gb"`,
+		`line 2, column 26: duplicate triple: <` + testUri + `#a> <http://b> "x"@EN-gb .`,
+	}, warningStrings(warnings))
+}
+
+func TestParseWarningsJSONLD(t *testing.T) {
+	var warnings []Warning
+	g := NewGraph(testUri)
+	g.SetWarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	})
+	err := g.Parse(strings.NewReader(`{"@id": "http://a", "foaf:name": "x", "http://b": "y"}`), "application/ld+json")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`undefined prefix: "foaf:name" uses an undefined prefix`}, warningStrings(warnings))
+}
+
+func TestParseWithoutWarningHandler(t *testing.T) {
+	g := NewGraph(testUri)
+	err := g.Parse(strings.NewReader("<#a> <http://b> \"x\"@EN ."), "text/turtle")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.Len())
+}
+
+func warningStrings(warnings []Warning) []string {
+	var out []string
+	for _, w := range warnings {
+		out = append(out, w.String())
+	}
+	return out
+}
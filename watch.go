@@ -0,0 +1,157 @@
+package rdf2go
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FileWatcher keeps a graph in sync with a file, see WatchFile
+type FileWatcher struct {
+	path     string
+	mime     string
+	uri      string
+	onReload func(*Graph)
+	graph    atomic.Pointer[Graph]
+
+	mu      sync.Mutex
+	err     error
+	modTime time.Time
+	size    int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// WatchFile parses the file at path into a new graph, and parses it again
+// every time the file changes, swapping the graph returned by the Graph
+// method of the watcher and calling onReload (if not nil) with the new graph.
+// Changes are detected by polling the modification time and size of the file,
+// which needs no platform specific notification API. The file is checked for
+// changes every interval (one second by default), and is only read again once
+// it has stayed the same for a whole interval, so that a file being written
+// is not parsed halfway. If the file cannot be parsed again, or holds no
+// triples (e.g. after being truncated), the previous graph is kept and the
+// error is available from the Err method.
+func WatchFile(path string, onReload func(*Graph), interval ...time.Duration) (*FileWatcher, error) {
+	mime := mimeRdfExt[filepath.Ext(path)]
+	if _, ok := mimeParser[mime]; !ok {
+		return nil, fmt.Errorf("%s: unsupported file format", path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &FileWatcher{
+		path:     path,
+		mime:     mime,
+		uri:      (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String(),
+		onReload: onReload,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	_, err = w.reload(info)
+	if err != nil {
+		return nil, err
+	}
+	every := time.Second
+	if len(interval) > 0 && interval[0] > 0 {
+		every = interval[0]
+	}
+	go w.poll(every)
+	return w, nil
+}
+
+// Graph returns the graph parsed from the latest version of the file
+func (w *FileWatcher) Graph() *Graph {
+	return w.graph.Load()
+}
+
+// Err returns the error of the last reload, or nil if it succeeded
+func (w *FileWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Close stops watching the file
+func (w *FileWatcher) Close() {
+	select {
+	case <-w.stop:
+	default:
+		close(w.stop)
+	}
+	<-w.done
+}
+
+func (w *FileWatcher) poll(interval time.Duration) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// pending is the changed version of the file seen at the previous tick
+	var pending os.FileInfo
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+		info, err := os.Stat(w.path)
+		w.mu.Lock()
+		changed := err == nil && !sameVersion(info, w.modTime, w.size)
+		if err != nil {
+			w.err = err
+		}
+		w.mu.Unlock()
+		if !changed {
+			pending = nil
+			continue
+		}
+		if pending == nil || !sameVersion(info, pending.ModTime(), pending.Size()) {
+			pending = info
+			continue
+		}
+		pending = nil
+		g, err := w.reload(info)
+		if err == nil && w.onReload != nil {
+			w.onReload(g)
+		}
+	}
+}
+
+// reload parses the file and swaps the graph, recording info as the version
+// of the file that was read
+func (w *FileWatcher) reload(info os.FileInfo) (*Graph, error) {
+	g := NewGraph(w.uri)
+	f, err := os.Open(w.path)
+	if err == nil {
+		err = g.Parse(f, w.mime)
+		f.Close()
+	}
+	if err == nil && g.Len() == 0 && w.graph.Load() != nil {
+		err = fmt.Errorf("%s: no triples found, keeping the previous graph", w.path)
+	}
+	w.mu.Lock()
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+	w.err = err
+	w.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	w.graph.Store(g)
+	return g, nil
+}
+
+// sameVersion reports whether info has the given modification time and size
+func sameVersion(info os.FileInfo, modTime time.Time, size int64) bool {
+	return info.ModTime().Equal(modTime) && info.Size() == size
+}
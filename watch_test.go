@@ -0,0 +1,66 @@
+package rdf2go
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.ttl")
+	require.NoError(t, os.WriteFile(path, []byte(simpleTurtle), 0644))
+
+	reloads := make(chan *Graph, 1)
+	w, err := WatchFile(path, func(g *Graph) { reloads <- g }, 5*time.Millisecond)
+	require.NoError(t, err)
+	defer w.Close()
+	assert.Equal(t, 2, w.Graph().Len())
+	assert.True(t, strings.HasPrefix(w.Graph().URI(), "file:///"))
+
+	require.NoError(t, os.WriteFile(path, []byte(simpleTurtle+"\n<#me> <http://xmlns.com/foaf/0.1/nick> \"t\" ."), 0644))
+	select {
+	case g := <-reloads:
+		assert.Equal(t, 3, g.Len())
+		assert.Equal(t, g, w.Graph())
+	case <-time.After(5 * time.Second):
+		t.Fatal("graph was not reloaded")
+	}
+
+	// invalid data keeps the previous graph
+	require.NoError(t, os.WriteFile(path, []byte("<a> <b"), 0644))
+	assert.Eventually(t, func() bool { return w.Err() != nil }, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 3, w.Graph().Len())
+
+	// so does an empty file
+	require.NoError(t, os.WriteFile(path, []byte(simpleTurtle), 0644))
+	select {
+	case g := <-reloads:
+		assert.Equal(t, 2, g.Len())
+	case <-time.After(5 * time.Second):
+		t.Fatal("graph was not reloaded")
+	}
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+	assert.Eventually(t, func() bool { return w.Err() != nil }, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 2, w.Graph().Len())
+}
+
+func TestWatchFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := WatchFile(filepath.Join(dir, "missing.ttl"), nil)
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte(simpleTurtle), 0644))
+	_, err = WatchFile(path, nil)
+	assert.Error(t, err)
+
+	path = filepath.Join(dir, "bad.ttl")
+	require.NoError(t, os.WriteFile(path, []byte("<a> <b"), 0644))
+	_, err = WatchFile(path, nil)
+	assert.Error(t, err)
+}
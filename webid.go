@@ -0,0 +1,50 @@
+package rdf2go
+
+import "sort"
+
+// Profile describes the WebID profile of an agent, as used by Solid. Storage
+// and OIDCIssuer are sorted.
+type Profile struct {
+	WebID            string
+	Name             string
+	Image            string
+	Storage          []string
+	OIDCIssuer       []string
+	PublicTypeIndex  string
+	PrivateTypeIndex string
+	// Graph holds the profile document the profile was read from
+	Graph *Graph
+}
+
+// FetchWebIDProfile loads the profile document of a WebID and returns the
+// profile it describes
+func FetchWebIDProfile(webid string, skipVerify ...bool) (*Profile, error) {
+	g := NewGraph(defrag(webid), skipVerify...)
+	err := g.LoadURI(webid)
+	if err != nil {
+		return nil, err
+	}
+	return g.WebIDProfile(webid), nil
+}
+
+// WebIDProfile reads the profile of a WebID from the graph
+func (g *Graph) WebIDProfile(webid string) *Profile {
+	s := NewResource(webid)
+	p := &Profile{
+		WebID:            webid,
+		Name:             g.firstValue(s, nsFOAF+"name", nsVCard+"fn"),
+		Image:            g.firstValue(s, nsFOAF+"img", nsVCard+"hasPhoto", nsFOAF+"depiction"),
+		PublicTypeIndex:  g.value(s, nsSolid+"publicTypeIndex"),
+		PrivateTypeIndex: g.value(s, nsSolid+"privateTypeIndex"),
+		Graph:            g,
+	}
+	for _, t := range g.All(s, NewResource(nsPIM+"storage"), nil) {
+		p.Storage = append(p.Storage, t.Object.RawValue())
+	}
+	for _, t := range g.All(s, NewResource(nsSolid+"oidcIssuer"), nil) {
+		p.OIDCIssuer = append(p.OIDCIssuer, t.Object.RawValue())
+	}
+	sort.Strings(p.Storage)
+	sort.Strings(p.OIDCIssuer)
+	return p
+}
@@ -0,0 +1,51 @@
+package rdf2go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchWebIDProfile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/invalid/card" {
+			w.Header().Add("Content-Type", "text/turtle")
+			w.Write([]byte("<#me> a"))
+			return
+		}
+		if req.URL.Path != "/profile/card" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Header().Add("Content-Type", "text/turtle")
+		w.Write([]byte(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix pim: <http://www.w3.org/ns/pim/space#> .
+@prefix solid: <http://www.w3.org/ns/solid/terms#> .
+<#me> a foaf:Person ;
+  foaf:name "Alice" ;
+  foaf:img <avatar.png> ;
+  pim:storage </b/>, </a/> ;
+  solid:oidcIssuer <https://idp2.example>, <https://idp.example> ;
+  solid:publicTypeIndex <../settings/publicTypeIndex.ttl> .`))
+	}))
+	defer ts.Close()
+
+	webid := ts.URL + "/profile/card#me"
+	p, err := FetchWebIDProfile(webid)
+	assert.NoError(t, err)
+	assert.Equal(t, webid, p.WebID)
+	assert.Equal(t, "Alice", p.Name)
+	assert.Equal(t, ts.URL+"/profile/avatar.png", p.Image)
+	assert.Equal(t, []string{ts.URL + "/a/", ts.URL + "/b/"}, p.Storage)
+	assert.Equal(t, []string{"https://idp.example", "https://idp2.example"}, p.OIDCIssuer)
+	assert.Equal(t, ts.URL+"/settings/publicTypeIndex.ttl", p.PublicTypeIndex)
+	assert.Equal(t, "", p.PrivateTypeIndex)
+	assert.Equal(t, 8, p.Graph.Len())
+
+	_, err = FetchWebIDProfile(ts.URL + "/missing#me")
+	assert.Error(t, err)
+	_, err = FetchWebIDProfile(ts.URL + "/invalid/card#me")
+	assert.Error(t, err)
+}
@@ -0,0 +1,336 @@
+package rdf2go
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XSDDuration is the value of an xsd:duration literal. Months holds the
+// years and months of the duration, and Duration its days and time, as days
+// always last 24 hours in XSD. Both are negative for a negative duration.
+type XSDDuration struct {
+	Months   int
+	Duration time.Duration
+}
+
+var xsdDurationRe = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseXSDDuration parses the lexical form of an xsd:duration, e.g.
+// P1Y2M3DT4H5M6.5S
+func ParseXSDDuration(s string) (XSDDuration, error) {
+	m := xsdDurationRe.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "-P" || strings.HasSuffix(s, "T") {
+		return XSDDuration{}, fmt.Errorf("invalid duration %q", s)
+	}
+	n := func(i int) int64 {
+		v, _ := strconv.ParseInt(m[i], 10, 64)
+		return v
+	}
+	d := XSDDuration{Months: int(n(2)*12 + n(3))}
+	d.Duration = time.Duration(n(4))*24*time.Hour + time.Duration(n(5))*time.Hour + time.Duration(n(6))*time.Minute
+	if len(m[7]) > 0 {
+		seconds, err := time.ParseDuration(m[7] + "s")
+		if err != nil {
+			return XSDDuration{}, fmt.Errorf("invalid duration %q", s)
+		}
+		d.Duration += seconds
+	}
+	if m[1] == "-" {
+		d.Months, d.Duration = -d.Months, -d.Duration
+	}
+	return d, nil
+}
+
+// String returns the canonical lexical form of the duration
+func (d XSDDuration) String() string {
+	months, rest := d.Months, d.Duration
+	sign := ""
+	if months < 0 || rest < 0 {
+		sign, months, rest = "-", -months, -rest
+	}
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if months/12 > 0 {
+		b.WriteString(strconv.Itoa(months/12) + "Y")
+	}
+	if months%12 > 0 {
+		b.WriteString(strconv.Itoa(months%12) + "M")
+	}
+	day := 24 * time.Hour
+	if rest >= day {
+		b.WriteString(strconv.FormatInt(int64(rest/day), 10) + "D")
+		rest %= day
+	}
+	if rest > 0 || months == 0 && d.Duration == 0 {
+		b.WriteString("T")
+		if rest >= time.Hour {
+			b.WriteString(strconv.FormatInt(int64(rest/time.Hour), 10) + "H")
+			rest %= time.Hour
+		}
+		if rest >= time.Minute {
+			b.WriteString(strconv.FormatInt(int64(rest/time.Minute), 10) + "M")
+			rest %= time.Minute
+		}
+		if rest > 0 || b.Len() == len(sign)+2 {
+			b.WriteString(strconv.FormatFloat(rest.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return b.String()
+}
+
+// AddTo returns the time t plus the duration: its months are added first,
+// keeping the day within the resulting month as XSD does (January 31 plus
+// one month is the last day of February), then its days and time
+func (d XSDDuration) AddTo(t time.Time) time.Time {
+	months := t.Year()*12 + int(t.Month()) - 1 + d.Months
+	year, m := months/12, months%12
+	if m < 0 {
+		year, m = year-1, m+12
+	}
+	month := time.Month(m + 1)
+	day := min(t.Day(), daysIn(month, year))
+	shifted := time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return shifted.Add(d.Duration)
+}
+
+// Add returns the sum of two durations
+func (d XSDDuration) Add(o XSDDuration) XSDDuration {
+	return XSDDuration{Months: d.Months + o.Months, Duration: d.Duration + o.Duration}
+}
+
+// Negate returns the opposite of the duration
+func (d XSDDuration) Negate() XSDDuration {
+	return XSDDuration{Months: -d.Months, Duration: -d.Duration}
+}
+
+// durationReferences are the dates XSD uses to compare durations, chosen so
+// that month lengths differ between them
+var durationReferences = []time.Time{
+	time.Date(1696, 9, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1697, 2, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, 3, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, 7, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// Compare orders two durations, returning -1, 0 or +1, by adding them to
+// the reference dates of XSD. Durations that are not ordered, such as P1M
+// and P30D, compare as equal.
+func (d XSDDuration) Compare(o XSDDuration) int {
+	c := 0
+	for i, ref := range durationReferences {
+		r := d.AddTo(ref).Compare(o.AddTo(ref))
+		if i > 0 && r != c {
+			return 0
+		}
+		c = r
+	}
+	return c
+}
+
+// PartialDate is the value of the xsd:gYear, gYearMonth, gMonth, gDay and
+// gMonthDay literals. The fields that are not part of the datatype are 0,
+// and Zone is nil when the literal has no time zone.
+type PartialDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+	Zone  *time.Location
+}
+
+// Time returns the start of the partial date, using January, the first day
+// of the month and year 0 for the missing fields, and UTC if it has no time
+// zone
+func (p PartialDate) Time() time.Time {
+	month, day, zone := p.Month, p.Day, p.Zone
+	if month == 0 {
+		month = time.January
+	}
+	if day == 0 {
+		day = 1
+	}
+	if zone == nil {
+		zone = time.UTC
+	}
+	return time.Date(p.Year, month, day, 0, 0, 0, 0, zone)
+}
+
+// Compare orders two partial dates field by field, ignoring time zones
+func (p PartialDate) Compare(o PartialDate) int {
+	if c := cmp.Compare(p.Year, o.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(p.Month, o.Month); c != 0 {
+		return c
+	}
+	return cmp.Compare(p.Day, o.Day)
+}
+
+// partialDateFormats gives the pattern of each partial date datatype, with
+// Y, M and D standing for the year, month and day
+var partialDateFormats = map[string]string{
+	"gYear":      "Y",
+	"gYearMonth": "Y-M",
+	"gMonth":     "--M",
+	"gDay":       "---D",
+	"gMonthDay":  "--M-D",
+}
+
+var (
+	partialYearRe = regexp.MustCompile(`^-?(?:[1-9]\d{4,}|\d{4})`)
+	timeZoneRe    = regexp.MustCompile(`(?:Z|[+-](?:(?:0\d|1[0-3]):[0-5]\d|14:00))$`)
+)
+
+// parsePartialDate parses a partial date in the format of a datatype
+func parsePartialDate(format string, s string) (PartialDate, error) {
+	p := PartialDate{}
+	value := s
+	if zone := timeZoneRe.FindString(value); len(zone) > 0 && len(zone) < len(value) {
+		loc, err := parseTimeZone(zone)
+		if err != nil {
+			return p, err
+		}
+		p.Zone = loc
+		value = value[:len(value)-len(zone)]
+	}
+	for i := 0; i < len(format); i++ {
+		var err error
+		switch format[i] {
+		case 'Y':
+			year := partialYearRe.FindString(value)
+			if len(year) == 0 {
+				return p, fmt.Errorf("invalid year in %q", s)
+			}
+			p.Year, err = strconv.Atoi(year)
+			value = value[len(year):]
+		case 'M':
+			var month int
+			month, value, err = twoDigits(value, 1, 12)
+			p.Month = time.Month(month)
+		case 'D':
+			p.Day, value, err = twoDigits(value, 1, 31)
+		default:
+			if len(value) == 0 || value[0] != format[i] {
+				err = fmt.Errorf("expected %q", format[i])
+			} else {
+				value = value[1:]
+			}
+		}
+		if err != nil {
+			return p, fmt.Errorf("invalid value %q: %w", s, err)
+		}
+	}
+	if len(value) > 0 {
+		return p, fmt.Errorf("invalid value %q", s)
+	}
+	if p.Day > 0 && p.Month > 0 && p.Day > daysIn(p.Month, 2000) {
+		return p, fmt.Errorf("invalid day in %q", s)
+	}
+	return p, nil
+}
+
+// formatPartialDate formats a partial date in the format of a datatype
+func formatPartialDate(format string, p PartialDate) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		switch format[i] {
+		case 'Y':
+			if p.Year < 0 {
+				fmt.Fprintf(&b, "-%04d", -p.Year)
+			} else {
+				fmt.Fprintf(&b, "%04d", p.Year)
+			}
+		case 'M':
+			fmt.Fprintf(&b, "%02d", int(p.Month))
+		case 'D':
+			fmt.Fprintf(&b, "%02d", p.Day)
+		default:
+			b.WriteByte(format[i])
+		}
+	}
+	if p.Zone != nil {
+		b.WriteString(time.Date(2000, 1, 1, 0, 0, 0, 0, p.Zone).Format("Z07:00"))
+	}
+	return b.String()
+}
+
+// twoDigits reads a two digits number between min and max
+func twoDigits(s string, min int, max int) (int, string, error) {
+	if len(s) < 2 {
+		return 0, s, fmt.Errorf("expected two digits")
+	}
+	n, err := strconv.Atoi(s[:2])
+	if err != nil || n < min || n > max {
+		return 0, s, fmt.Errorf("expected a number between %02d and %02d", min, max)
+	}
+	return n, s[2:], nil
+}
+
+// parseTimeZone returns a location with the offset of a time zone
+func parseTimeZone(zone string) (*time.Location, error) {
+	if zone == "Z" {
+		return time.UTC, nil
+	}
+	t, err := time.Parse("-07:00", zone)
+	if err != nil {
+		return nil, err
+	}
+	_, offset := t.Zone()
+	return time.FixedZone(zone, offset), nil
+}
+
+// daysIn returns the number of days of a month
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func init() {
+	durationCodec := func(name string, check func(XSDDuration) bool) *DatatypeCodec {
+		return &DatatypeCodec{
+			Parse: func(value string) (interface{}, error) {
+				d, err := ParseXSDDuration(value)
+				if err == nil && !check(d) {
+					err = fmt.Errorf("invalid %s %q", name, value)
+				}
+				return d, err
+			},
+			Format: func(v interface{}) (string, error) {
+				switch v := v.(type) {
+				case XSDDuration:
+					if check(v) {
+						return v.String(), nil
+					}
+				case time.Duration:
+					if d := (XSDDuration{Duration: v}); check(d) {
+						return d.String(), nil
+					}
+				}
+				return "", fmt.Errorf("cannot format %v as a %s", v, name)
+			},
+			Compare: func(a, b interface{}) int { return a.(XSDDuration).Compare(b.(XSDDuration)) },
+		}
+	}
+	RegisterDatatype(nsXSD+"duration", durationCodec("duration", func(XSDDuration) bool { return true }))
+	RegisterDatatype(nsXSD+"dayTimeDuration", durationCodec("dayTimeDuration", func(d XSDDuration) bool { return d.Months == 0 }))
+	RegisterDatatype(nsXSD+"yearMonthDuration", durationCodec("yearMonthDuration", func(d XSDDuration) bool { return d.Duration == 0 }))
+	for name, format := range partialDateFormats {
+		name, format := name, format
+		RegisterDatatype(nsXSD+name, &DatatypeCodec{
+			Parse: func(value string) (interface{}, error) {
+				return parsePartialDate(format, value)
+			},
+			Format: func(v interface{}) (string, error) {
+				p, ok := v.(PartialDate)
+				if !ok {
+					return "", fmt.Errorf("cannot format %T as a %s", v, name)
+				}
+				return formatPartialDate(format, p), nil
+			},
+			Compare: func(a, b interface{}) int { return a.(PartialDate).Compare(b.(PartialDate)) },
+		})
+	}
+	RegisterDatatype(nsXSD+"time", timeCodec("15:04:05.999999999Z07:00", "time", "15:04:05"))
+}
@@ -0,0 +1,98 @@
+package rdf2go
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseXSDDuration(t *testing.T) {
+	d, err := ParseXSDDuration("P1Y2M3DT4H5M6.5S")
+	require.NoError(t, err)
+	assert.Equal(t, 14, d.Months)
+	assert.Equal(t, 3*24*time.Hour+4*time.Hour+5*time.Minute+6500*time.Millisecond, d.Duration)
+	assert.Equal(t, "P1Y2M3DT4H5M6.5S", d.String())
+
+	for in, out := range map[string]string{
+		"-P13M":   "-P1Y1M",
+		"PT36H":   "P1DT12H",
+		"P0D":     "PT0S",
+		"PT0.25S": "PT0.25S",
+		"P2D":     "P2D",
+	} {
+		d, err := ParseXSDDuration(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, out, d.String(), in)
+	}
+	for _, in := range []string{"", "P", "PT", "P1DT", "1D", "P-1D", "PT1.S"} {
+		_, err := ParseXSDDuration(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestXSDDurationArithmetic(t *testing.T) {
+	month, _ := ParseXSDDuration("P1M")
+	days, _ := ParseXSDDuration("P30D")
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), month.AddTo(start))
+	assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), month.Negate().AddTo(start))
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), month.Add(days).AddTo(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	thirteen, _ := ParseXSDDuration("-P13M")
+	assert.Equal(t, time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC), thirteen.AddTo(start))
+	assert.Equal(t, 0, month.Compare(days))
+	year, _ := ParseXSDDuration("P1Y")
+	assert.Equal(t, 1, year.Compare(days))
+	assert.Equal(t, -1, days.Compare(year))
+}
+
+func TestPartialDates(t *testing.T) {
+	for datatype, cases := range map[string]map[string]PartialDate{
+		"gYear":      {"2024": {Year: 2024}, "-0044": {Year: -44}, "12345Z": {Year: 12345, Zone: time.UTC}},
+		"gYearMonth": {"2024-03": {Year: 2024, Month: time.March}},
+		"gMonth":     {"--12": {Month: time.December}},
+		"gDay":       {"---05": {Day: 5}},
+		"gMonthDay":  {"--02-29": {Month: time.February, Day: 29}},
+	} {
+		for in, want := range cases {
+			l := NewLiteralWithDatatype(in, NewResource(nsXSD+datatype))
+			v, err := LiteralValue(l)
+			require.NoError(t, err, in)
+			assert.Equal(t, want, v, in)
+			back, err := NewTypedLiteral(v, nsXSD+datatype)
+			require.NoError(t, err)
+			assert.Equal(t, in, back.RawValue())
+		}
+	}
+	v, err := LiteralValue(NewLiteralWithDatatype("2024+02:00", NewResource(nsXSD+"gYear")))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("", 7200)).Unix(), v.(PartialDate).Time().Unix())
+
+	for datatype, in := range map[string]string{"gYear": "24", "gMonth": "--13", "gMonthDay": "--02-30", "gDay": "--05"} {
+		_, err := LiteralValue(NewLiteralWithDatatype(in, NewResource(nsXSD+datatype)))
+		assert.Error(t, err, in)
+	}
+
+	c, err := CompareLiterals(NewLiteralWithDatatype("--03-01", NewResource(nsXSD+"gMonthDay")),
+		NewLiteralWithDatatype("--02-28", NewResource(nsXSD+"gMonthDay")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+}
+
+func TestXSDTimeLiterals(t *testing.T) {
+	v, err := LiteralValue(NewLiteralWithDatatype("13:20:00.5", NewResource(nsXSD+"time")))
+	require.NoError(t, err)
+	assert.Equal(t, "13:20:00.5Z", v.(time.Time).Format("15:04:05.999999999Z07:00"))
+	v, err = LiteralValue(NewLiteralWithDatatype("2024-03-01T12:00:00", NewResource(nsXSD+"dateTime")))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), v)
+	d, err := LiteralValue(NewLiteralWithDatatype("PT1H30M", NewResource(nsXSD+"dayTimeDuration")))
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d.(XSDDuration).Duration)
+	_, err = LiteralValue(NewLiteralWithDatatype("P1M", NewResource(nsXSD+"dayTimeDuration")))
+	assert.Error(t, err)
+	l, err := NewTypedLiteral(90*time.Minute, nsXSD+"dayTimeDuration")
+	require.NoError(t, err)
+	assert.Equal(t, "PT1H30M", l.RawValue())
+}